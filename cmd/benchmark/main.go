@@ -13,6 +13,7 @@ package main
 import (
 	"bytes"
 	"compress/flate"
+	"encoding/binary"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -90,13 +91,14 @@ type BenchmarkResult struct {
 	OriginalB   int    `json:"original_bytes"`
 
 	// Compression results (compressed size in bytes)
-	EnzSize     int    `json:"enz_size"`
-	EnzMethod   string `json:"enz_method"`
-	ZipSize     int    `json:"zip_size"`
-	GzipSize    int    `json:"gzip_size"`
-	DeflateOnly int    `json:"deflate_only"` // Raw DEFLATE (no container)
-	BpelateOnly int    `json:"bpelate_only"` // Raw BPELATE (no container)
-	UnzlateOnly int    `json:"unzlate_only"` // Raw UNZLATE (no container)
+	EnzSize     int     `json:"enz_size"`
+	EnzMethod   string  `json:"enz_method"`
+	EnzMillis   float64 `json:"enz_ms"` // time enz took to compress
+	ZipSize     int     `json:"zip_size"`
+	GzipSize    int     `json:"gzip_size"`
+	DeflateOnly int     `json:"deflate_only"` // Raw DEFLATE (no container)
+	BpelateOnly int     `json:"bpelate_only"` // Raw BPELATE (no container)
+	UnzlateOnly int     `json:"unzlate_only"` // Raw UNZLATE (no container)
 
 	// Derived metrics
 	EnzRatio  float64 `json:"enz_ratio"` // Compression ratio (0-1, lower is better)
@@ -105,6 +107,14 @@ type BenchmarkResult struct {
 	EnzVsZip  float64 `json:"enz_vs_zip"` // % improvement over zip (positive = enz wins)
 	EnzVsGzip float64 `json:"enz_vs_gzip"`
 	Winner    string  `json:"winner"` // "enz", "zip", or "gzip"
+
+	// Method prediction of enz (text and code only), against trials of
+	// both methods
+	Predicted    string  `json:"predicted,omitempty"`     // method predicted smaller
+	PredictRatio float64 `json:"predict_ratio,omitempty"` // estimated Bpelate size over DEFLATE size
+	PredictSure  bool    `json:"predict_sure"`            // trials skipped on the strength of it
+	Mispredicted bool    `json:"mispredicted"`
+	PredictLoss  int     `json:"predict_loss"` // bytes the misprediction cost
 }
 
 // Report holds the complete benchmark report
@@ -126,6 +136,12 @@ type ReportSummary struct {
 	AvgEnzVsZip float64               `json:"avg_enz_vs_zip"`
 	ByCategory  map[string]CatSummary `json:"by_category"`
 	BySizeKB    map[int]SizeSummary   `json:"by_size_kb"`
+
+	Predictions  int     `json:"predictions"`  // results with a method prediction
+	PredictSure  int     `json:"predict_sure"` // of which sure enough to skip trials
+	Mispredicted int     `json:"mispredicted"`
+	PredictLoss  int     `json:"predict_loss"` // bytes mispredictions cost
+	EnzMBps      float64 `json:"enz_mb_per_s"` // enz compression throughput
 }
 
 type CatSummary struct {
@@ -216,6 +232,9 @@ func main() {
 	fmt.Printf("enz wins:    %d (%.1f%%)\n", report.Summary.EnzWins, 100*float64(report.Summary.EnzWins)/float64(report.Summary.TotalTests))
 	fmt.Printf("zip wins:    %d (%.1f%%)\n", report.Summary.ZipWins, 100*float64(report.Summary.ZipWins)/float64(report.Summary.TotalTests))
 	fmt.Printf("Avg enz vs zip: %.1f%%\n", report.Summary.AvgEnzVsZip)
+	fmt.Printf("Predictions: %d sure of %d, %d mispredicted (%d bytes)\n", report.Summary.PredictSure,
+		report.Summary.Predictions, report.Summary.Mispredicted, report.Summary.PredictLoss)
+	fmt.Printf("enz speed:   %.1f MB/s\n", report.Summary.EnzMBps)
 }
 
 func runBenchmark(comp *compress.Compressor, ct ContentType, sizeKB int) BenchmarkResult {
@@ -231,12 +250,33 @@ func runBenchmark(comp *compress.Compressor, ct ContentType, sizeKB int) Benchma
 	}
 
 	// Test enz (our compressor)
+	start := time.Now()
 	enzZip, err := comp.CompressFile(data, "test"+ct.FileExt, time.Now())
+	result.EnzMillis = float64(time.Since(start).Microseconds()) / 1000
 	if err == nil {
 		result.EnzSize = len(enzZip)
 		result.EnzMethod = detectMethod(enzZip)
 	}
 
+	// Method prediction, checked against every method. Explain selects
+	// through the same code as CompressFile; the choice must be the one
+	// just timed, or the accuracy would describe other code.
+	e := comp.Explain(data, "test"+ct.FileExt)
+	if err == nil && (e.Method.String() != result.EnzMethod || e.CompSize != compressedSize(enzZip)) {
+		fmt.Fprintf(os.Stderr, "%s %dKB: Explain chose %v (%d bytes), CompressFile %s (%d bytes)\n",
+			ct.ID, sizeKB, e.Method, e.CompSize, result.EnzMethod, compressedSize(enzZip))
+		os.Exit(1)
+	}
+	if e.Prediction != nil {
+		result.Predicted = e.Prediction.Method.String()
+		result.PredictRatio = e.Prediction.Ratio
+		result.PredictSure = e.Prediction.Sure
+		if e.Mispredicted() {
+			result.Mispredicted = true
+			result.PredictLoss = predictionLoss(e)
+		}
+	}
+
 	// Test zip -9 (standard ZIP with DEFLATE)
 	result.ZipSize = testZip(data, ct.FileExt)
 
@@ -275,6 +315,32 @@ func runBenchmark(comp *compress.Compressor, ct ContentType, sizeKB int) Benchma
 	return result
 }
 
+// predictionLoss returns how much larger than the other the method
+// predicted smaller of DEFLATE and Bpelate is.
+func predictionLoss(e compress.Explanation) int {
+	var deflate, bpelate int64
+	for _, c := range e.Candidates {
+		switch {
+		case c.Method == compress.MethodDEFLATE:
+			deflate = c.Size
+		case c.Method == compress.MethodBPELATE && c.Vocab == e.Prediction.Vocab:
+			bpelate = c.Size
+		}
+	}
+	if e.Prediction.Method == compress.MethodDEFLATE {
+		return int(deflate - bpelate)
+	}
+	return int(bpelate - deflate)
+}
+
+// compressedSize returns the compressed size in the first local header.
+func compressedSize(zipData []byte) int64 {
+	if len(zipData) < 30 {
+		return -1
+	}
+	return int64(binary.LittleEndian.Uint32(zipData[18:22]))
+}
+
 func detectMethod(zipData []byte) string {
 	if len(zipData) < 30 {
 		return "unknown"
@@ -290,6 +356,10 @@ func detectMethod(zipData []byte) string {
 		return "Unzlate"
 	case 86:
 		return "Bpelate"
+	case 87:
+		return "Mixlate"
+	case 88:
+		return "Exelate"
 	default:
 		return fmt.Sprintf("Method%d", method)
 	}
@@ -360,10 +430,24 @@ func calculateSummary(results []BenchmarkResult) ReportSummary {
 		BySizeKB:   make(map[int]SizeSummary),
 	}
 
-	var totalEnzVsZip float64
+	var totalEnzVsZip, totalMillis float64
+	var totalBytes int
 
 	for _, r := range results {
 		totalEnzVsZip += r.EnzVsZip
+		totalMillis += r.EnzMillis
+		totalBytes += r.OriginalB
+
+		if r.Predicted != "" {
+			summary.Predictions++
+			if r.PredictSure {
+				summary.PredictSure++
+			}
+			if r.Mispredicted {
+				summary.Mispredicted++
+				summary.PredictLoss += r.PredictLoss
+			}
+		}
 
 		if r.Winner == "enz" {
 			summary.EnzWins++
@@ -396,6 +480,9 @@ func calculateSummary(results []BenchmarkResult) ReportSummary {
 	if len(results) > 0 {
 		summary.AvgEnzVsZip = totalEnzVsZip / float64(len(results))
 	}
+	if totalMillis > 0 {
+		summary.EnzMBps = float64(totalBytes) / (1 << 20) / (totalMillis / 1000)
+	}
 
 	for k, v := range summary.ByCategory {
 		if v.Tests > 0 {
@@ -1115,6 +1202,18 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="value">{{pct .Summary.AvgEnzVsZip}}%</div>
                 <div class="label">Avg enz vs zip</div>
             </div>
+            <div class="summary-card">
+                <div class="value">{{.Summary.PredictSure}}/{{.Summary.Predictions}}</div>
+                <div class="label">Sure Method Predictions</div>
+            </div>
+            <div class="summary-card {{if .Summary.Mispredicted}}loss{{else}}win{{end}}">
+                <div class="value">{{.Summary.Mispredicted}}</div>
+                <div class="label">Mispredicted ({{.Summary.PredictLoss}} bytes)</div>
+            </div>
+            <div class="summary-card">
+                <div class="value">{{printf "%.1f" .Summary.EnzMBps}}</div>
+                <div class="label">enz MB/s</div>
+            </div>
         </div>
 
         <div class="tabs">
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/ha1tch/unz/pkg/compress"
+)
+
+// unixAttrs reads the owner, access and change times and extended
+// attributes of an entry. Symlinks get no extended attributes, since the
+// syscall package can only read them through the link.
+func unixAttrs(entry fileEntry) compress.UnixAttrs {
+	st, ok := entry.info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return compress.UnixAttrs{}
+	}
+
+	attrs := compress.UnixAttrs{
+		Atime:    time.Unix(st.Atim.Unix()),
+		Ctime:    time.Unix(st.Ctim.Unix()),
+		HasOwner: true,
+		UID:      int(st.Uid),
+		GID:      int(st.Gid),
+	}
+	if entry.info.Mode()&os.ModeSymlink == 0 {
+		attrs.Xattrs = readXattrs(entry.path)
+	}
+	return attrs
+}
+
+// readXattrs returns the extended attributes of path, or nil if it has none
+// or the filesystem does not support them.
+func readXattrs(path string) map[string][]byte {
+	size, err := syscall.Listxattr(path, nil)
+	if err != nil || size == 0 {
+		return nil
+	}
+	list := make([]byte, size)
+	if size, err = syscall.Listxattr(path, list); err != nil {
+		return nil
+	}
+
+	xattrs := make(map[string][]byte)
+	for _, name := range bytes.Split(list[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		n, err := syscall.Getxattr(path, string(name), nil)
+		if err != nil {
+			continue
+		}
+		value := make([]byte, n)
+		if n, err = syscall.Getxattr(path, string(name), value); err != nil {
+			continue
+		}
+		xattrs[string(name)] = value[:n]
+	}
+	if len(xattrs) == 0 {
+		return nil
+	}
+	return xattrs
+}
+
+// fileID identifies a file by device and inode.
+type fileID struct{ dev, ino uint64 }
+
+// hardLinkID returns the identity of a file with more than one link.
+func hardLinkID(info os.FileInfo) (fileID, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || st.Nlink < 2 {
+		return fileID{}, false
+	}
+	return fileID{uint64(st.Dev), st.Ino}, true
+}
+
+// deviceNumber splits a device node's number into major and minor, as
+// glibc's major() and minor() do.
+func deviceNumber(info os.FileInfo) (major, minor uint32) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0
+	}
+	rdev := uint64(st.Rdev)
+	major = uint32((rdev>>8)&0xfff | (rdev>>32)&^0xfff)
+	minor = uint32(rdev&0xff | (rdev>>12)&^0xff)
+	return major, minor
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package main
+
+import (
+	"os"
+
+	"github.com/ha1tch/unz/pkg/compress"
+)
+
+// unixAttrs is only implemented on Linux; elsewhere entries carry just the
+// mode and mtime.
+func unixAttrs(entry fileEntry) compress.UnixAttrs {
+	return compress.UnixAttrs{}
+}
+
+// fileID identifies a file by device and inode.
+type fileID struct{ dev, ino uint64 }
+
+// hardLinkID is only implemented on Linux; elsewhere hard links are stored
+// as separate copies.
+func hardLinkID(info os.FileInfo) (fileID, bool) {
+	return fileID{}, false
+}
+
+// deviceNumber is only implemented on Linux.
+func deviceNumber(info os.FileInfo) (major, minor uint32) {
+	return 0, 0
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ha1tch/unz/pkg/compress"
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+// explainReport is the JSON form of a compress.Explanation, with names
+// instead of numeric codes.
+type explainReport struct {
+	Name       string             `json:"name"`
+	Size       int64              `json:"size"`
+	Profile    explainProfile     `json:"profile"`
+	Candidates []explainCandidate `json:"candidates"`
+	Method     string             `json:"method"`
+	Vocab      string             `json:"vocabulary,omitempty"`
+	CompSize   int64              `json:"compressed_size"`
+	Prediction *explainPrediction `json:"prediction,omitempty"`
+}
+
+type explainProfile struct {
+	Type               string           `json:"type"`
+	Language           string           `json:"language"`
+	LanguageConfidence float64          `json:"language_confidence"`
+	LanguageScores     []explainScore   `json:"language_scores,omitempty"`
+	DataFormat         string           `json:"data_format"`
+	Binary             string           `json:"binary"`
+	Markup             string           `json:"markup"`
+	NatLang            string           `json:"natural_language"`
+	NatLangConfidence  float64          `json:"natural_language_confidence"`
+	NatLangScores      []explainScore   `json:"natural_language_scores,omitempty"`
+	Segments           []explainSegment `json:"segments,omitempty"`
+	Entropy            float64          `json:"entropy"`
+	ASCIIRatio         float64          `json:"ascii_ratio"`
+	UniqueBytes        int              `json:"unique_bytes"`
+	RepetitionRate     float64          `json:"repetition_rate"`
+	CodeScore          float64          `json:"code_score"`
+}
+
+type explainScore struct {
+	Lang  string  `json:"lang"`
+	Score float64 `json:"score"`
+}
+
+type explainSegment struct {
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+	Type     string `json:"type"`
+	Language string `json:"language,omitempty"`
+	Format   string `json:"data_format,omitempty"`
+}
+
+type explainPrediction struct {
+	Method       string  `json:"method"`
+	Vocab        string  `json:"vocabulary"`
+	Ratio        float64 `json:"ratio"` // estimated Bpelate size over DEFLATE size
+	Sure         bool    `json:"sure"`
+	Mispredicted bool    `json:"mispredicted"`
+}
+
+type explainCandidate struct {
+	Method string `json:"method"`
+	Vocab  string `json:"vocabulary,omitempty"`
+	Size   int64  `json:"size"`
+	Auto   bool   `json:"auto"`
+	Error  string `json:"error,omitempty"`
+}
+
+// vocabName names the vocabulary of a candidate, or returns "" if the
+// method uses none.
+func vocabName(method compress.Method, lang compress.ProgLang) string {
+	switch {
+	case method == compress.MethodMIXLATE:
+		return "per segment"
+	case method != compress.MethodBPELATE && method != compress.MethodUNZLATE:
+		return ""
+	case lang == compress.ProgLangNone:
+		return "text"
+	}
+	return lang.String()
+}
+
+func makeExplainReport(e compress.Explanation) explainReport {
+	p := e.Profile
+	r := explainReport{
+		Name:     e.Name,
+		Size:     e.Size,
+		Method:   e.Method.String(),
+		Vocab:    vocabName(e.Method, e.Vocab.ProgLang),
+		CompSize: e.CompSize,
+		Profile: explainProfile{
+			Type:               p.Type.String(),
+			Language:           p.Language.String(),
+			LanguageConfidence: p.LanguageConfidence,
+			DataFormat:         p.DataFmt.String(),
+			Binary:             p.Binary.String(),
+			Markup:             p.Markup.String(),
+			NatLang:            p.NatLang.String(),
+			NatLangConfidence:  p.NatLangConfidence,
+			Entropy:            p.Entropy,
+			ASCIIRatio:         p.ASCIIRatio,
+			UniqueBytes:        p.UniqueBytes,
+			RepetitionRate:     p.RepetitionRate,
+			CodeScore:          p.CodeScore,
+		},
+	}
+	for _, s := range p.LanguageScores {
+		r.Profile.LanguageScores = append(r.Profile.LanguageScores, explainScore{s.Lang.String(), s.Score})
+	}
+	for _, s := range p.NatLangScores {
+		r.Profile.NatLangScores = append(r.Profile.NatLangScores, explainScore{s.Lang.String(), s.Score})
+	}
+	for _, s := range p.Segments {
+		seg := explainSegment{Offset: s.Offset, Length: s.Length, Type: s.Type.String()}
+		if s.Language != detect.CodeLangUnknown {
+			seg.Language = s.Language.String()
+		}
+		if s.DataFmt != detect.DataFormatNone {
+			seg.Format = s.DataFmt.String()
+		}
+		r.Profile.Segments = append(r.Profile.Segments, seg)
+	}
+	if pred := e.Prediction; pred != nil {
+		r.Prediction = &explainPrediction{
+			Method:       pred.Method.String(),
+			Vocab:        vocabName(compress.MethodBPELATE, pred.Vocab),
+			Ratio:        pred.Ratio,
+			Sure:         pred.Sure,
+			Mispredicted: e.Mispredicted(),
+		}
+	}
+	for _, c := range e.Candidates {
+		cand := explainCandidate{
+			Method: c.Method.String(),
+			Vocab:  vocabName(c.Method, c.Vocab),
+			Size:   c.Size,
+			Auto:   c.Auto,
+		}
+		if c.Err != nil {
+			cand.Error = c.Err.Error()
+		}
+		r.Candidates = append(r.Candidates, cand)
+	}
+	return r
+}
+
+// writeExplanations writes the -explain report: a table per entry, or with
+// -json, one JSON array.
+func writeExplanations(w io.Writer, explanations []compress.Explanation, asJSON bool) error {
+	if asJSON {
+		reports := make([]explainReport, 0, len(explanations))
+		for _, e := range explanations {
+			reports = append(reports, makeExplainReport(e))
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+
+	for i, e := range explanations {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		writeExplanation(w, makeExplainReport(e))
+	}
+	return nil
+}
+
+// writeExplanation writes the table of one entry. The chosen candidate is
+// marked with "*"; candidates automatic selection did not try are noted.
+func writeExplanation(w io.Writer, r explainReport) {
+	p := r.Profile
+	fmt.Fprintf(w, "%s: %d bytes, %s", r.Name, r.Size, p.Type)
+	if p.Language != detect.CodeLangUnknown.String() {
+		fmt.Fprintf(w, ", %s %.2f", p.Language, p.LanguageConfidence)
+		for i, s := range p.LanguageScores {
+			if i == 0 || i > 2 {
+				continue
+			}
+			fmt.Fprintf(w, " (%s %.2f)", s.Lang, s.Score)
+		}
+	}
+	for _, field := range []string{p.DataFormat, p.Binary, p.Markup} {
+		if field != "None" {
+			fmt.Fprintf(w, ", %s", field)
+		}
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "  %s %.2f, entropy %.2f, ascii %.2f, %d unique bytes, repetition %.2f, code score %.2f",
+		p.NatLang, p.NatLangConfidence, p.Entropy, p.ASCIIRatio, p.UniqueBytes, p.RepetitionRate, p.CodeScore)
+	if len(p.Segments) > 0 {
+		kinds := make([]string, len(p.Segments))
+		for i, s := range p.Segments {
+			kinds[i] = s.Type
+			if s.Language != "" {
+				kinds[i] = s.Language
+			} else if s.Format != "" {
+				kinds[i] = s.Format
+			}
+		}
+		fmt.Fprintf(w, ", segments %s", strings.Join(kinds, " "))
+	}
+	fmt.Fprintln(w)
+
+	if pred := r.Prediction; pred != nil {
+		fmt.Fprintf(w, "  predicted %s: Bpelate (%s) estimated at %.3f of Deflate", pred.Method, pred.Vocab, pred.Ratio)
+		switch {
+		case pred.Mispredicted:
+			fmt.Fprint(w, ", mispredicted")
+		case !pred.Sure:
+			fmt.Fprint(w, ", too close to call")
+		}
+		fmt.Fprintln(w)
+	}
+
+	for _, c := range r.Candidates {
+		mark := " "
+		if c.Auto && c.Method == r.Method && c.Vocab == r.Vocab && c.Size == r.CompSize {
+			mark = "*"
+		}
+		vocab := c.Vocab
+		if vocab == "" {
+			vocab = "-"
+		}
+		note := ""
+		switch {
+		case c.Error != "":
+			note = "  " + c.Error
+		case !c.Auto:
+			note = "  not tried"
+		}
+		ratio := float64(100)
+		if r.Size > 0 {
+			ratio = float64(c.Size) * 100 / float64(r.Size)
+		}
+		fmt.Fprintf(w, "%s %-8s %-12s %10d %6.1f%%%s\n", mark, c.Method, vocab, c.Size, ratio, note)
+	}
+}
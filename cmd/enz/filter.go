@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// patternList collects the values of a repeatable pattern flag (-x, -i).
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, " ")
+}
+
+func (p *patternList) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// ignoreFiles are read in every directory walked with -gitignore.
+var ignoreFiles = []string{".gitignore", ".unzignore"}
+
+// globMatch reports whether a slash-separated name matches pattern. Each
+// path segment is matched with path.Match, and a "**" segment matches any
+// number of segments, including none. Patterns without a slash match the
+// last segment of name, at any depth.
+func globMatch(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(strings.TrimSuffix(name, "/"))
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// globMatchAny reports whether name matches any of patterns. Directories
+// are also tried with a trailing slash, so "dir/**" covers dir itself.
+func globMatchAny(patterns []string, name string, isDir bool) bool {
+	for _, pattern := range patterns {
+		if globMatch(pattern, name) || isDir && globMatch(pattern, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// selected applies -x (and --exclude-from) and -i to an archive name.
+// Excluded directories are not walked; with -i, directories are walked but
+// only stored if they match.
+func selected(name string, isDir bool) bool {
+	if globMatchAny(excludes, name, isDir) {
+		return false
+	}
+	return len(includes) == 0 || globMatchAny(includes, name, isDir)
+}
+
+// readPatterns reads one pattern per line from a file, skipping blank
+// lines and lines starting with "#".
+func readPatterns(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, scanner.Err()
+}
+
+// ignoreRule is one line of a .gitignore or .unzignore file.
+type ignoreRule struct {
+	pattern  string
+	negate   bool // "!pattern" re-includes
+	dirOnly  bool // "pattern/" only matches directories
+	anchored bool // pattern with a slash is relative to the file's directory
+}
+
+// parseIgnoreRules parses gitignore syntax: blank lines and "#" comments
+// are skipped, "\#" and "\!" escape a leading character, "!" negates, a
+// trailing "/" restricts the rule to directories and any other slash
+// anchors the pattern to the directory holding the file.
+func parseIgnoreRules(data string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		var rule ignoreRule
+		if line[0] == '!' {
+			rule.negate = true
+			line = line[1:]
+		} else if line[0] == '\\' {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ignoreMatcher holds the ignore rules found while walking a tree, by
+// directory relative to the walk root ("." for the root itself).
+type ignoreMatcher struct {
+	root  string
+	rules map[string][]ignoreRule
+}
+
+func newIgnoreMatcher(root string) *ignoreMatcher {
+	return &ignoreMatcher{root: root, rules: make(map[string][]ignoreRule)}
+}
+
+// load reads the ignore files of a directory about to be walked.
+func (m *ignoreMatcher) load(dir string) {
+	var rules []ignoreRule
+	for _, file := range ignoreFiles {
+		data, err := os.ReadFile(filepath.Join(m.root, filepath.FromSlash(dir), file))
+		if err == nil {
+			rules = append(rules, parseIgnoreRules(string(data))...)
+		}
+	}
+	if len(rules) > 0 {
+		m.rules[dir] = rules
+	}
+}
+
+// ignored reports whether rel, a slash-separated path relative to the
+// root, is ignored. Files in outer directories are applied first, so rules
+// closer to the path override them, and within a file the last matching
+// rule wins, as in git.
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	if isDir && path.Base(rel) == ".git" {
+		return true
+	}
+	ignored := false
+	dir := "."
+	for {
+		sub := rel
+		if dir != "." {
+			sub = strings.TrimPrefix(rel, dir+"/")
+		}
+		for _, rule := range m.rules[dir] {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			pattern := rule.pattern
+			if !rule.anchored {
+				pattern = "**/" + pattern
+			}
+			if matchSegments(strings.Split(pattern, "/"), strings.Split(sub, "/")) {
+				ignored = !rule.negate
+			}
+		}
+		next := strings.IndexByte(sub, '/')
+		if next < 0 {
+			return ignored
+		}
+		if dir == "." {
+			dir = sub[:next]
+		} else {
+			dir += "/" + sub[:next]
+		}
+	}
+}
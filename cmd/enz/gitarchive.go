@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ha1tch/unz/pkg/compress"
+	"github.com/ha1tch/unz/pkg/git"
+)
+
+// archiveGit writes the tree of a git revision to an archive, like
+// git archive: entries come straight from the object database (no
+// checkout), every entry gets the commit time, and the commit ID is stored
+// as the archive comment.
+func archiveGit(comp *compress.Compressor, archivePath, repoDir, rev string) {
+	repo, err := git.Open(repoDir)
+	if err != nil {
+		fatal("%v", err)
+	}
+	defer repo.Close()
+
+	tree, commit, err := repo.ResolveTree(rev)
+	if err != nil {
+		fatal("%v", err)
+	}
+	modTime := time.Now()
+	comment := ""
+	if commit != nil {
+		modTime = commit.CommitterTime
+		comment = commit.ID.String()
+	}
+
+	archive := compress.NewArchive(comp)
+	if pass := password(); pass != "" {
+		archive.SetPassword(pass)
+	}
+	archive.SetSHA256(*storeSHA256)
+	archive.SetExplain(*explain)
+	if *reproducible {
+		epoch, err := compress.SourceDateEpoch()
+		if err != nil {
+			fatal("%v", err)
+		}
+		archive.SetReproducible(epoch)
+	}
+	sign(archive)
+
+	verb := func(name, note string) {
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "%8s: %s%s\n", "adding", name, note)
+		}
+	}
+
+	prefix := *gitPrefix
+	if prefix != "" {
+		prefix = strings.TrimSuffix(prefix, "/") + "/"
+		verb(prefix, "")
+		archive.AddDirectory(prefix, modTime, 0755)
+	}
+
+	var totalIn int64
+	start := time.Now()
+	err = repo.Walk(tree, func(path string, entry git.TreeEntry) error {
+		name := prefix + path
+		if !selected(name, entry.IsTree()) {
+			if entry.IsTree() && globMatchAny(excludes, name, true) {
+				return git.SkipTree
+			}
+			return nil
+		}
+
+		switch entry.Mode {
+		case git.ModeTree, git.ModeSubmodule:
+			// Submodules are not followed; git archive stores them as
+			// empty directories too
+			verb(name+"/", "")
+			return archive.AddDirectory(name, modTime, 0755)
+		}
+
+		typ, data, err := repo.Object(entry.ID)
+		if err != nil {
+			return err
+		}
+		if typ != git.ObjectBlob {
+			return fmt.Errorf("%s: expected a blob, found a %s", name, typ)
+		}
+		totalIn += int64(len(data))
+
+		switch entry.Mode {
+		case git.ModeSymlink:
+			verb(name, " (symlink -> "+string(data)+")")
+			return archive.AddSymlink(name, string(data), modTime, 0777)
+		case git.ModeExecutable:
+			verb(name, "")
+			return addData(archive, data, name, modTime, 0755)
+		default:
+			verb(name, "")
+			return addData(archive, data, name, modTime, 0644)
+		}
+	})
+	if err != nil {
+		fatal("%v", err)
+	}
+
+	if *zipComment {
+		comment = strings.TrimPrefix(comment+"\n"+readComment(), "\n")
+	}
+	if err := archive.SetComment(comment); err != nil {
+		fatal("%v", err)
+	}
+
+	output, err := archive.Bytes()
+	if err != nil {
+		fatal("cannot create archive: %v", err)
+	}
+	writeArchive(archivePath, output)
+	writeReport(archive)
+
+	if *verbose {
+		ratio := float64(0)
+		if totalIn > 0 {
+			ratio = 100 - (float64(len(output)) * 100 / float64(totalIn))
+		}
+		fmt.Fprintf(os.Stderr, "total %d bytes -> %d bytes (%.1f%%) in %v\n",
+			totalIn, len(output), ratio, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// addData adds a file's content, compressed unless -0 is given.
+func addData(archive *compress.Archive, data []byte, name string, modTime time.Time, mode os.FileMode) error {
+	if *level0 {
+		return archive.AddStore(data, name, modTime, mode)
+	}
+	return archive.AddWith(data, name, modTime, mode, addOptions(name))
+}
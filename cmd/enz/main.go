@@ -2,7 +2,10 @@
 //
 // Usage matches zip(1):
 //
-//	enz [-0|-9] [-r] [-q] [-v] [-m] [-j] archive.zip file...
+//	enz [-0|-1...-9] [-r] [-q] [-v] [-m] [-j] [-X] [-u|-f] [-c] [-z] [-x pat] [-i pat] [-gitignore] [-filetypes file] [-sample mode] [-method [pat=]m] [-vocab [pat=]v] [-explain [-json]] [-sha256] [-reproducible] [-e|-P password] [-sign key.pem] archive.zip file...
+//	enz -git rev [-prefix dir/] archive.zip [repository]
+//	enz -z archive.zip
+//	enz -d archive.zip name...
 package main
 
 import (
@@ -10,25 +13,83 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ha1tch/unz/pkg/compress"
+	"github.com/ha1tch/unz/pkg/detect"
 	"github.com/ha1tch/unz/pkg/vocab"
 )
 
 var (
 	level0       = flag.Bool("0", false, "store only (no compression)")
-	level9       = flag.Bool("9", false, "best compression (default)")
 	recursive    = flag.Bool("r", false, "recurse into directories")
 	storeSymlink = flag.Bool("y", false, "store symbolic links as links (default: follow links)")
 	quiet        = flag.Bool("q", false, "quiet operation")
 	verbose      = flag.Bool("v", false, "verbose operation")
 	move         = flag.Bool("m", false, "move into archive (delete input files)")
 	junkPaths    = flag.Bool("j", false, "junk (don't record) directory names")
+	update       = flag.Bool("u", false, "update: add new files and replace changed ones")
+	freshen      = flag.Bool("f", false, "freshen: replace changed entries only, add nothing new")
+	deleteMode   = flag.Bool("d", false, "delete entries matching the arguments from the archive")
+	encrypt      = flag.Bool("e", false, "encrypt entries with AES-256 (prompts for password)")
+	passwordArg  = flag.String("P", "", "encrypt entries with AES-256 using this password (insecure)")
+	noAttrs      = flag.Bool("X", false, "exclude extra file attributes (owner, atime/ctime, xattrs)")
+	zipComment   = flag.Bool("z", false, "add an archive comment (multi-line, read from stdin)")
+	fileComments = flag.Bool("c", false, "add a one-line comment for each added file")
+	storeSHA256  = flag.Bool("sha256", false, "store the SHA-256 of each entry (verified by unz)")
+	signKey      = flag.String("sign", "", "sign the archive with this Ed25519 private key (PKCS#8 PEM)")
+	reproducible = flag.Bool("reproducible", false, "sort entries, fix times to SOURCE_DATE_EPOCH and normalise permissions")
+	excludeFrom  = flag.String("exclude-from", "", "read exclude patterns from this file, one per line")
+	gitignore    = flag.Bool("gitignore", false, "honour .gitignore and .unzignore files (and skip .git) when recursing")
+	gitRev       = flag.String("git", "", "archive this git revision (tree-ish) of the repository instead of files")
+	gitPrefix    = flag.String("prefix", "", "with -git, put entries under this directory")
+	fileTypes    = flag.String("filetypes", "", "read file name to content type mappings from this file")
+	sampling     = flag.String("sample", "", "content detection sampling: windows, head, stride or full, optionally :size")
+	explain      = flag.Bool("explain", false, "report the detected profile and the size of every method for each file")
+	explainJSON  = flag.Bool("json", false, "with -explain, write the report as JSON")
 	help         = flag.Bool("h", false, "display this help")
+
+	excludes patternList // -x: names to leave out
+	includes patternList // -i: only add matching names
+	methods  methodRules // -method: forced methods
+	vocabs   vocabRules  // -vocab: forced vocabularies
+	level    int         // -1 ... -9: compression level, 0 for the default
 )
 
+func init() {
+	for n := compress.LevelFastest; n <= compress.LevelBest; n++ {
+		usage := fmt.Sprintf("compression level %d", n)
+		switch n {
+		case compress.LevelFastest:
+			usage = "compress faster"
+		case compress.LevelBest:
+			usage = "compress better"
+		}
+		flag.Var(levelFlag(n), strconv.Itoa(n), usage)
+	}
+	flag.Var(&excludes, "x", "exclude names matching this pattern (repeatable; ** matches any directories)")
+	flag.Var(&includes, "i", "include only names matching this pattern (repeatable)")
+	flag.Var(&methods, "method", "force this method (store, deflate, unzlate, bpelate, mixlate, exelate), optionally for pattern=method (repeatable)")
+	flag.Var(&vocabs, "vocab", "force this vocabulary, e.g. go or en,python, optionally for pattern=vocab (repeatable)")
+}
+
+// levelFlag is one of the flags -1 ... -9, which set level to its value.
+type levelFlag int
+
+func (f levelFlag) IsBoolFlag() bool { return true }
+func (f levelFlag) String() string   { return "false" }
+
+func (f levelFlag) Set(value string) error {
+	on, err := strconv.ParseBool(value)
+	if on {
+		level = int(f)
+	}
+	return err
+}
+
 type fileEntry struct {
 	path       string      // path on disk
 	name       string      // name in archive
@@ -36,6 +97,8 @@ type fileEntry struct {
 	isDir      bool
 	isSymlink  bool   // true if symlink and -y is set
 	linkTarget string // symlink target (only if isSymlink)
+	isSpecial  bool   // device node or named pipe (metadata only)
+	hardLink   string // archive name of an earlier hard link to the same file
 }
 
 func main() {
@@ -47,17 +110,77 @@ func main() {
 		os.Exit(0)
 	}
 
-	if flag.NArg() < 2 {
+	if flag.NArg() < 2 && !(*zipComment && flag.NArg() == 1) && !(*gitRev != "" && flag.NArg() == 1) {
 		fmt.Fprintln(os.Stderr, "enz: missing archive or file arguments")
 		fmt.Fprintln(os.Stderr, "Try 'enz -h' for more information.")
 		os.Exit(1)
 	}
 
+	if *gitRev != "" && flag.NArg() > 2 {
+		fmt.Fprintln(os.Stderr, "enz: -git takes an archive and at most one repository")
+		fmt.Fprintln(os.Stderr, "Try 'enz -h' for more information.")
+		os.Exit(1)
+	}
+
 	archivePath := flag.Arg(0)
 	if !strings.HasSuffix(archivePath, ".zip") && !strings.HasSuffix(archivePath, ".unz") {
 		archivePath += ".zip"
 	}
 
+	comp := compress.New(vocab.Default())
+	if level != 0 {
+		comp.SetLevel(level)
+	}
+
+	if *deleteMode {
+		deleteEntries(comp, archivePath, flag.Args()[1:])
+		return
+	}
+
+	if *fileTypes != "" {
+		m, err := detect.LoadNameMap(*fileTypes)
+		if err != nil {
+			fatal("cannot read file types: %v", err)
+		}
+		comp.SetNameMap(m)
+	}
+
+	if *sampling != "" {
+		s, err := detect.ParseSampling(*sampling)
+		if err != nil {
+			fatal("invalid -sample: %v", err)
+		}
+		comp.SetSampling(s)
+	}
+
+	if *explain && *level0 {
+		fatal("-explain has nothing to report with -0")
+	}
+
+	if *excludeFrom != "" {
+		patterns, err := readPatterns(*excludeFrom)
+		if err != nil {
+			fatal("cannot read exclude patterns: %v", err)
+		}
+		excludes = append(excludes, patterns...)
+	}
+
+	// -git reads the tree from the repository (default: current directory)
+	if *gitRev != "" {
+		repoDir := "."
+		if flag.NArg() > 1 {
+			repoDir = flag.Arg(1)
+		}
+		archiveGit(comp, archivePath, repoDir, *gitRev)
+		return
+	}
+
+	// -z with no files only edits the comment of an existing archive
+	if flag.NArg() == 1 {
+		editComment(comp, archivePath)
+		return
+	}
+
 	// Collect all files to add
 	var entries []fileEntry
 	for i := 1; i < flag.NArg(); i++ {
@@ -69,33 +192,99 @@ func main() {
 		entries = append(entries, collected...)
 	}
 
+	// Sort before classifying so the same file of a hard linked set is
+	// stored as the target on every run
+	if *reproducible {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	}
+	entries = classifyEntries(entries)
 	if len(entries) == 0 {
 		fatal("no files to add")
 	}
 
-	// Create archive
-	comp := compress.New(vocab.Default())
-	archive := compress.NewArchive(comp)
+	// Create archive, or open the existing one for -u/-f
+	var archive *compress.Archive
+	if *update || *freshen {
+		archive = openArchive(comp, archivePath)
+	} else {
+		archive = compress.NewArchive(comp)
+	}
+	if pass := password(); pass != "" {
+		archive.SetPassword(pass)
+	}
+	archive.SetSHA256(*storeSHA256)
+	archive.SetExplain(*explain)
+	if *reproducible {
+		epoch, err := compress.SourceDateEpoch()
+		if err != nil {
+			fatal("%v", err)
+		}
+		archive.SetReproducible(epoch)
+	}
+	sign(archive)
 
 	var totalIn, totalOut int64
+	var added []fileEntry
 	start := time.Now()
 
 	for _, entry := range entries {
+		verb := "adding"
+		if *update || *freshen {
+			archiveName := entry.name
+			if entry.isDir {
+				archiveName += "/"
+			}
+			existing, ok := archive.Lookup(archiveName)
+			switch {
+			case ok && !entry.info.ModTime().Truncate(time.Second).After(existing.ModTime):
+				continue // unchanged: keep the stored entry as is
+			case ok:
+				verb = "updating"
+			case *freshen:
+				continue // -f never adds new entries
+			}
+		}
+		added = append(added, entry)
+
 		if entry.isDir {
 			// Add directory entry
 			if !*quiet {
-				fmt.Fprintf(os.Stderr, "  adding: %s/\n", entry.name)
+				fmt.Fprintf(os.Stderr, "%8s: %s/\n", verb, entry.name)
 			}
 			archive.AddDirectory(entry.name, entry.info.ModTime(), entry.info.Mode())
+			setAttrs(archive, entry.name+"/", entry)
+			continue
+		}
+
+		if entry.hardLink != "" {
+			err := archive.AddHardLink(entry.name, entry.hardLink, entry.info.ModTime(), entry.info.Mode())
+			if err == nil {
+				if !*quiet {
+					fmt.Fprintf(os.Stderr, "%8s: %s (hard link => %s)\n", verb, entry.name, entry.hardLink)
+				}
+				setAttrs(archive, entry.name, entry)
+				continue
+			}
+			// Target not in the archive (e.g. -f skipped it): store a copy
+		}
+
+		if entry.isSpecial {
+			if !*quiet {
+				fmt.Fprintf(os.Stderr, "%8s: %s (special)\n", verb, entry.name)
+			}
+			major, minor := deviceNumber(entry.info)
+			archive.AddSpecial(entry.name, entry.info.ModTime(), entry.info.Mode(), major, minor)
+			setAttrs(archive, entry.name, entry)
 			continue
 		}
 
 		if entry.isSymlink {
 			// Add symlink entry (when -y flag is used)
 			if !*quiet {
-				fmt.Fprintf(os.Stderr, "  adding: %s (symlink -> %s)\n", entry.name, entry.linkTarget)
+				fmt.Fprintf(os.Stderr, "%8s: %s (symlink -> %s)\n", verb, entry.name, entry.linkTarget)
 			}
 			archive.AddSymlink(entry.name, entry.linkTarget, entry.info.ModTime(), entry.info.Mode())
+			setAttrs(archive, entry.name, entry)
 			totalIn += int64(len(entry.linkTarget))
 			continue
 		}
@@ -107,7 +296,7 @@ func main() {
 		}
 
 		if !*quiet {
-			fmt.Fprintf(os.Stderr, "  adding: %s", entry.name)
+			fmt.Fprintf(os.Stderr, "%8s: %s", verb, entry.name)
 		}
 
 		// Add to archive
@@ -115,12 +304,13 @@ func main() {
 		if *level0 {
 			err = archive.AddStore(data, entry.name, entry.info.ModTime(), mode)
 		} else {
-			err = archive.Add(data, entry.name, entry.info.ModTime(), mode)
+			err = archive.AddWith(data, entry.name, entry.info.ModTime(), mode, addOptions(entry.name))
 		}
 
 		if err != nil {
 			fatal("compression failed for '%s': %v", entry.path, err)
 		}
+		setAttrs(archive, entry.name, entry)
 
 		totalIn += int64(len(data))
 
@@ -129,6 +319,26 @@ func main() {
 		}
 	}
 
+	// Comments are asked for after all files are in, like zip
+	if *fileComments {
+		for _, entry := range added {
+			name := entry.name
+			if entry.isDir {
+				name += "/"
+			}
+			fmt.Fprintf(os.Stderr, "Enter comment for %s:\n", name)
+			comment, _ := readLine()
+			if _, err := archive.SetEntryComment(name, comment); err != nil {
+				fatal("%s: %v", name, err)
+			}
+		}
+	}
+	if *zipComment {
+		if err := archive.SetComment(readComment()); err != nil {
+			fatal("%v", err)
+		}
+	}
+
 	// Write archive
 	output, err := archive.Bytes()
 	if err != nil {
@@ -136,9 +346,8 @@ func main() {
 	}
 	totalOut = int64(len(output))
 
-	if err := os.WriteFile(archivePath, output, 0644); err != nil {
-		fatal("cannot write '%s': %v", archivePath, err)
-	}
+	writeArchive(archivePath, output)
+	writeReport(archive)
 
 	elapsed := time.Since(start)
 
@@ -152,22 +361,222 @@ func main() {
 			totalIn, totalOut, ratio, elapsed.Round(time.Millisecond))
 	}
 
-	// Delete inputs if -m
+	// Delete inputs if -m (only those actually stored by this run)
 	if *move {
-		for _, entry := range entries {
+		for _, entry := range added {
 			if !entry.isDir {
 				os.Remove(entry.path)
 			}
 		}
 		// Remove directories in reverse order (deepest first)
-		for i := len(entries) - 1; i >= 0; i-- {
-			if entries[i].isDir {
-				os.Remove(entries[i].path)
+		for i := len(added) - 1; i >= 0; i-- {
+			if added[i].isDir {
+				os.Remove(added[i].path)
 			}
 		}
 	}
 }
 
+// openArchive opens an existing archive for -u/-f. With -u a missing
+// archive starts empty; -f has nothing to freshen and fails.
+func openArchive(comp *compress.Compressor, archivePath string) *compress.Archive {
+	data, err := os.ReadFile(archivePath)
+	if os.IsNotExist(err) && !*freshen {
+		return compress.NewArchive(comp)
+	}
+	if err != nil {
+		fatal("cannot open '%s': %v", archivePath, err)
+	}
+
+	archive, err := compress.OpenArchive(comp, data)
+	if err != nil {
+		fatal("cannot read '%s': %v", archivePath, err)
+	}
+	return archive
+}
+
+// deleteEntries removes entries matching patterns from an existing archive.
+// Stored entries that remain are copied without recompression.
+func deleteEntries(comp *compress.Compressor, archivePath string, patterns []string) {
+	if len(patterns) == 0 {
+		fatal("nothing to delete (no names given)")
+	}
+
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		fatal("cannot open '%s': %v", archivePath, err)
+	}
+	archive, err := compress.OpenArchive(comp, data)
+	if err != nil {
+		fatal("cannot read '%s': %v", archivePath, err)
+	}
+
+	deleted := 0
+	for _, name := range archive.Names() {
+		if !matchesAny(name, patterns) {
+			continue
+		}
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "deleting: %s\n", name)
+		}
+		archive.Remove(name)
+		deleted++
+	}
+
+	if deleted == 0 {
+		fatal("no entries matched in '%s'", archivePath)
+	}
+
+	sign(archive)
+	output, err := archive.Bytes()
+	if err != nil {
+		fatal("cannot create archive: %v", err)
+	}
+	writeArchive(archivePath, output)
+}
+
+// classifyEntries flags device nodes and named pipes, which are stored
+// without content, drops sockets, and turns later occurrences of a hard
+// linked file into links to the first one.
+func classifyEntries(entries []fileEntry) []fileEntry {
+	kept := entries[:0]
+	firstName := make(map[fileID]string)
+	for _, entry := range entries {
+		mode := entry.info.Mode()
+		switch {
+		case mode&os.ModeSocket != 0:
+			fmt.Fprintf(os.Stderr, "enz: skipping socket '%s'\n", entry.path)
+			continue
+		case mode&(os.ModeDevice|os.ModeNamedPipe) != 0:
+			entry.isSpecial = true
+		case mode.IsRegular():
+			if id, ok := hardLinkID(entry.info); ok {
+				if first, seen := firstName[id]; seen {
+					entry.hardLink = first
+				} else {
+					firstName[id] = entry.name
+				}
+			}
+		}
+		kept = append(kept, entry)
+	}
+	return kept
+}
+
+// setAttrs records the owner, times and extended attributes of an added
+// entry unless -X is given.
+func setAttrs(archive *compress.Archive, name string, entry fileEntry) {
+	if *noAttrs {
+		return
+	}
+	if _, err := archive.SetUnixAttrs(name, unixAttrs(entry)); err != nil {
+		fmt.Fprintf(os.Stderr, "enz: %s: attributes not stored: %v\n", entry.path, err)
+	}
+}
+
+// editComment replaces the archive comment of an existing archive, copying
+// its entries unchanged.
+func editComment(comp *compress.Compressor, archivePath string) {
+	if _, err := os.Stat(archivePath); err != nil {
+		fatal("cannot open '%s': %v", archivePath, err)
+	}
+	archive := openArchive(comp, archivePath)
+	if err := archive.SetComment(readComment()); err != nil {
+		fatal("%v", err)
+	}
+
+	sign(archive)
+	output, err := archive.Bytes()
+	if err != nil {
+		fatal("cannot create archive: %v", err)
+	}
+	writeArchive(archivePath, output)
+}
+
+// readComment reads a multi-line archive comment from stdin, ended by a
+// line holding just "." or by end of input.
+func readComment() string {
+	if isTerminal() {
+		fmt.Fprintln(os.Stderr, "enter new zip file comment (end with .):")
+	}
+	var lines []string
+	for {
+		line, ok := readLine()
+		if !ok || line == "." {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// password returns the -P password, or prompts for one (twice) with -e.
+func password() string {
+	if *passwordArg != "" || !*encrypt {
+		return *passwordArg
+	}
+	pass := readPassword("Enter password: ")
+	if pass == "" {
+		fatal("empty password")
+	}
+	if readPassword("Verify password: ") != pass {
+		fatal("password verification failed")
+	}
+	return pass
+}
+
+// sign sets the archive's signing key from -sign, if given.
+func sign(archive *compress.Archive) {
+	if *signKey == "" {
+		return
+	}
+	key, err := loadPrivateKey(*signKey)
+	if err != nil {
+		fatal("cannot load signing key: %v", err)
+	}
+	archive.Sign(key)
+}
+
+// writeArchive writes the archive through a temporary file and renames it
+// into place, so a failed write never clobbers an existing archive. An
+// archive being updated keeps its permissions.
+func writeArchive(archivePath string, output []byte) {
+	tmpPath := archivePath + ".tmp"
+	perm := os.FileMode(0644)
+	existing, statErr := os.Stat(archivePath)
+	if statErr == nil {
+		perm = existing.Mode().Perm()
+	}
+	err := os.WriteFile(tmpPath, output, perm)
+	if err == nil && statErr == nil {
+		// WriteFile applies the umask; the original's mode already had it
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		fatal("cannot write '%s': %v", archivePath, err)
+	}
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		os.Remove(tmpPath)
+		fatal("cannot write '%s': %v", archivePath, err)
+	}
+}
+
+// matchesAny reports whether an archive entry name matches any pattern,
+// either whole or by base name. Directory entries also match without
+// their trailing slash.
+func matchesAny(name string, patterns []string) bool {
+	trimmed := strings.TrimSuffix(name, "/")
+	for _, pattern := range patterns {
+		for _, candidate := range []string{name, trimmed, filepath.Base(trimmed)} {
+			if matched, err := filepath.Match(pattern, candidate); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // collectFiles collects files from a path, recursing into directories if -r is set.
 // Handles symlinks according to -y flag: store as links (-y) or follow (default).
 func collectFiles(path string) ([]fileEntry, error) {
@@ -201,6 +610,9 @@ func collectFiles(path string) ([]fileEntry, error) {
 			name = filepath.Base(path)
 		}
 		name = filepath.ToSlash(name)
+		if !selected(name, false) {
+			return nil, nil
+		}
 
 		entries = append(entries, fileEntry{
 			path:  path,
@@ -223,6 +635,9 @@ func handleSymlink(path string, linfo os.FileInfo) ([]fileEntry, error) {
 
 	if *storeSymlink {
 		// -y flag: store symlink as-is
+		if !selected(name, false) {
+			return nil, nil
+		}
 		target, err := os.Readlink(path)
 		if err != nil {
 			return nil, fmt.Errorf("cannot read symlink '%s': %v", path, err)
@@ -259,6 +674,9 @@ func handleSymlink(path string, linfo os.FileInfo) ([]fileEntry, error) {
 	}
 
 	// Regular file - use real path for reading but original name in archive
+	if !selected(name, false) {
+		return nil, nil
+	}
 	return []fileEntry{{
 		path:  realPath,
 		name:  name,
@@ -273,7 +691,14 @@ func walkDir(root string, entries *[]fileEntry) error {
 }
 
 // walkDirWithBase walks a directory tree, using basePath for archive names.
+// Names excluded by -x, -i or (with -gitignore) ignore files are skipped,
+// along with everything below an excluded directory.
 func walkDirWithBase(root string, basePath string, entries *[]fileEntry) error {
+	var ignores *ignoreMatcher
+	if *gitignore {
+		ignores = newIgnoreMatcher(root)
+	}
+
 	return filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -298,6 +723,22 @@ func walkDirWithBase(root string, basePath string, entries *[]fileEntry) error {
 		}
 		name = filepath.ToSlash(name)
 
+		// Apply filters; excluded directories are not descended into
+		rel := filepath.ToSlash(relPath)
+		if ignores != nil && rel != "." && ignores.ignored(rel, linfo.IsDir()) ||
+			globMatchAny(excludes, name, linfo.IsDir()) {
+			if linfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if linfo.IsDir() && ignores != nil {
+			ignores.load(rel)
+		}
+		if len(includes) > 0 && !globMatchAny(includes, name, linfo.IsDir()) {
+			return nil
+		}
+
 		// Handle symlinks
 		if linfo.Mode()&os.ModeSymlink != 0 {
 			if *storeSymlink {
@@ -354,27 +795,96 @@ func walkDirWithBase(root string, basePath string, entries *[]fileEntry) error {
 }
 
 func usage() {
-	fmt.Fprintf(os.Stderr, `Usage: enz [-0|-9] [-ry] [-qvmj] archive[.zip] file...
+	fmt.Fprintf(os.Stderr, `Usage: enz [-0|-1...-9] [-ry] [-qvmjX] [-u|-f] [-cz] [-x pat] [-i pat] [-gitignore] [-filetypes file] [-sample mode] [-method [pat=]m] [-vocab [pat=]v] [-explain [-json]] [-sha256] [-reproducible] [-e|-P password] [-sign key.pem] archive[.zip] file...
+       enz -git rev [-prefix dir/] archive[.zip] [repository]
+       enz -d archive[.zip] name...
+       enz -z archive[.zip]
 
 Compress files into ZIP archive using adaptive BPE/DEFLATE compression.
 Output is standard PKZIP format compatible with unzip, WinZip, etc.
 
 Options:
   -0        store only (no compression)
-  -9        best compression (default)
+  -1 ... -9 compression level: -1 to -3 guess the method from the content
+            and use fast DEFLATE, -4 to -6 (default) compress with each
+            method suited to the content and keep the smallest, -7 to -9
+            also try the shortest BPE parse, ANS-coded tokens (Unzlate)
+            and every vocabulary
   -r        recurse into directories
   -y        store symbolic links as links (default: follow links)
   -q        quiet operation
   -v        verbose operation  
   -m        move into archive (delete input files after compression)
   -j        junk directory names (store only file names)
+  -X        exclude extra file attributes: owner (UID/GID), access and
+            change times, and extended attributes/ACLs (stored by default)
+  -u        update: add new files, replace entries older than the file
+  -f        freshen: replace entries older than the file, add nothing new
+  -d        delete entries matching the given names or patterns
+  -e        encrypt with WinZip AES-256 (prompts for password)
+  -P pass   encrypt with this password (visible to other users; prefer -e)
+  -c        prompt for a one-line comment for each added file
+  -z        read a multi-line archive comment from stdin, ended by a line
+            with just "." (with no files, only replaces the comment)
+  -x pat    exclude names matching pat (repeatable); a pattern without a
+            slash matches the base name at any depth, ** matches any
+            number of directories, and excluded directories are skipped
+  -i pat    include only names matching pat (repeatable)
+  --exclude-from file
+            read -x patterns from file, one per line (# for comments)
+  -gitignore
+            honour .gitignore and .unzignore files in the tree being
+            archived, nested ones included, and skip .git directories
+  -filetypes file
+            read "pattern type" lines mapping file names to content types
+            (go, python, json, markdown, text, ...), overriding the
+            built-in extension and file name hints
+  -sample mode[:size]
+            which parts of files larger than size bytes (default 8192)
+            content detection reads: windows at the start, middle and end
+            (default), head, stride (chunks spread over the file) or full
+  -method [pat=]m
+            compress with method m (store, deflate, unzlate, bpelate,
+            mixlate or exelate) instead of picking the smallest; with a
+            pattern, only names matching it (repeatable, last match wins)
+  -vocab [pat=]v
+            tokenize with vocabulary v and record it: a programming
+            language (go, python, javascript, ...), optionally with a
+            natural language, data format and markup (en,go or
+            es,json,markdown); text and code then get Bpelate with it
+            or DEFLATE, whichever is smaller (repeatable like -method)
+  -explain  after writing the archive, report on stdout for each compressed
+            file its detected profile, the size every method and
+            vocabulary gives (including those automatic selection skips)
+            and the method chosen, marked with *
+  -json     with -explain, write the report as a JSON array
+  -git rev  archive a git revision (commit, tag, branch or tree, optionally
+            rev:path) straight from the repository's objects, like git
+            archive; times are the commit time, the archive comment is the
+            commit ID, and -x/-i apply
+  -prefix d with -git, store entries under directory d
+  -sha256   store the SHA-256 of each entry's content (checked by unz -t)
+  -sign key sign the archive with an Ed25519 private key (PKCS#8 PEM, e.g.
+            from "openssl genpkey -algorithm ed25519 -out key.pem")
+  -reproducible
+            byte-identical output for the same tree: sorted entries,
+            times set to $SOURCE_DATE_EPOCH (default 1980-01-01 UTC),
+            permissions 0644/0755, no owner or extra attributes
   -h        display this help
 
+With -u, -f and -d, unchanged entries are copied without recompression.
+Encrypted entries are compressed first, then encrypted (AE-2), so they
+keep the BPE methods and open in 7-Zip and WinZip. A signature is stored in
+the archive comment; changing the archive in any way (including -u, -f
+and -d) drops it unless -sign is given again.
+
 Compression methods:
   Method 0  (Stored)  - no compression
   Method 8  (Deflate) - standard ZIP compression
   Method 85 (Unzlate) - BPE + ANS (reserved, high overhead)
   Method 86 (Bpelate) - BPE + DEFLATE (source code, text)
+  Method 87 (Mixlate) - BPE with a vocabulary per segment + DEFLATE (docs with code)
+  Method 88 (Exelate) - x86 branch filter + DEFLATE (executables)
 
 The compressor automatically selects the best method for each file.
 
@@ -385,10 +895,30 @@ Examples:
   enz -ry archive.zip src/          Recurse, preserve symlinks
   enz -0 backup.zip data.bin        Store without compression
   enz -v -m docs.zip readme.txt     Verbose, delete original after
+  enz -r -u snapshot.zip src/       Add new and changed files to snapshot
+  enz -d snapshot.zip 'src/*.tmp'   Delete entries from snapshot
+  enz -r -gitignore src.zip repo/   Archive a checkout without ignored files
+  enz -git v1.0 -prefix proj-1.0/ proj-1.0.zip
+                                    Archive tag v1.0 of the current repository
+  enz -r -x '**/node_modules/**' -x '*.o' src.zip src/
+  enz -e -r secret.zip src/         Encrypt with AES-256
+  echo "Release 1.0" | enz -z rel.zip Set the archive comment
+  enz -r -sign key.pem rel.zip dist/ Sign a release archive
+  SOURCE_DATE_EPOCH=1700000000 enz -r -reproducible src.zip src/
 
 `)
 }
 
+// writeReport writes the -explain report to stdout.
+func writeReport(archive *compress.Archive) {
+	if !*explain {
+		return
+	}
+	if err := writeExplanations(os.Stdout, archive.Explanations(), *explainJSON); err != nil {
+		fatal("cannot write report: %v", err)
+	}
+}
+
 func fatal(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "enz: "+format+"\n", args...)
 	os.Exit(1)
@@ -2,8 +2,13 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -205,3 +210,285 @@ func TestCompressCRC32(t *testing.T) {
 func testModTime() time.Time {
 	return time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
 }
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.o", "build/obj/main.o", true},
+		{"*.o", "main.go", false},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/pkg/main.go", false},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/**/*.go", "src/pkg/deep/main.go", true},
+		{"**/node_modules/**", "app/node_modules/x/index.js", true},
+		{"**/node_modules/**", "node_modules/", true},
+		{"**/node_modules/**", "app/modules/x.js", false},
+		{"/docs/*.md", "docs/a.md", true},
+		{"docs/**", "docs", true},
+		{"docs/**", "mydocs/a.md", false},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWalkDirFilters(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":              "*.log\nbuild/\n/top.txt\n!keep.log\n",
+		".git/HEAD":               "ref: refs/heads/main\n",
+		"main.go":                 "package main\n",
+		"top.txt":                 "anchored\n",
+		"debug.log":               "ignored\n",
+		"keep.log":                "negated\n",
+		"build/out.bin":           "ignored dir\n",
+		"sub/top.txt":             "not anchored here\n",
+		"sub/.unzignore":          "*.tmp\n!debug.log\n",
+		"sub/scratch.tmp":         "ignored by nested file\n",
+		"sub/debug.log":           "re-included by nested file\n",
+		"sub/node_modules/pkg.js": "excluded by -x\n",
+		"other/a.tmp":             "nested rule does not apply\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func(g bool, x, i patternList) { *gitignore, excludes, includes = g, x, i }(*gitignore, excludes, includes)
+	*gitignore = true
+	excludes = patternList{"**/node_modules/**"}
+	includes = nil
+
+	var entries []fileEntry
+	if err := walkDirWithBase(root, "repo", &entries); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, e := range entries {
+		got[e.name] = true
+	}
+	want := []string{"repo", "repo/.gitignore", "repo/main.go", "repo/keep.log", "repo/sub",
+		"repo/sub/top.txt", "repo/sub/.unzignore", "repo/sub/debug.log", "repo/other", "repo/other/a.tmp"}
+	for _, name := range want {
+		if !got[name] {
+			t.Errorf("%s missing", name)
+		}
+		delete(got, name)
+	}
+	for name := range got {
+		t.Errorf("%s should have been filtered", name)
+	}
+
+	// -i keeps only matching files
+	*gitignore = false
+	excludes, includes = nil, patternList{"**/*.go"}
+	entries = nil
+	if err := walkDirWithBase(root, "repo", &entries); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if len(entries) != 1 || entries[0].name != "repo/main.go" {
+		t.Errorf("-i '**/*.go' collected %v", entries)
+	}
+}
+
+func TestArchiveGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitCmd := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_COMMITTER_DATE=1700000000 +0000")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	gitCmd("init", "-q")
+	os.MkdirAll(filepath.Join(repo, "src"), 0755)
+	os.WriteFile(filepath.Join(repo, "src/main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	os.WriteFile(filepath.Join(repo, "run.sh"), []byte("#!/bin/sh\n"), 0755)
+	os.WriteFile(filepath.Join(repo, "notes.tmp"), []byte("scratch\n"), 0644)
+	os.Symlink("src/main.go", filepath.Join(repo, "main.go"))
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "initial")
+	os.WriteFile(filepath.Join(repo, "src/main.go"), []byte("uncommitted\n"), 0644)
+	head := gitCmd("rev-parse", "HEAD")
+
+	defer func(q bool, p string, x patternList) { *quiet, *gitPrefix, excludes = q, p, x }(*quiet, *gitPrefix, excludes)
+	*quiet, *gitPrefix, excludes = true, "proj-1.0", patternList{"*.tmp"}
+
+	archivePath := filepath.Join(t.TempDir(), "out.zip")
+	comp := compress.New(vocab.Default())
+	archiveGit(comp, archivePath, repo, "HEAD")
+
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment, _ := compress.ReadComment(data); comment != head {
+		t.Errorf("comment %q, want commit %s", comment, head)
+	}
+	files, err := compress.ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	modes := make(map[string]os.FileMode)
+	for _, f := range files {
+		modes[f.Name] = f.Mode
+		if f.ModTime.Unix() != 1700000000 {
+			t.Errorf("%s: mtime %v, want the commit time", f.Name, f.ModTime)
+		}
+		if f.Name == "proj-1.0/src/main.go" {
+			content, err := comp.DecompressFile(data, f)
+			if err != nil || string(content) != "package main\n\nfunc main() {}\n" {
+				t.Errorf("src/main.go = %q, %v (want the committed content)", content, err)
+			}
+		}
+	}
+	want := map[string]os.FileMode{
+		"proj-1.0/":            os.ModeDir | 0755,
+		"proj-1.0/main.go":     os.ModeSymlink | 0777,
+		"proj-1.0/run.sh":      0755,
+		"proj-1.0/src/":        os.ModeDir | 0755,
+		"proj-1.0/src/main.go": 0644,
+	}
+	for name, mode := range want {
+		if modes[name] != mode {
+			t.Errorf("%s: mode %v, want %v", name, modes[name], mode)
+		}
+	}
+	if len(modes) != len(want) {
+		t.Errorf("entries %v, want %d", modes, len(want))
+	}
+}
+
+func TestWriteExplanations(t *testing.T) {
+	comp := compress.New(vocab.Default())
+	text := []byte(strings.Repeat("The archive keeps every file with the method that makes it smallest.\n", 20))
+	explanations := []compress.Explanation{comp.Explain(text, "notes.txt")}
+
+	var buf bytes.Buffer
+	if err := writeExplanations(&buf, explanations, false); err != nil {
+		t.Fatal(err)
+	}
+	table := buf.String()
+	if !strings.HasPrefix(table, "notes.txt: ") || strings.Count(table, "\n* ") != 1 {
+		t.Errorf("table without a header or one chosen method:\n%s", table)
+	}
+	if !strings.Contains(table, "not tried") {
+		t.Errorf("table does not note methods automatic selection skips:\n%s", table)
+	}
+	if !strings.Contains(table, "\n  predicted ") {
+		t.Errorf("table without the method prediction:\n%s", table)
+	}
+
+	buf.Reset()
+	if err := writeExplanations(&buf, explanations, true); err != nil {
+		t.Fatal(err)
+	}
+	var reports []explainReport
+	if err := json.Unmarshal(buf.Bytes(), &reports); err != nil {
+		t.Fatalf("JSON report: %v", err)
+	}
+	r := reports[0]
+	if len(reports) != 1 || r.Name != "notes.txt" || r.Profile.Type != "text" || r.Method != explanations[0].Method.String() {
+		t.Errorf("JSON report: got %+v", reports)
+	}
+	if len(r.Candidates) != len(explanations[0].Candidates) {
+		t.Errorf("JSON report: %d candidates, want %d", len(r.Candidates), len(explanations[0].Candidates))
+	}
+	if r.Prediction == nil || r.Prediction.Vocab != "text" {
+		t.Errorf("JSON report: got prediction %+v", r.Prediction)
+	}
+}
+
+func TestAddOptions(t *testing.T) {
+	defer func() { methods, vocabs = nil, nil }()
+	for _, rule := range []string{"bpelate", "*.min.js=deflate", "vendor/**=store"} {
+		if err := methods.Set(rule); err != nil {
+			t.Fatalf("-method %s: %v", rule, err)
+		}
+	}
+	if err := vocabs.Set("docs/*.md=en,go,markdown"); err != nil {
+		t.Fatal(err)
+	}
+	if methods.Set("lzma") == nil || vocabs.Set("*.go=klingon") == nil {
+		t.Error("bad rules accepted")
+	}
+
+	tests := []struct {
+		name   string
+		method compress.Method
+		vocab  bool
+	}{
+		{"main.go", compress.MethodBPELATE, false},
+		{"web/app.min.js", compress.MethodDEFLATE, false},
+		{"vendor/lib/x.min.js", compress.MethodStore, false},
+		{"docs/guide.md", compress.MethodBPELATE, true},
+	}
+	for _, tt := range tests {
+		opts := addOptions(tt.name)
+		if !opts.ForceMethod || opts.Method != tt.method || opts.ForceVocab != tt.vocab {
+			t.Errorf("%s: got %+v", tt.name, opts)
+		}
+	}
+	if opts := addOptions("docs/guide.md"); opts.Vocab.ProgLang != compress.ProgLangGo || opts.Vocab.Markup != compress.MarkupMarkdown {
+		t.Errorf("docs/guide.md: vocabulary %+v", opts.Vocab)
+	}
+}
+
+func TestLevelFlags(t *testing.T) {
+	defer func() { level = 0 }()
+	fs := flag.NewFlagSet("enz", flag.ContinueOnError)
+	for n := 1; n <= 9; n++ {
+		fs.Var(levelFlag(n), strconv.Itoa(n), "")
+	}
+	if err := fs.Parse([]string{"-3", "a.zip"}); err != nil || level != 3 {
+		t.Errorf("-3: level %d, %v", level, err)
+	}
+	if err := fs.Parse([]string{"-9=false"}); err != nil || level != 3 {
+		t.Errorf("-9=false: level %d, %v", level, err)
+	}
+}
+
+func TestWriteArchiveKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "private.zip")
+	if err := os.WriteFile(archivePath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(archivePath, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writeArchive(archivePath, []byte("new"))
+
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600 kept from the original", info.Mode().Perm())
+	}
+	if data, _ := os.ReadFile(archivePath); string(data) != "new" {
+		t.Errorf("content = %q, want new", data)
+	}
+	if _, err := os.Stat(archivePath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary file left behind")
+	}
+}
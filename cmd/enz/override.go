@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/ha1tch/unz/pkg/compress"
+)
+
+// methodRule forces the method of names matching pattern; an empty pattern
+// matches every name.
+type methodRule struct {
+	pattern string
+	method  compress.Method
+}
+
+// vocabRule forces the vocabulary of names matching pattern.
+type vocabRule struct {
+	pattern string
+	vocab   compress.VocabInfo
+}
+
+// methodRules collects the values of the repeatable -method flag.
+type methodRules []methodRule
+
+func (r *methodRules) String() string {
+	return ""
+}
+
+// Set reads "[pattern=]method".
+func (r *methodRules) Set(value string) error {
+	pattern, name := splitRule(value)
+	method, err := compress.ParseMethod(name)
+	if err != nil {
+		return err
+	}
+	*r = append(*r, methodRule{pattern, method})
+	return nil
+}
+
+// vocabRules collects the values of the repeatable -vocab flag.
+type vocabRules []vocabRule
+
+func (r *vocabRules) String() string {
+	return ""
+}
+
+// Set reads "[pattern=]name,...".
+func (r *vocabRules) Set(value string) error {
+	pattern, names := splitRule(value)
+	vocab, err := compress.ParseVocabInfo(names)
+	if err != nil {
+		return err
+	}
+	*r = append(*r, vocabRule{pattern, vocab})
+	return nil
+}
+
+// splitRule splits a rule at its last "=" into the glob pattern, empty if
+// there is none, and the value.
+func splitRule(value string) (pattern, rest string) {
+	i := strings.LastIndex(value, "=")
+	if i < 0 {
+		return "", value
+	}
+	return value[:i], value[i+1:]
+}
+
+// addOptions returns the method and vocabulary forced for an archive name
+// by -method and -vocab. When several rules match, the last one wins.
+func addOptions(name string) compress.AddOptions {
+	var opts compress.AddOptions
+	for _, r := range methods {
+		if r.pattern == "" || globMatch(r.pattern, name) {
+			opts.Method, opts.ForceMethod = r.method, true
+		}
+	}
+	for _, r := range vocabs {
+		if r.pattern == "" || globMatch(r.pattern, name) {
+			opts.Vocab, opts.ForceVocab = r.vocab, true
+		}
+	}
+	return opts
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// stdin is shared by every prompt so that buffered input is not lost when
+// several answers are piped in.
+var stdin = bufio.NewReader(os.Stdin)
+
+// readPassword prompts on stderr and reads a line from stdin, turning off
+// echo while it does when stdin is a terminal.
+func readPassword(prompt string) string {
+	fmt.Fprint(os.Stderr, prompt)
+
+	if isTerminal() {
+		if stty("-echo") == nil {
+			defer func() {
+				stty("echo")
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+
+	line, _ := readLine()
+	return line
+}
+
+// readLine reads one line from stdin without its line ending. ok is false
+// at end of input when nothing was read.
+func readLine() (line string, ok bool) {
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", false
+	}
+	return strings.TrimRight(line, "\r\n"), true
+}
+
+func isTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func stty(arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
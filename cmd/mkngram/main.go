@@ -0,0 +1,241 @@
+// Command mkngram trains the language profiles of pkg/detect.
+//
+// Usage:
+//
+//	mkngram [-code] [-n size] [-o output] corpus...
+//
+// Each corpus argument is a directory of training texts, or a text file,
+// named after the ISO 639-1 code of its language (en.txt, pt.txt, ...).
+// With -code the texts are source code named after their programming
+// language (go.txt, python.txt, ...) and the profiles count tokens instead
+// of character n-grams. Output is Go source code for the detect package.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+var (
+	codeMode    = flag.Bool("code", false, "train programming language profiles")
+	profileSize = flag.Int("n", 0, "entries kept per language (default depends on -code)")
+	outputFile  = flag.String("o", "", "output file (default: stdout)")
+	help        = flag.Bool("h", false, "display help")
+)
+
+// language names a corpus file and the detect constant it trains.
+type language struct{ code, name string }
+
+// natLangs maps ISO 639-1 codes to detect.NatLang constant names, in
+// NatLang order.
+var natLangs = []language{
+	{"en", "NatLangEnglish"},
+	{"es", "NatLangSpanish"},
+	{"fr", "NatLangFrench"},
+	{"pt", "NatLangPortuguese"},
+	{"de", "NatLangGerman"},
+	{"it", "NatLangItalian"},
+	{"nl", "NatLangDutch"},
+	{"zh", "NatLangChinese"},
+	{"ar", "NatLangArabic"},
+	{"hi", "NatLangHindi"},
+	{"id", "NatLangIndonesian"},
+	{"bn", "NatLangBengali"},
+	{"ru", "NatLangRussian"},
+	{"ja", "NatLangJapanese"},
+}
+
+// codeLangs maps corpus names to detect.CodeLang constant names, in
+// CodeLang order.
+var codeLangs = []language{
+	{"go", "CodeLangGo"},
+	{"python", "CodeLangPython"},
+	{"javascript", "CodeLangJavaScript"},
+	{"java", "CodeLangJava"},
+	{"c", "CodeLangC"},
+	{"cpp", "CodeLangCPP"},
+	{"csharp", "CodeLangCSharp"},
+	{"ruby", "CodeLangRuby"},
+	{"rust", "CodeLangRust"},
+	{"php", "CodeLangPHP"},
+	{"swift", "CodeLangSwift"},
+	{"kotlin", "CodeLangKotlin"},
+}
+
+// profileSet describes the profiles being generated.
+type profileSet struct {
+	langs    []language
+	varName  string // generated variable
+	typeName string // its language key type
+	what     string // what a profile counts, for the doc comment
+	size     int    // default profile size
+	count    func(text []byte, top int) []detect.NGram
+}
+
+var (
+	natLangSet = profileSet{
+		langs:    natLangs,
+		varName:  "natLangProfiles",
+		typeName: "NatLang",
+		what:     fmt.Sprintf("character n-grams of each\n// language's training text seen at least %d times", detect.ProfileMinCount),
+		size:     detect.ProfileSize,
+		count:    detect.NGrams,
+	}
+	codeLangSet = profileSet{
+		langs:    codeLangs,
+		varName:  "codeLangProfiles",
+		typeName: "CodeLang",
+		what:     "tokens of each programming\n// language's training code",
+		size:     detect.CodeProfileSize,
+		count:    detect.CodeTokens,
+	}
+)
+
+// known reports whether code names one of the set's languages.
+func (set profileSet) known(code string) bool {
+	for _, l := range set.langs {
+		if l.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *help {
+		usage()
+		os.Exit(0)
+	}
+	if flag.NArg() == 0 {
+		fatal("no corpus given")
+	}
+
+	set := natLangSet
+	if *codeMode {
+		set = codeLangSet
+	}
+	if *profileSize <= 0 {
+		*profileSize = set.size
+	}
+
+	files, err := corpusFiles(flag.Args())
+	if err != nil {
+		fatal("%v", err)
+	}
+
+	// Concatenate the texts of each language
+	texts := make(map[string][]byte)
+	for _, file := range files {
+		code := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if !set.known(code) {
+			fatal("'%s': unknown language code %q", file, code)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fatal("cannot read '%s': %v", file, err)
+		}
+		texts[code] = append(append(texts[code], data...), '\n')
+	}
+
+	profiles := make(map[string][]detect.NGram)
+	for code, text := range texts {
+		profiles[code] = set.count(text, *profileSize)
+		fmt.Fprintf(os.Stderr, "%s: %d bytes, %d entries\n", code, len(text), len(profiles[code]))
+	}
+
+	var out io.Writer = os.Stdout
+	if *outputFile != "" {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			fatal("cannot create output: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writeGoSource(out, set, profiles)
+}
+
+// corpusFiles expands directories to the .txt files they contain.
+func corpusFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.txt"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func writeGoSource(w io.Writer, set profileSet, profiles map[string][]detect.NGram) {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	fmt.Fprintf(bw, "// Code generated by mkngram. DO NOT EDIT.\n\n")
+	fmt.Fprintf(bw, "package detect\n\n")
+	fmt.Fprintf(bw, "// %s holds up to %d of the most frequent %s, with their frequency in parts per million.\n", set.varName, *profileSize, set.what)
+	fmt.Fprintf(bw, "var %s = map[%s][]NGram{\n", set.varName, set.typeName)
+	for _, l := range set.langs {
+		grams, ok := profiles[l.code]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(bw, "\t%s: {\n", l.name)
+		for _, g := range grams {
+			fmt.Fprintf(bw, "\t\t{%s, %d},\n", strconv.Quote(g.Text), g.PPM)
+		}
+		fmt.Fprintf(bw, "\t},\n")
+	}
+	fmt.Fprintf(bw, "}\n")
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: mkngram [-code] [-n size] [-o output] corpus...
+
+Train character n-gram language profiles and generate Go source code for
+the detect package. Each corpus is a directory of, or a single, text file
+named after its ISO 639-1 language code (en.txt, pt.txt, ...).
+
+With -code, train token profiles of programming languages instead, from
+source files named after their language (go.txt, python.txt, cpp.txt, ...).
+
+Options:
+  -code      train programming language profiles
+  -n N       entries kept per language (default: %d, or %d with -code)
+  -o file    output file (default: stdout)
+  -h         display this help
+
+Examples:
+  mkngram -o pkg/detect/ngram_profiles.go cmd/mkngram/corpus
+  mkngram -code -o pkg/detect/code_profiles.go cmd/mkngram/corpus/code
+
+`, detect.ProfileSize, detect.CodeProfileSize)
+}
+
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "mkngram: "+format+"\n", args...)
+	os.Exit(1)
+}
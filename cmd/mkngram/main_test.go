@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+func TestCorpusFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"pt.txt", "en.txt", "README"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("text"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := corpusFiles([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "en.txt"), filepath.Join(dir, "pt.txt")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", files, want)
+	}
+
+	if _, err := corpusFiles([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("missing corpus: expected an error")
+	}
+}
+
+func TestWriteGoSource(t *testing.T) {
+	profiles := map[string][]detect.NGram{
+		"pt": detect.NGrams([]byte("não não não"), 20),
+		"en": detect.NGrams([]byte("the the the"), 2),
+	}
+
+	var buf bytes.Buffer
+	writeGoSource(&buf, natLangSet, profiles)
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "// Code generated by mkngram. DO NOT EDIT.") {
+		t.Error("missing generated code header")
+	}
+	// Languages are written in NatLang order whatever the map order
+	en := strings.Index(out, "NatLangEnglish: {")
+	pt := strings.Index(out, "NatLangPortuguese: {")
+	if en < 0 || pt < 0 || en > pt {
+		t.Errorf("languages missing or out of order:\n%s", out)
+	}
+	if strings.Contains(out, "NatLangSpanish") {
+		t.Error("language without a corpus should be omitted")
+	}
+	if !strings.Contains(out, `{"ã", `) {
+		t.Errorf("non-ASCII n-gram not quoted as expected:\n%s", out)
+	}
+}
+
+func TestWriteCodeSource(t *testing.T) {
+	profiles := map[string][]detect.NGram{
+		"python": detect.CodeTokens([]byte("def f(x):\n    return x\n"), 20),
+		"go":     detect.CodeTokens([]byte("func f(x int) int {\n\treturn x\n}\n"), 20),
+	}
+
+	var buf bytes.Buffer
+	writeGoSource(&buf, codeLangSet, profiles)
+	out := buf.String()
+
+	if !strings.Contains(out, "var codeLangProfiles = map[CodeLang][]NGram{") {
+		t.Errorf("missing profile variable:\n%s", out)
+	}
+	goAt := strings.Index(out, "CodeLangGo: {")
+	pyAt := strings.Index(out, "CodeLangPython: {")
+	if goAt < 0 || pyAt < 0 || goAt > pyAt {
+		t.Errorf("languages missing or out of order:\n%s", out)
+	}
+	if !strings.Contains(out, `{"^def", `) {
+		t.Errorf("line start token missing:\n%s", out)
+	}
+	if !codeLangSet.known("cpp") || codeLangSet.known("en") {
+		t.Error("code corpus names not recognised")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// lchtimes is only implemented on Linux; symlinks elsewhere keep the time
+// they were created.
+func lchtimes(path string, atime, mtime time.Time) error {
+	return errors.New("setting symlink times is not supported on this platform")
+}
@@ -2,31 +2,50 @@
 //
 // Usage matches unzip(1):
 //
-//	unz [-ltvqonp] [-d dir] archive.zip [file...]
+//	unz [-ltvqonpzX] [-d dir] archive.zip [file...]
 package main
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ha1tch/unz/pkg/compress"
 	"github.com/ha1tch/unz/pkg/vocab"
 )
 
 var (
-	list        = flag.Bool("l", false, "list files (short format)")
-	listVerbose = flag.Bool("v", false, "list files (verbose format)")
-	test        = flag.Bool("t", false, "test archive integrity")
-	quiet       = flag.Bool("q", false, "quiet operation")
-	overwrite   = flag.Bool("o", false, "overwrite files without prompting")
-	never       = flag.Bool("n", false, "never overwrite existing files")
-	pipe        = flag.Bool("p", false, "extract to stdout (pipe)")
-	junkPaths   = flag.Bool("j", false, "junk paths (extract to current directory)")
-	destDir     = flag.String("d", "", "extract files into dir")
-	help        = flag.Bool("h", false, "display this help")
+	list         = flag.Bool("l", false, "list files (short format)")
+	listVerbose  = flag.Bool("v", false, "list files (verbose format)")
+	test         = flag.Bool("t", false, "test archive integrity")
+	quiet        = flag.Bool("q", false, "quiet operation")
+	overwrite    = flag.Bool("o", false, "overwrite files without prompting")
+	never        = flag.Bool("n", false, "never overwrite existing files")
+	pipe         = flag.Bool("p", false, "extract to stdout (pipe)")
+	junkPaths    = flag.Bool("j", false, "junk paths (extract to current directory)")
+	destDir      = flag.String("d", "", "extract files into dir")
+	help         = flag.Bool("h", false, "display this help")
+	strict       = flag.Bool("strict", false, "fail on malformed or inconsistent headers")
+	salvage      = flag.Bool("recover", false, "recover entries by scanning local headers (damaged archives)")
+	passwordArg  = flag.String("P", "", "password for encrypted entries (insecure: visible to other users)")
+	restoreOwner = flag.Bool("X", false, "restore owner (UID/GID) and extended attributes (needs root)")
+	showComment  = flag.Bool("z", false, "display the archive comment only")
+	verify       = flag.Bool("verify", false, "verify the archive's Ed25519 signature")
+	trustedKey   = flag.String("key", "", "trusted Ed25519 public key for -verify (PKIX PEM)")
+	sha256Sums   = flag.Bool("sha256", false, "with -verify, also decompress every entry and print its SHA-256")
+
+	// Resource limits for untrusted archives (0 = no limit)
+	maxSize    = flag.Int64("max-size", 0, "max uncompressed bytes per entry")
+	maxTotal   = flag.Int64("max-total", 0, "max uncompressed bytes for the whole archive")
+	maxRatio   = flag.Int64("max-ratio", 0, "max compression ratio per entry")
+	maxEntries = flag.Int("max-entries", 0, "max number of entries in the archive")
 )
 
 func main() {
@@ -58,7 +77,19 @@ func main() {
 	}
 
 	// Get all files in archive
-	files, err := compress.ListFiles(data)
+	var files []*compress.FileInfo
+	switch {
+	case *salvage:
+		files, err = compress.RecoverFiles(data, limits())
+		if err == nil && !*quiet {
+			fmt.Fprintf(os.Stderr, "unz: recovered %d entr%s from '%s'\n",
+				len(files), pluralY(len(files)), archivePath)
+		}
+	case *strict:
+		files, err = compress.ListFilesStrict(data, limits())
+	default:
+		files, err = compress.ListFilesWithLimits(data, limits())
+	}
 	if err != nil {
 		fatal("cannot read archive: %v", err)
 	}
@@ -66,9 +97,22 @@ func main() {
 	// Collect patterns to extract (if specified)
 	patterns := flag.Args()[1:]
 
+	// Comment only
+	if *showComment {
+		fmt.Printf("Archive:  %s\n", archivePath)
+		printComment(data)
+		return
+	}
+
 	// List mode
 	if *list || *listVerbose {
-		printListing(archivePath, files, *listVerbose)
+		printListing(archivePath, data, files, *listVerbose)
+		return
+	}
+
+	// Verify mode
+	if *verify || *trustedKey != "" {
+		verifyArchive(archivePath, data, files)
 		return
 	}
 
@@ -79,11 +123,14 @@ func main() {
 	}
 
 	// Extract
-	extractFiles(archivePath, data, files, patterns)
+	if err := extractFiles(archivePath, data, files, patterns); err != nil {
+		fatal("%v", err)
+	}
 }
 
-func printListing(archivePath string, files []*compress.FileInfo, verbose bool) {
+func printListing(archivePath string, data []byte, files []*compress.FileInfo, verbose bool) {
 	fmt.Printf("Archive:  %s\n", archivePath)
+	printComment(data)
 
 	var totalSize, totalComp int64
 
@@ -112,6 +159,8 @@ func printListing(archivePath string, files []*compress.FileInfo, verbose bool)
 			name := info.Name
 			if info.Mode&os.ModeSymlink != 0 {
 				name = fmt.Sprintf("%s -> (symlink)", info.Name)
+			} else if info.HardLink != "" {
+				name = fmt.Sprintf("%s => %s", info.Name, info.HardLink)
 			}
 
 			fmt.Printf("%8d  %-6s  %8d %3d%% %s %s %08x  %s\n",
@@ -152,6 +201,8 @@ func printListing(archivePath string, files []*compress.FileInfo, verbose bool)
 			name := info.Name
 			if info.Mode&os.ModeSymlink != 0 {
 				name = fmt.Sprintf("%s -> (symlink)", info.Name)
+			} else if info.HardLink != "" {
+				name = fmt.Sprintf("%s => %s", info.Name, info.HardLink)
 			}
 
 			fmt.Printf("%9d  %s %s   %s\n",
@@ -168,9 +219,22 @@ func printListing(archivePath string, files []*compress.FileInfo, verbose bool)
 	}
 }
 
+// printComment prints the archive comment, if any, like unzip.
+func printComment(data []byte) {
+	comment, err := compress.ReadComment(data)
+	if err != nil {
+		fatal("cannot read archive comment: %v", err)
+	}
+	if comment != "" {
+		fmt.Println(comment)
+	}
+}
+
 func testArchive(archivePath string, data []byte, files []*compress.FileInfo) {
 	vocab := vocab.Default()
 	decomp := compress.New(vocab)
+	decomp.SetLimits(limits())
+	decomp.SetPassword(password(archivePath, files))
 
 	errors := 0
 	for _, info := range files {
@@ -184,7 +248,16 @@ func testArchive(archivePath string, data []byte, files []*compress.FileInfo) {
 		}
 
 		_, err := decomp.DecompressFile(data, info)
-		if err != nil {
+		if sumErr, ok := err.(*compress.ChecksumError); ok {
+			// Report like Info-ZIP: "bad CRC xxxxxxxx  (should be yyyyyyyy)"
+			msg := sumErr.Problem()
+			if *quiet {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", info.Name, msg)
+			} else {
+				fmt.Println(msg)
+			}
+			errors++
+		} else if err != nil {
 			if !*quiet {
 				fmt.Println("error")
 			}
@@ -204,9 +277,76 @@ func testArchive(archivePath string, data []byte, files []*compress.FileInfo) {
 	fmt.Println("No errors detected in compressed data of", archivePath)
 }
 
-func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, patterns []string) {
+func verifyArchive(archivePath string, data []byte, files []*compress.FileInfo) {
+	var trusted ed25519.PublicKey
+	if *trustedKey != "" {
+		var err error
+		if trusted, err = loadPublicKey(*trustedKey); err != nil {
+			fatal("cannot load key: %v", err)
+		}
+	}
+
+	signer, err := compress.VerifySignature(data, trusted)
+	if err != nil {
+		fatal("%s: %v", archivePath, err)
+	}
+	if !*quiet {
+		fmt.Printf("%s: signature OK, Ed25519 key %s\n", archivePath, base64.StdEncoding.EncodeToString(signer))
+		if trusted == nil {
+			fmt.Println("  (no -key given: the archive is intact, but the signer is not checked)")
+		}
+	}
+
+	if !*sha256Sums {
+		return
+	}
+
+	decomp := compress.New(vocab.Default())
+	decomp.SetLimits(limits())
+	decomp.SetPassword(password(archivePath, files))
+
+	errors := 0
+	for _, info := range files {
+		if strings.HasSuffix(info.Name, "/") {
+			continue
+		}
+		content, err := decomp.DecompressFile(data, info)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", info.Name, err)
+			errors++
+			continue
+		}
+		fmt.Printf("%x  %s\n", sha256.Sum256(content), info.Name)
+	}
+
+	if errors > 0 {
+		fmt.Fprintf(os.Stderr, "%d error(s) detected in %s\n", errors, archivePath)
+		os.Exit(1)
+	}
+}
+
+// extractFiles extracts the entries matching patterns (all if none). It
+// stops at the first entry it cannot write; directories extracted up to
+// then still get their modes and times.
+func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, patterns []string) error {
 	vocab := vocab.Default()
 	decomp := compress.New(vocab)
+	decomp.SetLimits(limits())
+	decomp.SetPassword(password(archivePath, files))
+
+	byName := make(map[string]*compress.FileInfo, len(files))
+	for _, info := range files {
+		byName[info.Name] = info
+	}
+
+	// Directory modes and times are applied last, deepest first, so that
+	// creating their contents does not undo them; this also happens when
+	// extraction stops early, so errors are returned rather than fatal
+	var dirs []extractedDir
+	defer func() { restoreDirs(dirs) }()
+
+	// Regular files written by this run, the only hard link targets
+	extracted := make(map[string]bool)
 
 	for _, info := range files {
 		// Check if file matches patterns (if any)
@@ -214,14 +354,8 @@ func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, p
 			continue
 		}
 
-		// Determine output path
-		outputPath := info.Name
-		if *junkPaths {
-			outputPath = filepath.Base(outputPath)
-		}
-		if *destDir != "" {
-			outputPath = filepath.Join(*destDir, outputPath)
-		}
+		outputPath := outputPathFor(info.Name)
+		delete(extracted, info.Name)
 
 		// Handle directory
 		if strings.HasSuffix(info.Name, "/") {
@@ -230,6 +364,8 @@ func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, p
 					fmt.Printf("   creating: %s\n", outputPath)
 				}
 				os.MkdirAll(outputPath, info.Mode|0755)
+				restoreOwnership(outputPath, info)
+				dirs = append(dirs, extractedDir{outputPath, info})
 			}
 			continue
 		}
@@ -266,10 +402,49 @@ func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, p
 			}
 		}
 
+		// Device nodes and named pipes have no content
+		if info.Mode&(os.ModeDevice|os.ModeNamedPipe) != 0 {
+			if !*pipe {
+				if !*quiet {
+					fmt.Printf("   creating: %s (special)\n", outputPath)
+				}
+				os.Remove(outputPath)
+				if err := mknod(outputPath, info); err != nil {
+					fmt.Fprintf(os.Stderr, "unz: cannot create '%s': %v\n", outputPath, err)
+					continue
+				}
+				restoreOwnership(outputPath, info)
+				restoreMode(outputPath, info)
+				restoreTimes(outputPath, info)
+			}
+			continue
+		}
+
+		// Hard links point at a regular entry extracted earlier in this
+		// run; if that is not possible (not extracted, other filesystem)
+		// the content is written out again from the linked entry
+		source := info
+		if info.HardLink != "" {
+			var err error
+			if source, err = hardLinkSource(info, byName); err != nil {
+				fmt.Fprintf(os.Stderr, "unz: %s: %v\n", info.Name, err)
+				continue
+			}
+			if !*pipe && extracted[info.HardLink] {
+				os.Remove(outputPath)
+				if err := os.Link(outputPathFor(info.HardLink), outputPath); err == nil {
+					if !*quiet {
+						fmt.Printf("    linking: %s => %s\n", outputPath, info.HardLink)
+					}
+					continue
+				}
+			}
+		}
+
 		// Decompress to get content
-		content, err := decomp.DecompressFile(data, info)
+		content, err := decomp.DecompressFile(data, source)
 		if err != nil {
-			fatal("decompression failed for '%s': %v", info.Name, err)
+			return fmt.Errorf("decompression failed for '%s': %v", source.Name, err)
 		}
 
 		// Handle output
@@ -295,11 +470,15 @@ func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, p
 			os.Remove(outputPath)
 
 			if err := os.Symlink(target, outputPath); err != nil {
-				fatal("cannot create symlink '%s': %v", outputPath, err)
+				return fmt.Errorf("cannot create symlink '%s': %v", outputPath, err)
 			}
 
-			// Note: Cannot set mtime on symlinks portably in Go
-			// os.Lchtimes doesn't exist, and os.Chtimes follows symlinks
+			// os.Chtimes follows symlinks, so use lutimes-style call
+			restoreOwnership(outputPath, info)
+			if !info.ModTime.IsZero() {
+				atime, mtime := entryTimes(info)
+				lchtimes(outputPath, atime, mtime)
+			}
 		} else {
 			// Regular file
 			if !*quiet {
@@ -307,15 +486,146 @@ func extractFiles(archivePath string, data []byte, files []*compress.FileInfo, p
 			}
 
 			if err := os.WriteFile(outputPath, content, info.Mode&os.ModePerm); err != nil {
-				fatal("cannot write '%s': %v", outputPath, err)
+				return fmt.Errorf("cannot write '%s': %v", outputPath, err)
 			}
 
-			// Set modification time if available
-			if !info.ModTime.IsZero() {
-				os.Chtimes(outputPath, info.ModTime, info.ModTime)
-			}
+			restoreOwnership(outputPath, info)
+			restoreMode(outputPath, info)
+			restoreTimes(outputPath, info)
+			extracted[info.Name] = true
+		}
+	}
+	return nil
+}
+
+// hardLinkSource returns the entry the hard link entry info links to. The
+// target comes from the archive, so it must be a relative path without ".."
+// naming another regular entry of the archive; anything else could link to
+// a file outside the extraction.
+func hardLinkSource(info *compress.FileInfo, byName map[string]*compress.FileInfo) (*compress.FileInfo, error) {
+	target := info.HardLink
+	if filepath.IsAbs(target) || strings.HasPrefix(target, "/") || strings.HasPrefix(target, `\`) {
+		return nil, fmt.Errorf("hard link target '%s' is an absolute path", target)
+	}
+	for _, part := range strings.FieldsFunc(target, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return nil, fmt.Errorf("hard link target '%s' leaves the archive", target)
 		}
 	}
+	source := byName[target]
+	if source == nil || target == info.Name {
+		return nil, fmt.Errorf("hard link target '%s' not in archive", target)
+	}
+	if strings.HasSuffix(source.Name, "/") || source.HardLink != "" ||
+		source.Mode&(os.ModeSymlink|os.ModeDevice|os.ModeNamedPipe) != 0 {
+		return nil, fmt.Errorf("hard link target '%s' is not a regular file", target)
+	}
+	return source, nil
+}
+
+// extractedDir is a directory whose mode and times are restored after all
+// entries are extracted.
+type extractedDir struct {
+	path string
+	info *compress.FileInfo
+}
+
+// restoreDirs applies directory modes and times, deepest first, so that
+// setting a parent does not precede changes to its children and read-only
+// directories do not block extraction.
+func restoreDirs(dirs []extractedDir) {
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return strings.Count(dirs[i].path, string(filepath.Separator)) >
+			strings.Count(dirs[j].path, string(filepath.Separator))
+	})
+	for _, dir := range dirs {
+		restoreMode(dir.path, dir.info)
+		restoreTimes(dir.path, dir.info)
+	}
+}
+
+// restoreMode applies the recorded permissions exactly, regardless of the
+// umask. Set-user-ID and set-group-ID bits are only restored with -X.
+func restoreMode(path string, info *compress.FileInfo) {
+	mode := info.Mode & (os.ModePerm | os.ModeSticky)
+	if *restoreOwner {
+		mode |= info.Mode & (os.ModeSetuid | os.ModeSetgid)
+	}
+	os.Chmod(path, mode)
+}
+
+// restoreTimes sets the modification time, and the access time if recorded.
+func restoreTimes(path string, info *compress.FileInfo) {
+	if info.ModTime.IsZero() {
+		return
+	}
+	atime, mtime := entryTimes(info)
+	os.Chtimes(path, atime, mtime)
+}
+
+// entryTimes returns the access and modification times to restore; the
+// access time defaults to the modification time.
+func entryTimes(info *compress.FileInfo) (atime, mtime time.Time) {
+	atime = info.ModTime
+	if !info.Unix.Atime.IsZero() {
+		atime = info.Unix.Atime
+	}
+	return atime, info.ModTime
+}
+
+// outputPathFor returns where the named entry is extracted to.
+func outputPathFor(name string) string {
+	outputPath := name
+	if *junkPaths {
+		outputPath = filepath.Base(outputPath)
+	}
+	if *destDir != "" {
+		outputPath = filepath.Join(*destDir, outputPath)
+	}
+	return outputPath
+}
+
+// restoreOwnership applies the recorded owner and extended attributes with
+// -X. Changing the owner normally needs root; failures are reported but do
+// not stop extraction.
+func restoreOwnership(path string, info *compress.FileInfo) {
+	if !*restoreOwner {
+		return
+	}
+	if info.Unix.HasOwner {
+		if err := os.Lchown(path, info.Unix.UID, info.Unix.GID); err != nil {
+			fmt.Fprintf(os.Stderr, "unz: cannot restore owner of '%s': %v\n", path, err)
+		}
+	}
+	if len(info.Unix.Xattrs) > 0 && info.Mode&os.ModeSymlink == 0 {
+		if err := setXattrs(path, info.Unix.Xattrs); err != nil {
+			fmt.Fprintf(os.Stderr, "unz: cannot restore attributes of '%s': %v\n", path, err)
+		}
+	}
+}
+
+// password returns the -P password, or prompts for one if the archive has
+// encrypted entries and none was given.
+func password(archivePath string, files []*compress.FileInfo) string {
+	if *passwordArg != "" {
+		return *passwordArg
+	}
+	for _, info := range files {
+		if info.Encryption != compress.EncryptionNone {
+			return readPassword(fmt.Sprintf("[%s] %s password: ", archivePath, info.Name))
+		}
+	}
+	return ""
+}
+
+// limits returns the resource limits set on the command line.
+func limits() compress.Limits {
+	return compress.Limits{
+		MaxEntrySize:   *maxSize,
+		MaxArchiveSize: *maxTotal,
+		MaxRatio:       *maxRatio,
+		MaxEntries:     *maxEntries,
+	}
 }
 
 // matchesAny checks if name matches any of the patterns.
@@ -341,28 +651,61 @@ func plural(n int) string {
 	return "s"
 }
 
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
+
 func usage() {
-	fmt.Fprintf(os.Stderr, `Usage: unz [-ltvqonpj] [-d dir] archive[.zip] [file...]
+	fmt.Fprintf(os.Stderr, `Usage: unz [-ltvqonpjzX] [-d dir] [-P password] archive[.zip] [file...]
 
 Extract files from ZIP archive. Supports standard ZIP plus BPE methods.
 
 Options:
   -l        list files (short format)
   -v        list files with verbose information
-  -t        test archive integrity
+  -z        display the archive comment only
+  -t        test archive integrity (decompress and check CRC-32, size and
+            SHA-256 when stored)
   -q        quiet operation
   -o        overwrite files without prompting
   -n        never overwrite existing files
   -p        extract to stdout (pipe)
   -j        junk paths (extract to current directory)
   -d dir    extract files into specified directory
+  -X        restore owner (UID/GID), set-user-ID/set-group-ID bits and
+            extended attributes/ACLs; changing the owner normally requires
+            running as root
+  -P pass   password for encrypted entries (default: prompt if needed)
   -h        display this help
+  -strict   fail on truncated central directory or local/central header mismatch
+  -recover  salvage entries by scanning local headers (damaged or truncated archive)
+
+Signatures:
+  -verify   verify the archive's Ed25519 signature
+  -key pub  require the signature to be made by this public key (PKIX PEM,
+            e.g. from "openssl pkey -in key.pem -pubout"); implies -verify
+  -sha256   with -verify, also decompress every entry (checking CRC-32, size
+            and stored SHA-256) and print its SHA-256 in sha256sum format
+
+Resource limits (for untrusted archives, 0 = no limit):
+  -max-size N     max uncompressed bytes per entry
+  -max-total N    max uncompressed bytes for the whole archive
+  -max-ratio N    max uncompressed/compressed ratio per entry
+  -max-entries N  max number of entries in the archive
 
 Supported methods:
   Method 0  (Stored)  - no compression
   Method 8  (Deflate) - standard ZIP compression
   Method 85 (Unzlate) - BPE + ANS
   Method 86 (Bpelate) - BPE + DEFLATE
+  Method 87 (Mixlate) - BPE with a vocabulary per segment + DEFLATE
+  Method 88 (Exelate) - x86 branch filter + DEFLATE
+
+Encryption: WinZip AES (AE-1/AE-2, 128/192/256-bit) and traditional
+PKWARE ZipCrypto (read-only).
 
 Examples:
   unz archive.zip                  Extract all files
@@ -372,6 +715,11 @@ Examples:
   unz -d /tmp archive.zip          Extract to /tmp
   unz archive.zip '*.txt'          Extract only .txt files
   unz -p archive.zip > file        Extract to stdout
+  unz -key release.pub -verify rel.zip
+                                   Check a release archive's provenance
+  unz -recover -d out truncated.zip Salvage complete entries of a truncated upload
+  unz -max-total 1073741824 -max-ratio 100 upload.zip
+                                   Extract with decompression bomb limits
 
 `)
 }
@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/binary"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -304,3 +307,174 @@ func makeBinaryData(size int) []byte {
 func testModTime() time.Time {
 	return time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
 }
+
+func TestExtractRestoresMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	dirTime := time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC)
+	fileTime := time.Date(2021, 6, 15, 12, 30, 0, 0, time.UTC)
+	linkTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	archive := compress.NewArchive(compress.New(vocab.Default()))
+	archive.AddDirectory("tree", dirTime, 0775)
+	archive.AddDirectory("tree/shared", dirTime, os.ModeSticky|0777)
+	archive.AddDirectory("tree/locked", dirTime, 0555)
+	archive.AddStore([]byte("data\n"), "tree/shared/file.txt", fileTime, 0664)
+	archive.AddStore([]byte("inside\n"), "tree/locked/file.txt", fileTime, 0444)
+	archive.AddSymlink("tree/link", "shared/file.txt", linkTime, 0777)
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	files, err := compress.ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+
+	defer func(d string, q, o bool) { *destDir, *quiet, *overwrite = d, q, o }(*destDir, *quiet, *overwrite)
+	*destDir, *quiet, *overwrite = tmpDir, true, true
+	defer os.Chmod(filepath.Join(tmpDir, "tree/locked"), 0755) // let TempDir clean up
+	if err := extractFiles("test.zip", data, files, nil); err != nil {
+		t.Fatalf("extractFiles: %v", err)
+	}
+
+	checks := []struct {
+		path  string
+		mode  os.FileMode
+		mtime time.Time
+	}{
+		{"tree", os.ModeDir | 0775, dirTime},
+		{"tree/shared", os.ModeDir | os.ModeSticky | 0777, dirTime},
+		{"tree/locked", os.ModeDir | 0555, dirTime},
+		{"tree/shared/file.txt", 0664, fileTime},
+		{"tree/locked/file.txt", 0444, fileTime},
+	}
+	for _, c := range checks {
+		fi, err := os.Lstat(filepath.Join(tmpDir, c.path))
+		if err != nil {
+			t.Errorf("%s: %v", c.path, err)
+			continue
+		}
+		if fi.Mode() != c.mode {
+			t.Errorf("%s: mode %v, want %v", c.path, fi.Mode(), c.mode)
+		}
+		if !fi.ModTime().Equal(c.mtime) {
+			t.Errorf("%s: mtime %v, want %v", c.path, fi.ModTime(), c.mtime)
+		}
+	}
+
+	if runtime.GOOS == "linux" {
+		fi, err := os.Lstat(filepath.Join(tmpDir, "tree/link"))
+		if err != nil || fi.Mode()&os.ModeSymlink == 0 {
+			t.Fatalf("symlink not created: %v", err)
+		}
+		if !fi.ModTime().Equal(linkTime) {
+			t.Errorf("symlink mtime %v, want %v", fi.ModTime(), linkTime)
+		}
+	}
+}
+
+func TestExtractFailureRestoresDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+	dirTime := time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	archive := compress.NewArchive(compress.New(vocab.Default()))
+	archive.AddDirectory("tree", dirTime, 0750)
+	archive.AddStore([]byte("intact\n"), "tree/good.txt", testModTime(), 0644)
+	archive.AddStore([]byte("corrupt\n"), "tree/bad.txt", testModTime(), 0644)
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	data = bytes.Replace(data, []byte("corrupt\n"), []byte("CORRUPT\n"), 1)
+	files, err := compress.ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+
+	defer func(d string, q, o bool) { *destDir, *quiet, *overwrite = d, q, o }(*destDir, *quiet, *overwrite)
+	*destDir, *quiet, *overwrite = tmpDir, true, true
+	if err := extractFiles("test.zip", data, files, nil); err == nil {
+		t.Fatal("extractFiles succeeded with a corrupt entry")
+	}
+
+	// The directory was created before the failure and keeps its metadata
+	fi, err := os.Stat(filepath.Join(tmpDir, "tree"))
+	if err != nil {
+		t.Fatalf("tree: %v", err)
+	}
+	if fi.Mode() != os.ModeDir|0750 {
+		t.Errorf("tree: mode %v, want %v", fi.Mode(), os.ModeDir|0750)
+	}
+	if !fi.ModTime().Equal(dirTime) {
+		t.Errorf("tree: mtime %v, want %v", fi.ModTime(), dirTime)
+	}
+}
+
+// hardLinkArchive returns an archive holding a file and a hard link to it,
+// with the link's target rewritten to target after the fact, as a crafted
+// archive would carry it.
+func hardLinkArchive(t *testing.T, target string) []byte {
+	t.Helper()
+	name := strings.Repeat("a", len(target))
+	archive := compress.NewArchive(compress.New(vocab.Default()))
+	archive.AddStore([]byte("archived\n"), name, testModTime(), 0644)
+	if err := archive.AddHardLink("link.txt", name, testModTime(), 0644); err != nil {
+		t.Fatalf("AddHardLink: %v", err)
+	}
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	field := binary.LittleEndian.AppendUint16([]byte{0x4C, 0x55}, uint16(len(name)))
+	return bytes.ReplaceAll(data, append(field, name...), append(field, target...))
+}
+
+func TestExtractHardLinkTargets(t *testing.T) {
+	tmpDir := t.TempDir()
+	outside := filepath.Join(tmpDir, "outside.txt")
+	dest := filepath.Join(tmpDir, "dest")
+
+	defer func(d string, q, o bool) { *destDir, *quiet, *overwrite = d, q, o }(*destDir, *quiet, *overwrite)
+	*destDir, *quiet, *overwrite = dest, true, true
+
+	for _, target := range []string{outside, "../outside.txt", "x/../../outside.txt", "missing.txt"} {
+		if err := os.WriteFile(outside, []byte("secret\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		os.RemoveAll(dest)
+
+		data := hardLinkArchive(t, target)
+		files, err := compress.ListFiles(data)
+		if err != nil {
+			t.Fatalf("ListFiles: %v", err)
+		}
+		if files[1].HardLink != target {
+			t.Fatalf("crafted link target = %q, want %q", files[1].HardLink, target)
+		}
+		extractFiles("test.zip", data, files, nil)
+
+		link := filepath.Join(dest, "link.txt")
+		if fi, err := os.Stat(link); err == nil {
+			if ofi, _ := os.Stat(outside); os.SameFile(fi, ofi) {
+				t.Errorf("%s: link.txt is linked to a file outside the archive", target)
+			}
+			t.Errorf("%s: link.txt created for an invalid target", target)
+		}
+		if content, _ := os.ReadFile(outside); string(content) != "secret\n" {
+			t.Errorf("%s: outside file changed to %q", target, content)
+		}
+	}
+
+	// A valid target extracted earlier in the run is linked
+	os.RemoveAll(dest)
+	data := hardLinkArchive(t, "aaaa")
+	files, _ := compress.ListFiles(data)
+	extractFiles("test.zip", data, files, nil)
+	fi, err := os.Stat(filepath.Join(dest, "link.txt"))
+	if err != nil {
+		t.Fatalf("link.txt not extracted: %v", err)
+	}
+	if tfi, _ := os.Stat(filepath.Join(dest, "aaaa")); !os.SameFile(fi, tfi) {
+		t.Error("link.txt is not a hard link to its target")
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/ha1tch/unz/pkg/compress"
+)
+
+// mknod creates a device node or named pipe. Device nodes need root.
+func mknod(path string, info *compress.FileInfo) error {
+	perm := uint32(info.Mode.Perm())
+	switch {
+	case info.Mode&os.ModeNamedPipe != 0:
+		return syscall.Mkfifo(path, perm)
+	case info.Mode&os.ModeCharDevice != 0:
+		return syscall.Mknod(path, syscall.S_IFCHR|perm, mkdev(info.DevMajor, info.DevMinor))
+	default:
+		return syscall.Mknod(path, syscall.S_IFBLK|perm, mkdev(info.DevMajor, info.DevMinor))
+	}
+}
+
+// mkdev encodes a device number the way glibc's makedev does.
+func mkdev(major, minor uint32) int {
+	dev := uint64(major&0xfff)<<8 | uint64(major&^0xfff)<<32 |
+		uint64(minor&0xff) | uint64(minor&^0xff)<<12
+	return int(dev)
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+
+	"github.com/ha1tch/unz/pkg/compress"
+)
+
+// mknod is only implemented on Linux.
+func mknod(path string, info *compress.FileInfo) error {
+	return errors.New("device nodes and named pipes are not supported on this platform")
+}
@@ -0,0 +1,14 @@
+package main
+
+import "syscall"
+
+// setXattrs sets extended attributes on path (following symlinks).
+func setXattrs(path string, xattrs map[string][]byte) error {
+	var firstErr error
+	for name, value := range xattrs {
+		if err := syscall.Setxattr(path, name, value, 0); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
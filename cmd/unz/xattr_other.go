@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// setXattrs is only implemented on Linux.
+func setXattrs(path string, xattrs map[string][]byte) error {
+	return errors.New("extended attributes are not supported on this platform")
+}
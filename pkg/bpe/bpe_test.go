@@ -212,6 +212,50 @@ func TestEncoderRoundtrip(t *testing.T) {
 	}
 }
 
+func TestEncodeShortest(t *testing.T) {
+	tokens := map[string]int{}
+	for i := 0; i < 256; i++ {
+		tokens[string([]byte{byte(i)})] = i
+	}
+	tokens["ab"] = 256
+	tokens["abc"] = 257
+	tokens["cdef"] = 258
+	tokens["the"] = 259
+	encoder := NewEncoder(NewVocabulary(tokens))
+
+	testCases := []struct {
+		text   string
+		greedy int // tokens from Encode
+		want   int // tokens from EncodeShortest
+	}{
+		// Greedy takes "abc" and is left with "d", "e", "f"
+		{"abcdef", 4, 2},
+		{"the", 1, 1},
+		{"xyz", 3, 3},
+		{"", 0, 0},
+	}
+	for _, tc := range testCases {
+		text := []byte(tc.text)
+		if got := len(encoder.Encode(text)); got != tc.greedy {
+			t.Errorf("Encode(%q): %d tokens, want %d", tc.text, got, tc.greedy)
+		}
+		ids := encoder.EncodeShortest(text)
+		if len(ids) != tc.want {
+			t.Errorf("EncodeShortest(%q): %d tokens, want %d", tc.text, len(ids), tc.want)
+		}
+		if !bytes.Equal(encoder.Decode(ids), text) {
+			t.Errorf("EncodeShortest(%q): roundtrip failed", tc.text)
+		}
+	}
+
+	// Never more tokens than greedy
+	basic := NewEncoder(CreateBasicVocab())
+	text := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog\x00\xff ", 20))
+	if s, g := len(basic.EncodeShortest(text)), len(basic.Encode(text)); s > g {
+		t.Errorf("EncodeShortest: %d tokens, greedy %d", s, g)
+	}
+}
+
 func TestEncoderVocabulary(t *testing.T) {
 	vocab := CreateBasicVocab()
 	encoder := NewEncoder(vocab)
@@ -102,6 +102,46 @@ func (e *Encoder) Encode(text []byte) []int {
 	return result
 }
 
+// EncodeShortest tokenizes text into the fewest tokens, by dynamic
+// programming over every token matching at each position, in O(n·k) for
+// tokens of up to k bytes. Greedy longest-match can take a long token that
+// leaves an awkward remainder; this never does. Ties go to the longer
+// token first. The tokens decode like those of Encode.
+func (e *Encoder) EncodeShortest(text []byte) []int {
+	if len(text) == 0 {
+		return nil
+	}
+
+	n := len(text)
+	count := make([]int, n+1)  // fewest tokens for text[i:]
+	length := make([]int, n+1) // length of the first of them
+	id := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		// Single byte fallback, as in Encode
+		count[i], length[i], id[i] = count[i+1]+1, 1, int(text[i])
+		if byteID, ok := e.vocab.GetID(text[i : i+1]); ok {
+			id[i] = byteID
+		}
+
+		node := e.trie.root
+		for j := i; j < n; j++ {
+			node = node.children[text[j]]
+			if node == nil {
+				break
+			}
+			if node.isToken && count[j+1]+1 <= count[i] {
+				count[i], length[i], id[i] = count[j+1]+1, j+1-i, node.tokenID
+			}
+		}
+	}
+
+	result := make([]int, 0, count[0])
+	for i := 0; i < n; i += length[i] {
+		result = append(result, id[i])
+	}
+	return result
+}
+
 // Decode converts token IDs back to bytes.
 func (e *Encoder) Decode(ids []int) []byte {
 	return e.vocab.Decode(ids)
@@ -0,0 +1,67 @@
+package compress
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// maxCommentLen is the largest comment a ZIP length field can describe.
+const maxCommentLen = 0xFFFF
+
+// ErrCommentTooLong is returned for archive or file comments over 64 KiB.
+var ErrCommentTooLong = errors.New("compress: comment exceeds 65535 bytes")
+
+// SetComment sets the archive comment, written after the end of central
+// directory record. Info-ZIP shows it with unzip -z.
+func (a *Archive) SetComment(comment string) error {
+	if len(comment) > maxCommentLen {
+		return ErrCommentTooLong
+	}
+	a.comment = comment
+	return nil
+}
+
+// Comment returns the archive comment.
+func (a *Archive) Comment() string {
+	return a.comment
+}
+
+// SetEntryComment sets the file comment of the named entry, stored in its
+// central directory record. It reports whether the entry exists.
+func (a *Archive) SetEntryComment(name, comment string) (bool, error) {
+	if len(comment) > maxCommentLen {
+		return false, ErrCommentTooLong
+	}
+	i, ok := a.index[name]
+	if !ok {
+		return false, nil
+	}
+	a.entries[i].comment = comment
+	return true, nil
+}
+
+// ReadComment returns the archive comment of a ZIP archive. A signature
+// written by Archive.Sign is not part of the comment and is left out.
+func ReadComment(data []byte) (string, error) {
+	comment, _, err := eocdComment(data)
+	if err != nil {
+		return "", err
+	}
+	text, _, _ := splitSignature(comment)
+	return strings.TrimSuffix(text, "\n"), nil
+}
+
+// eocdComment returns the raw archive comment and the offset of the end of
+// central directory record.
+func eocdComment(data []byte) (string, int, error) {
+	eocdOffset := findEndCentralDir(data)
+	if eocdOffset < 0 {
+		return "", -1, ErrInvalidFormat
+	}
+	commentLen := int(binary.LittleEndian.Uint16(data[eocdOffset+20 : eocdOffset+22]))
+	if eocdOffset+22+commentLen > len(data) {
+		return "", -1, ErrCorrupted
+	}
+	return string(data[eocdOffset+22 : eocdOffset+22+commentLen]), eocdOffset, nil
+}
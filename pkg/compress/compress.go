@@ -17,10 +17,13 @@ package compress
 import (
 	"bytes"
 	"compress/flate"
+	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"strings"
 	"time"
@@ -39,6 +42,8 @@ const (
 	MethodDEFLATE Method = 8  // Standard DEFLATE
 	MethodUNZLATE Method = 85 // 'U' = BPE + ANS
 	MethodBPELATE Method = 86 // 'V' = BPE + DEFLATE (vocabulary-assisted)
+	MethodMIXLATE Method = 87 // 'W' = BPE with a vocabulary per segment + DEFLATE
+	MethodEXELATE Method = 88 // 'X' = x86 branch filter + DEFLATE
 )
 
 func (m Method) String() string {
@@ -51,11 +56,21 @@ func (m Method) String() string {
 		return "Unzlate"
 	case MethodBPELATE:
 		return "Bpelate"
+	case MethodMIXLATE:
+		return "Mixlate"
+	case MethodEXELATE:
+		return "Exelate"
 	default:
 		return "Unknown"
 	}
 }
 
+// usesVocab reports whether entries compressed with the method record the
+// vocabulary they were tokenized with in a VocabInfo extra field.
+func usesVocab(m Method) bool {
+	return m == MethodUNZLATE || m == MethodBPELATE || m == MethodMIXLATE
+}
+
 // ZIP signatures
 const (
 	sigLocalFile   = 0x04034b50
@@ -76,6 +91,12 @@ const (
 	unixModeRegular  = 0100000 // S_IFREG - regular file
 	unixModeDir      = 0040000 // S_IFDIR - directory
 	unixModeSymlink  = 0120000 // S_IFLNK - symbolic link
+	unixModeFIFO     = 0010000 // S_IFIFO - named pipe
+	unixModeCharDev  = 0020000 // S_IFCHR - character device
+	unixModeBlockDev = 0060000 // S_IFBLK - block device
+	unixModeSetuid   = 0004000 // S_ISUID
+	unixModeSetgid   = 0002000 // S_ISGID
+	unixModeSticky   = 0001000 // S_ISVTX
 )
 
 // Extra field IDs
@@ -214,8 +235,44 @@ var (
 	ErrTooShort      = errors.New("compress: data too short")
 	ErrUnsupported   = errors.New("compress: unsupported compression method")
 	ErrFileTooLarge  = errors.New("compress: file exceeds 4GB limit (ZIP64 not supported)")
+	ErrChecksum      = errors.New("compress: checksum mismatch")
 )
 
+// ChecksumError reports an entry whose decompressed content does not match
+// the CRC-32 or uncompressed size recorded in its header. It wraps ErrChecksum.
+type ChecksumError struct {
+	Name     string
+	CRC32    uint32 // CRC-32 of the decompressed content
+	WantCRC  uint32 // CRC-32 recorded in the header
+	Size     int64  // length of the decompressed content
+	WantSize int64  // uncompressed size recorded in the header
+
+	// Set when only the SHA-256 extra field disagrees
+	SHA256     []byte
+	WantSHA256 []byte
+}
+
+func (e *ChecksumError) Error() string {
+	return e.Name + ": " + e.Problem()
+}
+
+// Problem describes the mismatch without the entry name, as Info-ZIP's
+// unzip -t reports it: "bad CRC 1a2b3c4d  (should be 5e6f7a8b)".
+func (e *ChecksumError) Problem() string {
+	switch {
+	case e.Size != e.WantSize:
+		return fmt.Sprintf("bad length %d  (should be %d)", e.Size, e.WantSize)
+	case e.CRC32 != e.WantCRC:
+		return fmt.Sprintf("bad CRC %08x  (should be %08x)", e.CRC32, e.WantCRC)
+	default:
+		return fmt.Sprintf("bad SHA-256 %x  (should be %x)", e.SHA256, e.WantSHA256)
+	}
+}
+
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksum
+}
+
 // FileInfo contains metadata about a file in the archive.
 type FileInfo struct {
 	Name     string
@@ -227,6 +284,23 @@ type FileInfo struct {
 	Mode     os.FileMode // Unix permissions
 	Offset   int64       // offset of local header
 	Vocab    VocabInfo   // vocabulary info for BPELATE
+	SHA256   []byte      // SHA-256 of the content, nil if not stored
+	Comment  string      // file comment
+	Unix     UnixAttrs   // owner, atime/ctime and xattrs, if recorded
+	HardLink string      // for hard links, the name of the linked entry
+
+	// Device number of character and block device entries
+	DevMajor uint32
+	DevMinor uint32
+
+	// Encryption is the cipher protecting the entry. Method is always the
+	// real compression method, also for WinZip AES entries (method 99).
+	Encryption Encryption
+
+	centralExtra []byte // raw central directory extra field
+	flags        uint16 // general purpose flags
+	dosTime      uint16 // DOS time field (ZipCrypto check byte source)
+	aesVersion   uint16 // WinZip AES vendor version (AE-1 or AE-2)
 }
 
 // Compressor provides ZIP-compatible compression.
@@ -239,6 +313,20 @@ type Compressor struct {
 	goEncoder *bpe.Encoder
 	pyEncoder *bpe.Encoder
 	jsEncoder *bpe.Encoder
+
+	// Extraction limits and uncompressed bytes produced so far
+	limits    Limits
+	extracted int64
+
+	// Password for encrypted entries
+	password string
+
+	// User file name mappings and sampling for content detection
+	nameMap  *detect.NameMap
+	sampling detect.Sampling
+
+	// Compression level; 0 is LevelDefault
+	level int
 }
 
 // New creates a new compressor with the given BPE vocabulary.
@@ -249,6 +337,19 @@ func New(vocab *bpe.Vocabulary) *Compressor {
 	}
 }
 
+// SetNameMap sets user mappings from file names to content types, used
+// with the built-in extension and file name hints when choosing how to
+// compress an entry. A nil map leaves only the built-in hints.
+func (c *Compressor) SetNameMap(m *detect.NameMap) {
+	c.nameMap = m
+}
+
+// SetSampling sets the parts of large files that content detection
+// analyses. The zero Sampling is detect.DefaultSampling.
+func (c *Compressor) SetSampling(s detect.Sampling) {
+	c.sampling = s
+}
+
 // NewWithEncoder creates a compressor with an existing encoder.
 func NewWithEncoder(enc *bpe.Encoder) *Compressor {
 	return &Compressor{
@@ -261,28 +362,77 @@ func NewWithEncoder(enc *bpe.Encoder) *Compressor {
 type Archive struct {
 	compressor *Compressor
 	entries    []archiveEntry
+	index      map[string]int // entry name -> position in entries
+	password   string         // encrypt new entries with WinZip AES if set
+	signKey    ed25519.PrivateKey
+	sha256     bool   // store SHA-256 of each entry's content
+	comment    string // archive comment
+
+	reproducible bool      // sort entries and normalise metadata in Bytes
+	epoch        time.Time // timestamp of every entry when reproducible
+
+	explain      bool // record an Explanation of each entry Add compresses
+	explanations []Explanation
 }
 
 type archiveEntry struct {
 	name       string
 	data       []byte
 	compressed []byte
+	size       int64 // uncompressed size
 	method     Method
 	crc        uint32
 	modTime    time.Time
 	mode       os.FileMode
 	vocabInfo  VocabInfo
-	isSymlink  bool   // true if this is a symbolic link
-	linkTarget string // target path for symlinks
+	isSymlink  bool       // true if this is a symbolic link
+	linkTarget string     // target path for symlinks
+	encryption Encryption // compressed data is encrypted
+	sha256     []byte     // SHA-256 of the content, if stored
+	comment    string     // file comment (central directory)
+	unix       UnixAttrs  // owner, atime/ctime and extended attributes
+	hardLink   string     // name of the entry this is a hard link to
+	device     *[2]uint32 // major and minor number of a device node
+
+	// Raw entries were copied from another archive: compressed data,
+	// method and flags fields, DOS time and extra fields are written back
+	// byte-for-byte.
+	raw          bool
+	headerMethod Method
+	flags        uint16
+	dosTime      uint16
+	dosDate      uint16
+	extraLocal   []byte
+	extraCentral []byte
 }
 
 // NewArchive creates a new archive builder.
 func NewArchive(c *Compressor) *Archive {
-	return &Archive{compressor: c}
+	return &Archive{compressor: c, index: make(map[string]int)}
+}
+
+// put adds an entry, replacing any existing entry with the same name at
+// its position rather than appending a duplicate.
+func (a *Archive) put(entry archiveEntry) {
+	if i, ok := a.index[entry.name]; ok {
+		a.entries[i] = entry
+		return
+	}
+	a.index[entry.name] = len(a.entries)
+	a.entries = append(a.entries, entry)
 }
 
-// Add adds a file to the archive with automatic method selection.
+// Add adds a file to the archive with automatic method selection. Adding a
+// name already in the archive replaces that entry in place, so the archive
+// never holds two entries with the same name; the other Add methods do the
+// same.
 func (a *Archive) Add(data []byte, name string, modTime time.Time, mode os.FileMode) error {
+	return a.AddWith(data, name, modTime, mode, AddOptions{})
+}
+
+// AddWith adds a file to the archive with the method and vocabulary opts
+// forces, selecting the rest automatically.
+func (a *Archive) AddWith(data []byte, name string, modTime time.Time, mode os.FileMode, opts AddOptions) error {
 	if len(data) > 0xFFFFFFFF {
 		return ErrFileTooLarge
 	}
@@ -290,43 +440,32 @@ func (a *Archive) Add(data []byte, name string, modTime time.Time, mode os.FileM
 	entry := archiveEntry{
 		name:    name,
 		data:    data,
+		size:    int64(len(data)),
 		crc:     crc32.ChecksumIEEE(data),
 		modTime: modTime,
 		mode:    mode,
 	}
 
-	if len(data) == 0 {
-		entry.method = MethodStore
-		entry.compressed = data
-	} else {
-		profile := detect.Detect(data)
-
-		switch profile.Type {
-		case detect.TypeText:
-			compressed, method, vocab := a.compressor.compressTextBest(data)
-			entry.compressed = compressed
-			entry.method = method
-			entry.vocabInfo = vocab
-		case detect.TypeCode:
-			compressed, method, vocab := a.compressor.compressCodeBest(data, profile.Language)
-			entry.compressed = compressed
-			entry.method = method
-			entry.vocabInfo = vocab
-		case detect.TypeRandom:
-			entry.method = MethodStore
-			entry.compressed = data
-		default:
-			compressed, _ := a.compressor.compressDEFLATE(data)
-			entry.method = MethodDEFLATE
-			entry.compressed = compressed
-		}
+	profile := a.compressor.nameMap.DetectWith(name, data, a.compressor.sampling)
+	ch, err := a.compressor.selectMethod(data, profile, opts)
+	if err != nil {
+		return err
 	}
+	if a.explain {
+		a.explanations = append(a.explanations, a.compressor.explain(data, name, profile, ch))
+	}
+	entry.compressed, entry.method, entry.vocabInfo = ch.compressed, ch.method, ch.vocab
 
 	if len(entry.compressed) > 0xFFFFFFFF {
 		return ErrFileTooLarge
 	}
 
-	a.entries = append(a.entries, entry)
+	a.digest(&entry)
+	if err := a.seal(&entry); err != nil {
+		return err
+	}
+
+	a.put(entry)
 	return nil
 }
 
@@ -340,13 +479,19 @@ func (a *Archive) AddStore(data []byte, name string, modTime time.Time, mode os.
 		name:       name,
 		data:       data,
 		compressed: data,
+		size:       int64(len(data)),
 		method:     MethodStore,
 		crc:        crc32.ChecksumIEEE(data),
 		modTime:    modTime,
 		mode:       mode,
 	}
 
-	a.entries = append(a.entries, entry)
+	a.digest(&entry)
+	if err := a.seal(&entry); err != nil {
+		return err
+	}
+
+	a.put(entry)
 	return nil
 }
 
@@ -367,7 +512,7 @@ func (a *Archive) AddDirectory(name string, modTime time.Time, mode os.FileMode)
 		mode:       mode | os.ModeDir,
 	}
 
-	a.entries = append(a.entries, entry)
+	a.put(entry)
 	return nil
 }
 
@@ -380,6 +525,7 @@ func (a *Archive) AddSymlink(name string, target string, modTime time.Time, mode
 		name:       name,
 		data:       targetBytes,
 		compressed: targetBytes,
+		size:       int64(len(targetBytes)),
 		method:     MethodStore,
 		crc:        crc32.ChecksumIEEE(targetBytes),
 		modTime:    modTime,
@@ -388,7 +534,12 @@ func (a *Archive) AddSymlink(name string, target string, modTime time.Time, mode
 		linkTarget: target,
 	}
 
-	a.entries = append(a.entries, entry)
+	a.digest(&entry)
+	if err := a.seal(&entry); err != nil {
+		return err
+	}
+
+	a.put(entry)
 	return nil
 }
 
@@ -396,40 +547,88 @@ func (a *Archive) AddSymlink(name string, target string, modTime time.Time, mode
 func (a *Archive) Bytes() ([]byte, error) {
 	var buf bytes.Buffer
 	var centralDir bytes.Buffer
+	spans := make([][2]int, 0, len(a.entries)) // local header + data of each entry
 
-	for _, entry := range a.entries {
+	for _, entry := range a.reproducibleEntries() {
 		dosTime, dosDate := timeToDOS(entry.modTime)
 		flags := uint16(0)
-		if hasNonASCII(entry.name) {
+		if hasNonASCII(entry.name) || hasNonASCII(entry.comment) {
 			flags |= flagUTF8
 		}
 
 		// Build extra fields
-		extraLocal := makeExtendedTimestamp(entry.modTime, true)
-		extraCentral := makeExtendedTimestamp(entry.modTime, false)
+		extraLocal := makeExtendedTimestamp(entry.modTime, true, entry.unix.Atime, entry.unix.Ctime)
+		extraCentral := makeExtendedTimestamp(entry.modTime, false, entry.unix.Atime, entry.unix.Ctime)
+		if entry.unix.HasOwner {
+			ownerExtra := makeUnixOwner(entry.unix.UID, entry.unix.GID)
+			extraLocal = append(extraLocal, ownerExtra...)
+			extraCentral = append(extraCentral, ownerExtra...)
+		}
+		if entry.hardLink != "" {
+			linkExtra := makeHardLink(entry.hardLink)
+			extraLocal = append(extraLocal, linkExtra...)
+			extraCentral = append(extraCentral, linkExtra...)
+		}
+		if entry.device != nil {
+			devExtra := makeDevice(entry.device[0], entry.device[1])
+			extraLocal = append(extraLocal, devExtra...)
+			extraCentral = append(extraCentral, devExtra...)
+		}
+		if len(entry.unix.Xattrs) > 0 {
+			// Central directory only: extraction reads it from there
+			extraCentral = append(extraCentral, makeXattrs(entry.unix.Xattrs)...)
+		}
 
-		// Add vocabulary info for BPELATE
-		if entry.method == MethodBPELATE {
+		// Add vocabulary info for the BPE methods
+		if usesVocab(entry.method) {
 			vocabExtra := makeVocabInfo(entry.vocabInfo)
 			extraLocal = append(extraLocal, vocabExtra...)
 			extraCentral = append(extraCentral, vocabExtra...)
 		}
 
+		if entry.sha256 != nil {
+			hashExtra := makeContentHash(entry.sha256)
+			extraLocal = append(extraLocal, hashExtra...)
+			extraCentral = append(extraCentral, hashExtra...)
+		}
+
+		// Encrypted entries are stored as method 99 with the real method
+		// in the WinZip AES extra field
+		method := entry.method
+		if entry.encryption == EncryptionAES256 {
+			aesExtra := makeAESExtra(entry.method, aesStrength256)
+			extraLocal = append(extraLocal, aesExtra...)
+			extraCentral = append(extraCentral, aesExtra...)
+			method = methodWinZipAE
+			flags |= flagEncrypted
+		}
+
+		// Copied entries keep their original headers
+		if entry.raw {
+			method, flags = entry.headerMethod, entry.flags
+			dosTime, dosDate = entry.dosTime, entry.dosDate
+			extraLocal, extraCentral = entry.extraLocal, entry.extraCentral
+			if hasNonASCII(entry.comment) {
+				flags |= flagUTF8
+			}
+		}
+
 		// Unix external attributes (convert Go mode to Unix st_mode)
 		externalAttrs := goModeToUnix(entry.mode) << 16
 
 		// Local file header
 		localHeaderOffset := buf.Len()
-		writeLocalHeader(&buf, entry.name, entry.method, flags, dosTime, dosDate, entry.crc,
-			uint32(len(entry.compressed)), uint32(len(entry.data)), extraLocal)
+		writeLocalHeader(&buf, entry.name, method, flags, dosTime, dosDate, entry.crc,
+			uint32(len(entry.compressed)), uint32(entry.size), extraLocal)
 
 		// File data
 		buf.Write(entry.compressed)
+		spans = append(spans, [2]int{localHeaderOffset, buf.Len()})
 
 		// Central directory entry
-		writeCentralDir(&centralDir, entry.name, entry.method, flags, dosTime, dosDate, entry.crc,
-			uint32(len(entry.compressed)), uint32(len(entry.data)), uint32(localHeaderOffset),
-			externalAttrs, extraCentral)
+		writeCentralDir(&centralDir, entry.name, method, flags, dosTime, dosDate, entry.crc,
+			uint32(len(entry.compressed)), uint32(entry.size), uint32(localHeaderOffset),
+			externalAttrs, extraCentral, entry.comment)
 	}
 
 	// Append central directory
@@ -437,141 +636,217 @@ func (a *Archive) Bytes() ([]byte, error) {
 	buf.Write(centralDir.Bytes())
 	centralDirSize := buf.Len() - centralDirOffset
 
+	// Signature goes in the archive comment, after the user's text
+	comment := a.comment
+	if a.signKey != nil {
+		if comment != "" {
+			comment += "\n"
+		}
+		comment += signatureComment(a.signKey, signatureDigest(buf.Bytes(), spans, centralDir.Bytes(), comment))
+	}
+	if len(comment) > maxCommentLen {
+		return nil, ErrCommentTooLong
+	}
+
 	// End of central directory
-	writeEndCentralDir(&buf, len(a.entries), uint32(centralDirSize), uint32(centralDirOffset))
+	writeEndCentralDir(&buf, len(a.entries), uint32(centralDirSize), uint32(centralDirOffset), comment)
 
 	return buf.Bytes(), nil
 }
 
-// compressTextBest compresses text and returns best result with method and vocab info.
-func (c *Compressor) compressTextBest(data []byte) ([]byte, Method, VocabInfo) {
-	deflateData, _ := c.compressDEFLATE(data)
-	bpelateData, _ := c.compressBPELATE(data)
-
-	vocab := VocabInfo{NatLang: NatLangEnglish}
-
-	if len(bpelateData) < len(deflateData) {
-		return bpelateData, MethodBPELATE, vocab
+// selectMethod compresses data with the method opts forces, or with each
+// method suited to its profile, keeping the smallest result; levels without
+// trials guess the method instead. Only a forced method can fail.
+func (c *Compressor) selectMethod(data []byte, profile detect.Profile, opts AddOptions) (*choice, error) {
+	vocab := makeVocabInfoFromDetect(profile.Language, profile.NatLang)
+	if opts.ForceVocab {
+		vocab = opts.Vocab
 	}
-	return deflateData, MethodDEFLATE, vocab
-}
-
-// compressCodeBest compresses code and returns best result with method and vocab info.
-func (c *Compressor) compressCodeBest(data []byte, lang detect.CodeLang) ([]byte, Method, VocabInfo) {
-	encoder := c.getEncoderForLang(lang)
-	vocabInfo := makeVocabInfoFromDetect(lang)
-
-	deflateData, _ := c.compressDEFLATE(data)
-	bpelateData, _ := c.compressBPELATEWith(data, encoder)
-
-	if len(bpelateData) < len(deflateData) {
-		return bpelateData, MethodBPELATE, vocabInfo
-	}
-	return deflateData, MethodDEFLATE, vocabInfo
-}
-
-// CompressFile creates a ZIP archive containing one file.
-func (c *Compressor) CompressFile(data []byte, name string, modTime time.Time) ([]byte, error) {
-	return c.CompressFileWithMode(data, name, modTime, 0644)
-}
 
-// CompressFileWithMode creates a ZIP archive with specified Unix permissions.
-func (c *Compressor) CompressFileWithMode(data []byte, name string, modTime time.Time, mode os.FileMode) ([]byte, error) {
-	if len(data) == 0 {
-		return c.createZIP(data, name, modTime, mode, MethodStore)
+	switch {
+	case opts.ForceMethod:
+		ch := &choice{vocab: vocab}
+		compressed, err := c.compressAs(data, opts.Method, vocab)
+		if err != nil {
+			return nil, err
+		}
+		ch.try(opts.Method, vocab.ProgLang, compressed, nil)
+		return ch, nil
+	case len(data) == 0,
+		profile.Type == detect.TypeRandom, profile.Type == detect.TypeCompressed, profile.Type == detect.TypeMedia:
+		// Already compressed; another pass would only add overhead
+		ch := &choice{}
+		ch.try(MethodStore, ProgLangNone, data, nil)
+		return ch, nil
+	case !c.strategy().trials:
+		return c.guessMethod(data, profile, vocab, opts.ForceVocab), nil
+	case opts.ForceVocab && (profile.Type == detect.TypeText || profile.Type == detect.TypeCode):
+		// Just the given vocabulary against DEFLATE
+		ch := &choice{vocab: vocab}
+		compressed, err := c.compressDEFLATE(data)
+		ch.try(MethodDEFLATE, ProgLangNone, compressed, err)
+		compressed, err = c.compressBPELATEVocab(data, vocab.ProgLang)
+		ch.try(MethodBPELATE, vocab.ProgLang, compressed, err)
+		return ch, nil
 	}
 
-	profile := detect.Detect(data)
-
 	switch profile.Type {
 	case detect.TypeText:
-		// Use BPELATE with text vocabulary - compare against DEFLATE
-		return c.compressText(data, name, modTime, mode)
+		return c.compressTextBest(data, profile), nil
 	case detect.TypeCode:
-		// Try language-specific UNZLATE and compare with DEFLATE
-		return c.compressCode(data, name, modTime, mode, profile.Language)
-	case detect.TypeRandom:
-		return c.createZIP(data, name, modTime, mode, MethodStore)
+		return c.compressCodeBest(data, profile), nil
+	case detect.TypeExecutable:
+		return c.compressExecutableBest(data), nil
 	default:
-		return c.createZIP(data, name, modTime, mode, MethodDEFLATE)
+		ch := &choice{}
+		compressed, err := c.compressDEFLATE(data)
+		ch.try(MethodDEFLATE, ProgLangNone, compressed, err)
+		return ch, nil
 	}
 }
 
-// compressCode compresses source code using the best method.
-// It tries DEFLATE, UNZLATE (BPE+ANS), and BPELATE (BPE+DEFLATE),
-// then picks whichever produces the smallest output.
-func (c *Compressor) compressCode(data []byte, name string, modTime time.Time, mode os.FileMode, lang detect.CodeLang) ([]byte, error) {
-	// Get language-specific encoder
-	encoder := c.getEncoderForLang(lang)
+// compressTextBest compresses text with DEFLATE and BPELATE, and MIXLATE if
+// it has embedded code. At levels trying every vocabulary, BPELATE is tried
+// with each; at levels that predict, only one of DEFLATE and BPELATE may be.
+func (c *Compressor) compressTextBest(data []byte, profile detect.Profile) *choice {
+	pred := c.predictMethod(data, ProgLangNone)
+	ch := &choice{vocab: VocabInfo{NatLang: natLangFromDetect(profile.NatLang)}, prediction: pred}
+	if !pred.skips(MethodDEFLATE) {
+		c.tryDEFLATE(ch, data)
+	}
+	langs := []ProgLang{ProgLangNone}
+	if c.strategy().allVocabs {
+		langs = vocabLangs
+	}
+	if !pred.skips(MethodBPELATE) {
+		c.tryBPELATE(ch, data, langs)
+	}
+	c.tryMIXLATE(ch, data, profile)
+	if !ch.found {
+		// The predicted method failed
+		c.tryDEFLATE(ch, data)
+	}
+	return ch
+}
 
-	// Try all three methods
-	deflateData, deflateErr := c.compressDEFLATE(data)
-	unzlateData, unzlateErr := c.compressUNZLATEWith(data, encoder)
-	bpelateData, bpelateErr := c.compressBPELATEWith(data, encoder)
+// compressCodeBest compresses code with DEFLATE, BPELATE with the
+// vocabulary of the detected language (and of each of vocabCandidates, or
+// every vocabulary, if the level says so), and MIXLATE if the file mixes
+// languages. At levels that predict, only one of DEFLATE and BPELATE may be
+// tried.
+func (c *Compressor) compressCodeBest(data []byte, profile detect.Profile) *choice {
+	vocab := makeVocabInfoFromDetect(profile.Language, profile.NatLang)
+	pred := c.predictMethod(data, vocab.ProgLang)
+	ch := &choice{vocab: vocab, prediction: pred}
+	if !pred.skips(MethodDEFLATE) {
+		c.tryDEFLATE(ch, data)
+	}
 
-	// Find the smallest successful result
-	type candidate struct {
-		data   []byte
-		method Method
-		err    error
+	candidates := []detect.CodeLang{profile.Language}
+	if c.strategy().runnerUp {
+		candidates = c.vocabCandidates(profile)
+	}
+	var langs []ProgLang
+	for _, candidate := range candidates {
+		langs = append(langs, makeVocabInfoFromDetect(candidate, profile.NatLang).ProgLang)
 	}
+	if c.strategy().allVocabs {
+		langs = append(langs, vocabLangs...)
+	}
+	if !pred.skips(MethodBPELATE) {
+		c.tryBPELATE(ch, data, langs)
+	}
+	c.tryMIXLATE(ch, data, profile)
+	if !ch.found {
+		// The predicted method failed
+		c.tryDEFLATE(ch, data)
+	}
+	return ch
+}
 
-	candidates := []candidate{
-		{deflateData, MethodDEFLATE, deflateErr},
-		{unzlateData, MethodUNZLATE, unzlateErr},
-		{bpelateData, MethodBPELATE, bpelateErr},
+// tryDEFLATE tries DEFLATE if it was not tried yet.
+func (c *Compressor) tryDEFLATE(ch *choice, data []byte) {
+	if !ch.tried(MethodDEFLATE, ProgLangNone) {
+		compressed, err := c.compressDEFLATE(data)
+		ch.try(MethodDEFLATE, ProgLangNone, compressed, err)
 	}
+}
 
-	var best *candidate
-	for i := range candidates {
-		c := &candidates[i]
-		if c.err != nil {
-			continue
+// tryBPELATE tries BPELATE with the vocabulary of each of langs not tried
+// yet, and at levels trying token layouts, UNZLATE with it too.
+func (c *Compressor) tryBPELATE(ch *choice, data []byte, langs []ProgLang) {
+	for _, lang := range langs {
+		if !ch.tried(MethodBPELATE, lang) {
+			compressed, err := c.compressBPELATEVocab(data, lang)
+			ch.try(MethodBPELATE, lang, compressed, err)
 		}
-		if best == nil || len(c.data) < len(best.data) {
-			best = c
+		if c.strategy().layouts && !ch.tried(MethodUNZLATE, lang) {
+			compressed, err := c.compressUNZLATEWith(data, c.getEncoderForProgLang(lang))
+			ch.try(MethodUNZLATE, lang, compressed, err)
 		}
 	}
+}
 
-	if best == nil {
-		// All failed - return first error
-		if deflateErr != nil {
-			return nil, deflateErr
-		}
-		return nil, unzlateErr
+// tryMIXLATE tries MIXLATE if the file mixes languages and the level says
+// so.
+func (c *Compressor) tryMIXLATE(ch *choice, data []byte, profile detect.Profile) {
+	if !c.strategy().mixlate {
+		return
 	}
-
-	// For BPELATE, include language info in metadata
-	if best.method == MethodBPELATE {
-		vocabInfo := makeVocabInfoFromDetect(lang)
-		return c.createZIPWithCompressedAndLang(data, best.data, name, modTime, mode, best.method, vocabInfo)
+	if mixed, ok := c.compressMIXLATE(data, profile.Segments); ok {
+		ch.try(MethodMIXLATE, ProgLangNone, mixed, nil)
 	}
+}
 
-	return c.createZIPWithCompressed(data, best.data, name, modTime, mode, best.method)
+// lowCodeConfidence is the programming language confidence below which
+// the detector's runner-up is not ruled out, and its vocabulary is tried
+// as well.
+const lowCodeConfidence = 0.9
+
+// vocabCandidates returns the languages whose vocabularies are worth trying
+// on code: the detected language, and when the detector is unsure, the top
+// two it scored. Languages sharing a vocabulary are only tried once.
+func (c *Compressor) vocabCandidates(profile detect.Profile) []detect.CodeLang {
+	langs := []detect.CodeLang{profile.Language}
+	if profile.LanguageConfidence >= lowCodeConfidence {
+		return langs
+	}
+	encoders := map[*bpe.Encoder]bool{c.getEncoderForLang(profile.Language): true}
+	for i, score := range profile.LanguageScores {
+		if i == 2 {
+			break
+		}
+		if encoder := c.getEncoderForLang(score.Lang); !encoders[encoder] {
+			encoders[encoder] = true
+			langs = append(langs, score.Lang)
+		}
+	}
+	return langs
 }
 
-// compressText compresses natural language text using the best method.
-// It compares DEFLATE and BPELATE (with text vocabulary) and picks the smaller result.
-func (c *Compressor) compressText(data []byte, name string, modTime time.Time, mode os.FileMode) ([]byte, error) {
-	// Try DEFLATE and BPELATE with text vocabulary
-	deflateData, deflateErr := c.compressDEFLATE(data)
-	bpelateData, bpelateErr := c.compressBPELATEWith(data, c.encoder) // default encoder uses text vocab
+// CompressFile creates a ZIP archive containing one file.
+func (c *Compressor) CompressFile(data []byte, name string, modTime time.Time) ([]byte, error) {
+	return c.CompressFileWithMode(data, name, modTime, 0644)
+}
 
-	// Pick the smaller result
-	if deflateErr != nil && bpelateErr != nil {
-		return nil, deflateErr
+// CompressFileWithMode creates a ZIP archive with specified Unix permissions.
+// The method is selected as Archive.Add selects it, at the compressor's
+// level.
+func (c *Compressor) CompressFileWithMode(data []byte, name string, modTime time.Time, mode os.FileMode) ([]byte, error) {
+	if len(data) > 0xFFFFFFFF {
+		return nil, ErrFileTooLarge
 	}
-
-	if bpelateErr != nil || (deflateErr == nil && len(deflateData) <= len(bpelateData)) {
-		return c.createZIPWithCompressed(data, deflateData, name, modTime, mode, MethodDEFLATE)
+	profile := c.nameMap.DetectWith(name, data, c.sampling)
+	ch, err := c.selectMethod(data, profile, AddOptions{})
+	if err != nil {
+		return nil, err
 	}
-
-	return c.createZIPWithCompressed(data, bpelateData, name, modTime, mode, MethodBPELATE)
+	return c.createZIPWithCompressedAndLang(data, ch.compressed, name, modTime, mode, ch.method, ch.vocab)
 }
 
-// makeVocabInfoFromDetect creates VocabInfo from detected language.
-func makeVocabInfoFromDetect(lang detect.CodeLang) VocabInfo {
-	info := VocabInfo{NatLang: NatLangEnglish} // assume English comments
+// makeVocabInfoFromDetect creates VocabInfo from detected languages.
+func makeVocabInfoFromDetect(lang detect.CodeLang, natLang detect.NatLang) VocabInfo {
+	info := VocabInfo{NatLang: natLangFromDetect(natLang)}
 	switch lang {
 	case detect.CodeLangGo:
 		info.ProgLang = ProgLangGo
@@ -583,6 +858,43 @@ func makeVocabInfoFromDetect(lang detect.CodeLang) VocabInfo {
 	return info
 }
 
+// natLangFromDetect maps a detected natural language to its VocabInfo
+// code. Text the detector is not confident about is assumed to be English,
+// which the default vocabulary is trained on.
+func natLangFromDetect(lang detect.NatLang) NatLang {
+	switch lang {
+	case detect.NatLangEnglish:
+		return NatLangEnglish
+	case detect.NatLangSpanish:
+		return NatLangSpanish
+	case detect.NatLangFrench:
+		return NatLangFrench
+	case detect.NatLangPortuguese:
+		return NatLangPortuguese
+	case detect.NatLangGerman:
+		return NatLangGerman
+	case detect.NatLangItalian:
+		return NatLangItalian
+	case detect.NatLangDutch:
+		return NatLangDutch
+	case detect.NatLangChinese:
+		return NatLangChinese
+	case detect.NatLangArabic:
+		return NatLangArabic
+	case detect.NatLangHindi:
+		return NatLangHindi
+	case detect.NatLangIndonesian:
+		return NatLangIndonesian
+	case detect.NatLangBengali:
+		return NatLangBengali
+	case detect.NatLangRussian:
+		return NatLangRussian
+	case detect.NatLangJapanese:
+		return NatLangJapanese
+	}
+	return NatLangEnglish
+}
+
 // getEncoderForLang returns the appropriate encoder for the language.
 func (c *Compressor) getEncoderForLang(lang detect.CodeLang) *bpe.Encoder {
 	switch lang {
@@ -608,89 +920,22 @@ func (c *Compressor) getEncoderForLang(lang detect.CodeLang) *bpe.Encoder {
 
 // CompressFileAs creates a ZIP archive using a specific method.
 func (c *Compressor) CompressFileAs(data []byte, name string, modTime time.Time, method Method) ([]byte, error) {
-	return c.createZIP(data, name, modTime, 0644, method)
+	return c.CompressFileAsWithMode(data, name, modTime, 0644, method)
 }
 
-// CompressFileAsWithMode creates a ZIP archive using a specific method and permissions.
+// CompressFileAsWithMode creates a ZIP archive using a specific method and
+// permissions. The BPE methods use the vocabulary of the detected language.
 func (c *Compressor) CompressFileAsWithMode(data []byte, name string, modTime time.Time, mode os.FileMode, method Method) ([]byte, error) {
-	return c.createZIP(data, name, modTime, mode, method)
-}
-
-// createZIP builds a complete ZIP archive.
-func (c *Compressor) createZIP(data []byte, name string, modTime time.Time, mode os.FileMode, method Method) ([]byte, error) {
-	// Check size limits (no ZIP64 support)
 	if len(data) > 0xFFFFFFFF {
 		return nil, ErrFileTooLarge
 	}
-
-	// Compress data
-	var compressed []byte
-	var err error
-
-	switch method {
-	case MethodUNZLATE:
-		compressed, err = c.compressUNZLATE(data)
-	case MethodBPELATE:
-		compressed, err = c.compressBPELATE(data)
-	case MethodDEFLATE:
-		compressed, err = c.compressDEFLATE(data)
-	case MethodStore:
-		compressed = data
-	default:
-		return nil, ErrUnsupported
-	}
-
+	profile := c.nameMap.DetectWith(name, data, c.sampling)
+	vocab := makeVocabInfoFromDetect(profile.Language, profile.NatLang)
+	compressed, err := c.compressAs(data, method, vocab)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(compressed) > 0xFFFFFFFF {
-		return nil, ErrFileTooLarge
-	}
-
-	crc := crc32.ChecksumIEEE(data)
-	dosTime, dosDate := timeToDOS(modTime)
-	flags := uint16(0)
-
-	// Set UTF-8 flag if filename contains non-ASCII
-	if hasNonASCII(name) {
-		flags |= flagUTF8
-	}
-
-	// Build extended timestamp extra field
-	extraLocal := makeExtendedTimestamp(modTime, true)
-	extraCentral := makeExtendedTimestamp(modTime, false)
-
-	// Unix external attributes: convert Go mode to Unix st_mode
-	externalAttrs := goModeToUnix(mode) << 16
-
-	// Build archive
-	var buf bytes.Buffer
-
-	// Local file header
-	localHeaderOffset := buf.Len()
-	writeLocalHeader(&buf, name, method, flags, dosTime, dosDate, crc,
-		uint32(len(compressed)), uint32(len(data)), extraLocal)
-
-	// File data
-	buf.Write(compressed)
-
-	// Central directory
-	centralDirOffset := buf.Len()
-	writeCentralDir(&buf, name, method, flags, dosTime, dosDate, crc,
-		uint32(len(compressed)), uint32(len(data)), uint32(localHeaderOffset),
-		externalAttrs, extraCentral)
-	centralDirSize := buf.Len() - centralDirOffset
-
-	// End of central directory
-	writeEndCentralDir(&buf, 1, uint32(centralDirSize), uint32(centralDirOffset))
-
-	return buf.Bytes(), nil
-}
-
-// createZIPWithCompressed builds a ZIP archive with pre-compressed data.
-func (c *Compressor) createZIPWithCompressed(originalData, compressed []byte, name string, modTime time.Time, mode os.FileMode, method Method) ([]byte, error) {
-	return c.createZIPWithCompressedAndLang(originalData, compressed, name, modTime, mode, method, VocabInfo{})
+	return c.createZIPWithCompressedAndLang(data, compressed, name, modTime, mode, method, vocab)
 }
 
 // createZIPWithCompressedAndLang builds a ZIP archive with pre-compressed data and language info.
@@ -710,11 +955,11 @@ func (c *Compressor) createZIPWithCompressedAndLang(originalData, compressed []b
 	}
 
 	// Build extra fields
-	extraLocal := makeExtendedTimestamp(modTime, true)
-	extraCentral := makeExtendedTimestamp(modTime, false)
+	extraLocal := makeExtendedTimestamp(modTime, true, time.Time{}, time.Time{})
+	extraCentral := makeExtendedTimestamp(modTime, false, time.Time{}, time.Time{})
 
-	// Add vocabulary info for BPELATE
-	if method == MethodBPELATE {
+	// Add vocabulary info for the BPE methods
+	if usesVocab(method) {
 		vocabExtra := makeVocabInfo(vocabInfo)
 		extraLocal = append(extraLocal, vocabExtra...)
 		extraCentral = append(extraCentral, vocabExtra...)
@@ -738,11 +983,11 @@ func (c *Compressor) createZIPWithCompressedAndLang(originalData, compressed []b
 	centralDirOffset := buf.Len()
 	writeCentralDir(&buf, name, method, flags, dosTime, dosDate, crc,
 		uint32(len(compressed)), uint32(len(originalData)), uint32(localHeaderOffset),
-		externalAttrs, extraCentral)
+		externalAttrs, extraCentral, "")
 	centralDirSize := buf.Len() - centralDirOffset
 
 	// End of central directory
-	writeEndCentralDir(&buf, 1, uint32(centralDirSize), uint32(centralDirOffset))
+	writeEndCentralDir(&buf, 1, uint32(centralDirSize), uint32(centralDirOffset), "")
 
 	return buf.Bytes(), nil
 }
@@ -761,24 +1006,41 @@ func makeVocabInfo(info VocabInfo) []byte {
 }
 
 // makeExtendedTimestamp creates the 0x5455 extra field.
-// Local header includes mtime; central directory is shorter (just flags + mtime).
-func makeExtendedTimestamp(t time.Time, local bool) []byte {
+// The local header carries mtime plus atime and ctime when they are set; the
+// central directory has only mtime, with the flags still describing the
+// local header, as Info-ZIP does.
+func makeExtendedTimestamp(t time.Time, local bool, atime, ctime time.Time) []byte {
 	if t.IsZero() {
 		return nil
 	}
 
-	// Extra field: 2 bytes ID + 2 bytes size + 1 byte flags + 4 bytes mtime
+	flags := byte(0x01) // bit 0 = mtime present
+	if !atime.IsZero() {
+		flags |= 0x02
+	}
+	if !ctime.IsZero() {
+		flags |= 0x04
+	}
+
+	// Extra field: 2 bytes ID + 2 bytes size + 1 byte flags + 4 bytes per time
 	var extra bytes.Buffer
 	binary.Write(&extra, binary.LittleEndian, uint16(extraExtendedTS))
 
+	times := []time.Time{t}
 	if local {
-		binary.Write(&extra, binary.LittleEndian, uint16(5)) // size: flags(1) + mtime(4)
-	} else {
-		binary.Write(&extra, binary.LittleEndian, uint16(5)) // same for central
+		if !atime.IsZero() {
+			times = append(times, atime)
+		}
+		if !ctime.IsZero() {
+			times = append(times, ctime)
+		}
 	}
+	binary.Write(&extra, binary.LittleEndian, uint16(1+4*len(times)))
 
-	extra.WriteByte(0x01) // flags: bit 0 = mtime present
-	binary.Write(&extra, binary.LittleEndian, uint32(t.Unix()))
+	extra.WriteByte(flags)
+	for _, tm := range times {
+		binary.Write(&extra, binary.LittleEndian, uint32(tm.Unix()))
+	}
 
 	return extra.Bytes()
 }
@@ -814,7 +1076,7 @@ func writeLocalHeader(w *bytes.Buffer, name string, method Method, flags, dosTim
 }
 
 // writeCentralDir writes a ZIP central directory entry.
-func writeCentralDir(w *bytes.Buffer, name string, method Method, flags, dosTime, dosDate uint16, crc, compSize, uncompSize, localOffset, externalAttrs uint32, extra []byte) {
+func writeCentralDir(w *bytes.Buffer, name string, method Method, flags, dosTime, dosDate uint16, crc, compSize, uncompSize, localOffset, externalAttrs uint32, extra []byte, comment string) {
 	var hdr [46]byte
 	binary.LittleEndian.PutUint32(hdr[0:4], sigCentralDir)
 	binary.LittleEndian.PutUint16(hdr[4:6], zipVersionUnix) // version made by (Unix)
@@ -828,7 +1090,7 @@ func writeCentralDir(w *bytes.Buffer, name string, method Method, flags, dosTime
 	binary.LittleEndian.PutUint32(hdr[24:28], uncompSize)
 	binary.LittleEndian.PutUint16(hdr[28:30], uint16(len(name)))
 	binary.LittleEndian.PutUint16(hdr[30:32], uint16(len(extra)))
-	binary.LittleEndian.PutUint16(hdr[32:34], 0) // comment length
+	binary.LittleEndian.PutUint16(hdr[32:34], uint16(len(comment)))
 	binary.LittleEndian.PutUint16(hdr[34:36], 0) // disk number
 	binary.LittleEndian.PutUint16(hdr[36:38], 0) // internal attrs
 	binary.LittleEndian.PutUint32(hdr[38:42], externalAttrs)
@@ -837,10 +1099,11 @@ func writeCentralDir(w *bytes.Buffer, name string, method Method, flags, dosTime
 	w.Write(hdr[:])
 	w.WriteString(name)
 	w.Write(extra)
+	w.WriteString(comment)
 }
 
 // writeEndCentralDir writes the ZIP end of central directory record.
-func writeEndCentralDir(w *bytes.Buffer, numEntries int, centralDirSize, centralDirOffset uint32) {
+func writeEndCentralDir(w *bytes.Buffer, numEntries int, centralDirSize, centralDirOffset uint32, comment string) {
 	var hdr [22]byte
 	binary.LittleEndian.PutUint32(hdr[0:4], sigEndCentralD)
 	binary.LittleEndian.PutUint16(hdr[4:6], 0)                    // disk number
@@ -849,9 +1112,10 @@ func writeEndCentralDir(w *bytes.Buffer, numEntries int, centralDirSize, central
 	binary.LittleEndian.PutUint16(hdr[10:12], uint16(numEntries)) // total entries
 	binary.LittleEndian.PutUint32(hdr[12:16], centralDirSize)
 	binary.LittleEndian.PutUint32(hdr[16:20], centralDirOffset)
-	binary.LittleEndian.PutUint16(hdr[20:22], 0) // comment length
+	binary.LittleEndian.PutUint16(hdr[20:22], uint16(len(comment)))
 
 	w.Write(hdr[:])
+	w.WriteString(comment)
 }
 
 // Decompress extracts the first file from a ZIP archive.
@@ -872,34 +1136,41 @@ func (c *Compressor) Decompress(data []byte) ([]byte, error) {
 
 	compressed := data[dataOffset : dataOffset+int(info.CompSize)]
 
-	switch info.Method {
-	case MethodUNZLATE:
-		return c.decompressUNZLATE(compressed)
-	case MethodBPELATE:
-		return c.decompressBPELATEWithVocab(compressed, info.Vocab)
-	case MethodDEFLATE:
-		return c.decompressDEFLATE(compressed)
-	case MethodStore:
-		return compressed, nil
-	default:
-		return nil, ErrUnsupported
+	return c.decompressEntry(compressed, info)
+}
+
+// findEndCentralDir returns the offset of the end of central directory
+// record, searching backwards past a comment of up to 64 KiB, or -1.
+func findEndCentralDir(data []byte) int {
+	for i := len(data) - 22; i >= 0 && i > len(data)-65557; i-- {
+		if binary.LittleEndian.Uint32(data[i:i+4]) == sigEndCentralD {
+			return i
+		}
 	}
+	return -1
 }
 
 // ListFiles returns metadata for all files in a ZIP archive.
 func ListFiles(data []byte) ([]*FileInfo, error) {
+	return ListFilesWithLimits(data, Limits{})
+}
+
+// ListFilesWithLimits is like ListFiles but rejects archives whose central
+// directory declares more entries than limits.MaxEntries.
+func ListFilesWithLimits(data []byte, limits Limits) ([]*FileInfo, error) {
+	return listFiles(data, limits, false)
+}
+
+// listFiles parses the central directory. In strict mode a truncated or
+// malformed record is an error; otherwise parsing stops and the entries
+// read so far are returned.
+func listFiles(data []byte, limits Limits, strict bool) ([]*FileInfo, error) {
 	if len(data) < 22 {
 		return nil, ErrTooShort
 	}
 
 	// Find end of central directory
-	eocdOffset := -1
-	for i := len(data) - 22; i >= 0 && i > len(data)-65557; i-- {
-		if binary.LittleEndian.Uint32(data[i:i+4]) == sigEndCentralD {
-			eocdOffset = i
-			break
-		}
-	}
+	eocdOffset := findEndCentralDir(data)
 	if eocdOffset < 0 {
 		return nil, ErrInvalidFormat
 	}
@@ -908,6 +1179,10 @@ func ListFiles(data []byte) ([]*FileInfo, error) {
 	numEntries := int(binary.LittleEndian.Uint16(data[eocdOffset+10 : eocdOffset+12]))
 	centralDirOffset := int(binary.LittleEndian.Uint32(data[eocdOffset+16 : eocdOffset+20]))
 
+	if err := limits.checkEntries(numEntries); err != nil {
+		return nil, err
+	}
+
 	if centralDirOffset >= len(data) {
 		return nil, ErrCorrupted
 	}
@@ -918,14 +1193,21 @@ func ListFiles(data []byte) ([]*FileInfo, error) {
 
 	for i := 0; i < numEntries && offset < eocdOffset; i++ {
 		if offset+46 > len(data) {
+			if strict {
+				return nil, ErrCorrupted
+			}
 			break
 		}
 
 		sig := binary.LittleEndian.Uint32(data[offset : offset+4])
 		if sig != sigCentralDir {
+			if strict {
+				return nil, ErrCorrupted
+			}
 			break
 		}
 
+		flags := binary.LittleEndian.Uint16(data[offset+8 : offset+10])
 		method := Method(binary.LittleEndian.Uint16(data[offset+10 : offset+12]))
 		dosTime := binary.LittleEndian.Uint16(data[offset+12 : offset+14])
 		dosDate := binary.LittleEndian.Uint16(data[offset+14 : offset+16])
@@ -939,23 +1221,34 @@ func ListFiles(data []byte) ([]*FileInfo, error) {
 		localOffset := binary.LittleEndian.Uint32(data[offset+42 : offset+46])
 
 		if offset+46+nameLen+extraLen+commentLen > len(data) {
+			if strict {
+				return nil, ErrCorrupted
+			}
 			break
 		}
 
 		name := string(data[offset+46 : offset+46+nameLen])
+		comment := string(data[offset+46+nameLen+extraLen : offset+46+nameLen+extraLen+commentLen])
 
 		// Parse modification time
 		modTime := dosToTime(dosTime, dosDate)
 		vocab := VocabInfo{}
 
 		// Parse extra fields
-		if extraLen > 0 {
-			extra := data[offset+46+nameLen : offset+46+nameLen+extraLen]
-			if unixTime, ok := parseExtendedTimestamp(extra); ok {
-				modTime = unixTime
-			}
-			if parsedVocab, ok := parseVocabInfo(extra); ok {
-				vocab = parsedVocab
+		extra := data[offset+46+nameLen : offset+46+nameLen+extraLen]
+		if unixTime, ok := parseExtendedTimestamp(extra); ok {
+			modTime = unixTime
+		}
+		if parsedVocab, ok := parseVocabInfo(extra); ok {
+			vocab = parsedVocab
+		}
+		sum, _ := parseContentHash(extra)
+		method, encryption, aesVersion := resolveEncryption(method, flags, extra)
+		unixAttrs := parseUnixAttrs(extra)
+		devMajor, devMinor := parseDevice(extra)
+		if hasAccessTimes(extra) {
+			if hdr, err := parseLocalHeader(data, int(localOffset)); err == nil {
+				unixAttrs.Atime, unixAttrs.Ctime = parseAccessTimes(hdr.extra)
 			}
 		}
 
@@ -981,11 +1274,28 @@ func ListFiles(data []byte) ([]*FileInfo, error) {
 			Mode:     mode,
 			Offset:   int64(localOffset),
 			Vocab:    vocab,
+			SHA256:   sum,
+			Comment:  comment,
+			Unix:     unixAttrs,
+			HardLink: parseHardLink(extra),
+			DevMajor: devMajor,
+			DevMinor: devMinor,
+
+			Encryption: encryption,
+
+			centralExtra: extra,
+			flags:        flags,
+			dosTime:      dosTime,
+			aesVersion:   aesVersion,
 		})
 
 		offset += 46 + nameLen + extraLen + commentLen
 	}
 
+	if strict && len(files) != numEntries {
+		return nil, ErrCorrupted
+	}
+
 	return files, nil
 }
 
@@ -1020,27 +1330,94 @@ func (c *Compressor) DecompressFile(data []byte, info *FileInfo) ([]byte, error)
 
 	compressed := data[dataOffset : dataOffset+int(info.CompSize)]
 
+	return c.decompressEntry(compressed, info)
+}
+
+// decompressEntry decodes an entry's data, enforcing the extraction limits
+// while decoding rather than trusting the sizes declared in the headers.
+func (c *Compressor) decompressEntry(compressed []byte, info *FileInfo) ([]byte, error) {
+	b := c.entryBudget(info)
+	if !b.allows(info.Size) {
+		return nil, b.exceeded()
+	}
+
+	if info.Encryption != EncryptionNone {
+		plain, err := c.decryptEntry(compressed, info)
+		if err != nil {
+			return nil, err
+		}
+		compressed = plain
+	}
+
+	var content []byte
+	var err error
+
 	switch info.Method {
 	case MethodUNZLATE:
-		return c.decompressUNZLATE(compressed)
+		content, err = c.decompressUNZLATEMax(compressed, info.Vocab, b)
 	case MethodBPELATE:
-		return c.decompressBPELATEWithVocab(compressed, info.Vocab)
+		content, err = c.decompressBPELATEMax(compressed, info.Vocab, b)
+	case MethodMIXLATE:
+		content, err = c.decompressMIXLATEMax(compressed, b)
+	case MethodEXELATE:
+		content, err = c.decompressEXELATEMax(compressed, b.max)
 	case MethodDEFLATE:
-		return c.decompressDEFLATE(compressed)
+		content, err = c.decompressDEFLATEMax(compressed, b.max)
 	case MethodStore:
-		return compressed, nil
+		content = compressed
+		if !b.allows(int64(len(content))) {
+			err = errOverBudget
+		}
 	default:
 		return nil, ErrUnsupported
 	}
+
+	if err == errOverBudget {
+		return nil, b.exceeded()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.extracted += int64(len(content))
+
+	if err := verifyEntry(content, info); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// verifyEntry checks decompressed content against the size and CRC-32
+// recorded in the entry's header.
+func verifyEntry(content []byte, info *FileInfo) error {
+	size := int64(len(content))
+	crc := crc32.ChecksumIEEE(content)
+
+	// AE-2 entries store no CRC; their HMAC was checked when decrypting
+	if info.aesVersion == aesVersionAE2 && info.CRC32 == 0 {
+		crc = 0
+	}
+
+	if size != info.Size || crc != info.CRC32 {
+		return &ChecksumError{
+			Name:     info.Name,
+			CRC32:    crc,
+			WantCRC:  info.CRC32,
+			Size:     size,
+			WantSize: info.Size,
+		}
+	}
+	return verifyContentHash(content, info)
 }
 
 // DecompressAll extracts all files from a ZIP archive.
 // Returns a map of filename to file contents.
 func (c *Compressor) DecompressAll(data []byte) (map[string][]byte, error) {
-	files, err := ListFiles(data)
+	files, err := ListFilesWithLimits(data, c.limits)
 	if err != nil {
 		return nil, err
 	}
+	c.extracted = 0
 
 	result := make(map[string][]byte)
 	for _, info := range files {
@@ -1081,15 +1458,29 @@ func (c *Compressor) compressUNZLATEWith(data []byte, encoder *bpe.Encoder) ([]b
 	return ans.Compress(tokenBytes)
 }
 
-// decompressUNZLATE decompresses BPE + ANS data.
+// decompressUNZLATE decompresses BPE + ANS data using default encoder.
 func (c *Compressor) decompressUNZLATE(data []byte) ([]byte, error) {
+	return c.decompressUNZLATEMax(data, VocabInfo{}, budget{max: -1})
+}
+
+// decompressUNZLATEMax decompresses BPE + ANS data tokenized with the
+// vocabulary of vocab within budget b.
+func (c *Compressor) decompressUNZLATEMax(data []byte, vocab VocabInfo, b budget) ([]byte, error) {
+	// The ANS header declares the token stream length; check it before
+	// ans.Decompress allocates it.
+	if len(data) >= 4 {
+		tokenLen := int64(binary.LittleEndian.Uint32(data[:4]))
+		if max := b.scaled(maxVarintLen); max >= 0 && tokenLen > max {
+			return nil, errOverBudget
+		}
+	}
+
 	tokenBytes, err := ans.Decompress(data)
 	if err != nil {
 		return nil, err
 	}
 
-	tokens := decodeVarints(tokenBytes)
-	return c.encoder.Decode(tokens), nil
+	return decodeTokensMax(c.getEncoderForProgLang(vocab.ProgLang), tokenBytes, b.max)
 }
 
 // compressBPELATE compresses using BPE + DEFLATE.
@@ -1121,7 +1512,12 @@ func (c *Compressor) decompressBPELATE(data []byte) ([]byte, error) {
 
 // decompressBPELATEWithVocab decompresses BPE + DEFLATE data with specified vocabulary info.
 func (c *Compressor) decompressBPELATEWithVocab(data []byte, vocab VocabInfo) ([]byte, error) {
-	tokenBytes, err := c.decompressDEFLATE(data)
+	return c.decompressBPELATEMax(data, vocab, budget{max: -1})
+}
+
+// decompressBPELATEMax decompresses BPE + DEFLATE data within budget b.
+func (c *Compressor) decompressBPELATEMax(data []byte, vocab VocabInfo, b budget) ([]byte, error) {
+	tokenBytes, err := c.decompressDEFLATEMax(data, b.scaled(maxVarintLen))
 	if err != nil {
 		return nil, err
 	}
@@ -1131,7 +1527,32 @@ func (c *Compressor) decompressBPELATEWithVocab(data []byte, vocab VocabInfo) ([
 	}
 
 	encoder := c.getEncoderForProgLang(vocab.ProgLang)
-	tokens := decodeVarints(tokenBytes)
+	return decodeTokensMax(encoder, tokenBytes, b.max)
+}
+
+// decodeTokensMax decodes a varint token stream, failing with errOverBudget
+// instead of allocating more than max output bytes (max < 0 means no limit).
+func decodeTokensMax(encoder *bpe.Encoder, tokenBytes []byte, max int64) ([]byte, error) {
+	// Every valid token decodes to at least one byte, so more tokens than
+	// max output bytes can never fit.
+	tokens, ok := decodeVarintsMax(tokenBytes, max)
+	if !ok {
+		return nil, errOverBudget
+	}
+
+	if max >= 0 {
+		vocab := encoder.Vocabulary()
+		total := int64(0)
+		for _, id := range tokens {
+			if tok, ok := vocab.GetToken(id); ok {
+				total += int64(len(tok.Bytes))
+			}
+		}
+		if total > max {
+			return nil, errOverBudget
+		}
+	}
+
 	return encoder.Decode(tokens), nil
 }
 
@@ -1158,10 +1579,10 @@ func (c *Compressor) getEncoderForProgLang(lang ProgLang) *bpe.Encoder {
 	}
 }
 
-// compressDEFLATE compresses using DEFLATE.
+// compressDEFLATE compresses using DEFLATE, as hard as the level says.
 func (c *Compressor) compressDEFLATE(data []byte) ([]byte, error) {
 	var buf bytes.Buffer
-	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	w, err := flate.NewWriter(&buf, c.strategy().deflate)
 	if err != nil {
 		return nil, err
 	}
@@ -1172,9 +1593,28 @@ func (c *Compressor) compressDEFLATE(data []byte) ([]byte, error) {
 
 // decompressDEFLATE decompresses DEFLATE data.
 func (c *Compressor) decompressDEFLATE(data []byte) ([]byte, error) {
+	return c.decompressDEFLATEMax(data, -1)
+}
+
+// decompressDEFLATEMax decompresses DEFLATE data, failing with errOverBudget
+// once the output exceeds max bytes (max < 0 means no limit).
+func (c *Compressor) decompressDEFLATEMax(data []byte, max int64) ([]byte, error) {
 	r := flate.NewReader(bytes.NewReader(data))
 	defer r.Close()
-	return io.ReadAll(r)
+
+	if max < 0 || max == math.MaxInt64 {
+		// A saturated budget is as good as none, and max+1 would wrap
+		return io.ReadAll(r)
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(out)) > max {
+		return nil, errOverBudget
+	}
+	return out, nil
 }
 
 // encodeVarints encodes integers as variable-length bytes.
@@ -1195,9 +1635,23 @@ func encodeVarints(values []int) []byte {
 
 // decodeVarints decodes variable-length integers.
 func decodeVarints(data []byte) []int {
-	values := make([]int, 0, len(data)/2)
+	values, _ := decodeVarintsMax(data, -1)
+	return values
+}
+
+// decodeVarintsMax decodes at most max variable-length integers (max < 0
+// means no limit). It reports false if the data holds more than max values.
+func decodeVarintsMax(data []byte, max int64) ([]int, bool) {
+	capacity := int64(len(data) / 2)
+	if max >= 0 && capacity > max {
+		capacity = max
+	}
+	values := make([]int, 0, capacity)
 	pos := 0
 	for pos < len(data) {
+		if max >= 0 && int64(len(values)) >= max {
+			return nil, false
+		}
 		v := 0
 		shift := 0
 		for pos < len(data) {
@@ -1211,7 +1665,7 @@ func decodeVarints(data []byte) []int {
 		}
 		values = append(values, v)
 	}
-	return values
+	return values, true
 }
 
 // timeToDOS converts time.Time to DOS date/time format.
@@ -1280,15 +1734,17 @@ func GetFileInfo(data []byte) (*FileInfo, error) {
 	vocab := VocabInfo{} // default
 
 	// Parse extra fields
-	if extraLen > 0 {
-		extra := data[30+nameLen : 30+int(nameLen)+int(extraLen)]
-		if unixTime, ok := parseExtendedTimestamp(extra); ok {
-			modTime = unixTime
-		}
-		if parsedVocab, ok := parseVocabInfo(extra); ok {
-			vocab = parsedVocab
-		}
+	extra := data[30+nameLen : 30+int(nameLen)+int(extraLen)]
+	if unixTime, ok := parseExtendedTimestamp(extra); ok {
+		modTime = unixTime
 	}
+	if parsedVocab, ok := parseVocabInfo(extra); ok {
+		vocab = parsedVocab
+	}
+	sum, _ := parseContentHash(extra)
+	method, encryption, aesVersion := resolveEncryption(method, flags, extra)
+	unixAttrs := parseUnixAttrs(extra)
+	unixAttrs.Atime, unixAttrs.Ctime = parseAccessTimes(extra)
 
 	// Parse Unix mode from central directory (if we can find it)
 	mode := os.FileMode(0644) // default
@@ -1298,8 +1754,6 @@ func GetFileInfo(data []byte) (*FileInfo, error) {
 		}
 	}
 
-	_ = flags // UTF-8 flag doesn't affect decoding in Go
-
 	return &FileInfo{
 		Name:     name,
 		Size:     int64(uncompSize),
@@ -1310,6 +1764,14 @@ func GetFileInfo(data []byte) (*FileInfo, error) {
 		Mode:     mode,
 		Offset:   0,
 		Vocab:    vocab,
+		SHA256:   sum,
+		Unix:     unixAttrs,
+
+		Encryption: encryption,
+
+		flags:      flags,
+		dosTime:    dosTime,
+		aesVersion: aesVersion,
 	}, nil
 }
 
@@ -1438,10 +1900,26 @@ func goModeToUnix(mode os.FileMode) uint32 {
 		unixMode |= unixModeSymlink
 	case mode&os.ModeDir != 0:
 		unixMode |= unixModeDir
+	case mode&os.ModeNamedPipe != 0:
+		unixMode |= unixModeFIFO
+	case mode&os.ModeCharDevice != 0:
+		unixMode |= unixModeCharDev
+	case mode&os.ModeDevice != 0:
+		unixMode |= unixModeBlockDev
 	default:
 		unixMode |= unixModeRegular
 	}
 
+	if mode&os.ModeSetuid != 0 {
+		unixMode |= unixModeSetuid
+	}
+	if mode&os.ModeSetgid != 0 {
+		unixMode |= unixModeSetgid
+	}
+	if mode&os.ModeSticky != 0 {
+		unixMode |= unixModeSticky
+	}
+
 	return unixMode
 }
 
@@ -1456,8 +1934,25 @@ func unixModeToGo(unixMode uint32) os.FileMode {
 		mode |= os.ModeSymlink
 	case unixModeDir:
 		mode |= os.ModeDir
+	case unixModeFIFO:
+		mode |= os.ModeNamedPipe
+	case unixModeCharDev:
+		mode |= os.ModeDevice | os.ModeCharDevice
+	case unixModeBlockDev:
+		mode |= os.ModeDevice
 		// Regular file has no special mode bit in Go
 	}
 
+	// Set-user-ID, set-group-ID and sticky bits
+	if unixMode&unixModeSetuid != 0 {
+		mode |= os.ModeSetuid
+	}
+	if unixMode&unixModeSetgid != 0 {
+		mode |= os.ModeSetgid
+	}
+	if unixMode&unixModeSticky != 0 {
+		mode |= os.ModeSticky
+	}
+
 	return mode
 }
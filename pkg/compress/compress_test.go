@@ -2,11 +2,22 @@ package compress
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ha1tch/unz/pkg/bpe"
+	"github.com/ha1tch/unz/pkg/detect"
 )
 
 func testVocab() *bpe.Vocabulary {
@@ -423,6 +434,701 @@ func TestVocabInfo(t *testing.T) {
 	}
 }
 
+// Test that archive entries record the detected natural language
+func TestArchiveNatLang(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want NatLang
+	}{
+		{"english", "The committee met on Tuesday to discuss the new budget. Most of the members agreed that the library should stay open longer in the evenings.", NatLangEnglish},
+		{"german", "Der Ausschuss traf sich am Dienstag, um den neuen Haushalt zu besprechen. Die meisten Mitglieder waren sich einig, dass die Bibliothek abends länger geöffnet bleiben soll.", NatLangGerman},
+		{"too short", "ok ok ok", NatLangEnglish},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			archive := NewArchive(New(testVocab()))
+			if err := archive.Add([]byte(tc.text), "doc.txt", time.Now(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := archive.entries[0].vocabInfo.NatLang; got != tc.want {
+				t.Errorf("NatLang: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// Test that entry names steer detection for short files
+func TestArchiveNameHints(t *testing.T) {
+	comp := New(testVocab())
+	m, err := detect.ParseNameMap(strings.NewReader("*.tmpl python\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comp.SetNameMap(m)
+
+	archive := NewArchive(comp)
+	files := []struct {
+		name string
+		data string
+		want ProgLang
+	}{
+		{"cmd/short.go", "x := f()\n", ProgLangGo},
+		{"gen/page.tmpl", "x = f()\n", ProgLangPython},
+		{"notes", "x := f()\n", ProgLangNone},
+	}
+	for _, f := range files {
+		if err := archive.Add([]byte(f.data), f.name, time.Now(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, f := range files {
+		if got := archive.entries[i].vocabInfo.ProgLang; got != f.want {
+			t.Errorf("%s: ProgLang got %v, want %v", f.name, got, f.want)
+		}
+	}
+
+	// CompressFileWithMode uses the name as well
+	output, err := comp.CompressFileWithMode([]byte("x := f()\n"), "short.go", time.Now(), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := comp.Decompress(output)
+	if err != nil || string(data) != "x := f()\n" {
+		t.Errorf("roundtrip: got %q, %v", data, err)
+	}
+}
+
+// testX86ELF builds an x86-64 ELF image whose code calls a few functions
+// from many places, as compiled code does.
+func testX86ELF() []byte {
+	data := make([]byte, 64, 64+5000*9)
+	copy(data, "\x7fELF\x02\x01\x01")
+	data[18] = 62 // EM_X86_64
+	seed := uint32(1)
+	for i := 0; i < 5000; i++ {
+		seed = seed*1103515245 + 12345
+		data = append(data, 0x48, 0x89, byte(seed>>16)|0xc0)
+		target := 0x1000 + int(seed>>24%8)*0x40
+		rel := uint32(target - (len(data) + 5))
+		data = append(data, 0xe8, byte(rel), byte(rel>>8), byte(rel>>16), byte(rel>>24))
+		data = append(data, 0x90)
+	}
+	return data
+}
+
+func TestX86Filter(t *testing.T) {
+	// Arbitrary data full of E8/E9 bytes must survive the filter
+	data := make([]byte, 100000)
+	seed := uint32(7)
+	for i := range data {
+		seed = seed*1103515245 + 12345
+		switch seed >> 28 {
+		case 0, 1:
+			data[i] = 0xe8
+		case 2:
+			data[i] = 0xe9
+		case 3, 4:
+			data[i] = 0xff
+		case 5, 6:
+			data[i] = 0x00
+		default:
+			data[i] = byte(seed >> 16)
+		}
+	}
+	filtered := append([]byte(nil), data...)
+	x86Filter(filtered, true)
+	if bytes.Equal(filtered, data) {
+		t.Error("filter changed nothing")
+	}
+	x86Filter(filtered, false)
+	if !bytes.Equal(filtered, data) {
+		t.Error("filter roundtrip mismatch")
+	}
+
+	// Calls to the same function become identical
+	call := func(pos, target int) []byte {
+		rel := uint32(target - (pos + 5))
+		return []byte{0xe8, byte(rel), byte(rel >> 8), byte(rel >> 16), byte(rel >> 24)}
+	}
+	code := append(call(0, 0x400), call(5, 0x400)...)
+	x86Filter(code, true)
+	if !bytes.Equal(code[:5], code[5:]) {
+		t.Errorf("filtered calls differ: % x", code)
+	}
+}
+
+func TestArchiveBinaryFormats(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+
+	exe := testX86ELF()
+	arm := append([]byte(nil), exe...)
+	arm[18] = 183 // EM_AARCH64
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte("IDAT"), 1000)...)
+	gz := append([]byte("\x1f\x8b\x08\x00"), bytes.Repeat([]byte("abcd"), 1000)...)
+
+	files := []struct {
+		name string
+		data []byte
+		want Method
+	}{
+		{"bin/tool", exe, MethodEXELATE},
+		{"bin/tool-arm64", arm, MethodDEFLATE},
+		{"logo.png", png, MethodStore},
+		{"data.gz", gz, MethodStore},
+	}
+	for _, f := range files {
+		if err := archive.Add(f.data, f.name, time.Now(), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	infos, err := ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles(): %v", err)
+	}
+	for i, f := range files {
+		if infos[i].Method != f.want {
+			t.Errorf("%s: method got %v, want %v", f.name, infos[i].Method, f.want)
+		}
+		content, err := comp.DecompressFile(data, infos[i])
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if !bytes.Equal(content, f.data) {
+			t.Errorf("%s: roundtrip mismatch", f.name)
+		}
+	}
+
+	// CompressFileWithMode selects the same method
+	output, err := comp.CompressFileWithMode(exe, "tool", time.Now(), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := ListFiles(output)
+	if err != nil || single[0].Method != MethodEXELATE {
+		t.Errorf("CompressFileWithMode: got %v, %v, want Exelate", single, err)
+	}
+	if content, err := comp.Decompress(output); err != nil || !bytes.Equal(content, exe) {
+		t.Errorf("CompressFileWithMode roundtrip: %v", err)
+	}
+}
+
+func TestMixlate(t *testing.T) {
+	comp := New(testVocab())
+	prose := strings.Repeat("The tool reads the configuration and prints a summary of each file. ", 8)
+	goCode := `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	for _, arg := range os.Args[1:] {
+		if err := run(arg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+`
+	pyCode := `import os
+import sys
+
+def main():
+    for arg in sys.argv[1:]:
+        if not os.path.exists(arg):
+            print("missing:", arg, file=sys.stderr)
+            sys.exit(1)
+
+if __name__ == "__main__":
+    main()
+`
+	doc := []byte("# Usage\n\n" + prose + "\n\n```go\n" + goCode + "```\n\n" + prose +
+		"\n\n```python\n" + pyCode + "```\n\n" + prose + "\n")
+
+	profile := detect.Detect(doc)
+	mixed, ok := comp.compressMIXLATE(doc, profile.Segments)
+	if !ok {
+		t.Fatalf("compressMIXLATE: not mixed (%d segments)", len(profile.Segments))
+	}
+	got, err := comp.decompressMIXLATEMax(mixed, budget{max: -1})
+	if err != nil || !bytes.Equal(got, doc) {
+		t.Fatalf("roundtrip: %v", err)
+	}
+
+	// The segment map: prose, Go, prose, Python, prose
+	if count, _ := binary.Uvarint(mixed); count != 5 {
+		t.Errorf("segment count: got %d, want 5", count)
+	}
+
+	// Files in one language are left to Bpelate
+	if _, ok := comp.compressMIXLATE([]byte(goCode), detect.Detect([]byte(goCode)).Segments); ok {
+		t.Error("compressMIXLATE accepted a file with one language")
+	}
+
+	// Corrupt segment maps are rejected, including sizes that wrap around
+	// to the length of the tokens (2^64-1 + 4 = 3)
+	tokens, err := comp.compressDEFLATE([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped := binary.AppendUvarint([]byte{2}, math.MaxUint64)
+	wrapped = append(binary.AppendUvarint(append(wrapped, 0), 4), 0)
+	wrapped = append(wrapped, tokens...)
+	for _, bad := range [][]byte{nil, {0xff}, {2, 3}, append([]byte{1, 200, 0}, mixed[len(mixed)-20:]...), wrapped} {
+		if _, err := comp.decompressMIXLATEMax(bad, budget{max: -1}); err == nil {
+			t.Errorf("decompressMIXLATEMax(% x): no error", bad)
+		}
+	}
+
+	// Archives pick the method by size; whatever wins must round-trip
+	archive := NewArchive(comp)
+	if err := archive.Add(doc, "README.md", testTime(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := comp.DecompressAll(data)
+	if err != nil || !bytes.Equal(files["README.md"], doc) {
+		t.Errorf("archive roundtrip: %v", err)
+	}
+}
+
+func TestVocabCandidates(t *testing.T) {
+	comp := New(testVocab())
+	testCases := []struct {
+		name    string
+		profile detect.Profile
+		want    []detect.CodeLang
+	}{
+		{
+			name: "confident",
+			profile: detect.Profile{
+				Language:           detect.CodeLangGo,
+				LanguageConfidence: 0.97,
+				LanguageScores:     []detect.CodeLangScore{{Lang: detect.CodeLangGo, Score: 0.97}, {Lang: detect.CodeLangPython, Score: 0.03}},
+			},
+			want: []detect.CodeLang{detect.CodeLangGo},
+		},
+		{
+			name: "unsure",
+			profile: detect.Profile{
+				Language:           detect.CodeLangGo,
+				LanguageConfidence: 0.7,
+				LanguageScores:     []detect.CodeLangScore{{Lang: detect.CodeLangGo, Score: 0.7}, {Lang: detect.CodeLangPython, Score: 0.2}, {Lang: detect.CodeLangJavaScript, Score: 0.1}},
+			},
+			want: []detect.CodeLang{detect.CodeLangGo, detect.CodeLangPython},
+		},
+		{
+			name: "runner-up shares the default vocabulary",
+			profile: detect.Profile{
+				Language:           detect.CodeLangUnknown,
+				LanguageConfidence: 0.5,
+				LanguageScores:     []detect.CodeLangScore{{Lang: detect.CodeLangJava, Score: 0.5}, {Lang: detect.CodeLangJavaScript, Score: 0.4}},
+			},
+			want: []detect.CodeLang{detect.CodeLangUnknown, detect.CodeLangJavaScript},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := comp.vocabCandidates(tc.profile)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	comp := New(testVocab())
+	goCode := []byte("package calc\n\n" + strings.Repeat(`func (s *Stack) Push(v int) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack) Pop() (int, error) {
+	if len(s.items) == 0 {
+		return 0, fmt.Errorf("empty stack")
+	}
+	v := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return v, nil
+}
+
+`, 10))
+
+	archive := NewArchive(comp)
+	archive.SetExplain(true)
+	for _, name := range []string{"add.go", "empty.txt"} {
+		data := goCode
+		if name == "empty.txt" {
+			data = nil
+		}
+		if err := archive.Add(data, name, testTime(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	explanations := archive.Explanations()
+	if len(explanations) != 2 {
+		t.Fatalf("got %d explanations, want 2", len(explanations))
+	}
+	e := explanations[0]
+	if e.Name != "add.go" || e.Size != int64(len(goCode)) || e.Profile.Type != detect.TypeCode {
+		t.Errorf("got %s, %d bytes, %v", e.Name, e.Size, e.Profile.Type)
+	}
+
+	// Explain matches the choice of Add
+	info, _ := archive.Lookup("add.go")
+	if e.Method != info.Method || e.CompSize != info.CompSize || e.Vocab != info.Vocab {
+		t.Errorf("explained %v (%d bytes), added %v (%d bytes)", e.Method, e.CompSize, info.Method, info.CompSize)
+	}
+	if again := comp.Explain(goCode, "add.go"); !reflect.DeepEqual(again, e) {
+		t.Errorf("Explain differs from the archive's explanation")
+	}
+
+	// Every method and vocabulary is measured, those Add skips included
+	type key struct {
+		method Method
+		vocab  ProgLang
+	}
+	seen := make(map[key]Candidate)
+	for _, c := range e.Candidates {
+		seen[key{c.Method, c.Vocab}] = c
+		if c.Err == nil && c.Auto && c.Size < e.CompSize {
+			t.Errorf("%v/%v is smaller than the chosen method", c.Method, c.Vocab)
+		}
+	}
+	want := []key{
+		{MethodStore, ProgLangNone}, {MethodDEFLATE, ProgLangNone}, {MethodUNZLATE, ProgLangNone},
+		{MethodBPELATE, ProgLangNone}, {MethodBPELATE, ProgLangGo}, {MethodBPELATE, ProgLangPython},
+		{MethodBPELATE, ProgLangJavaScript},
+	}
+	for _, k := range want {
+		if _, ok := seen[k]; !ok {
+			t.Errorf("no candidate %v/%v", k.method, k.vocab)
+		}
+	}
+	if !seen[key{MethodDEFLATE, ProgLangNone}].Auto || !seen[key{MethodBPELATE, ProgLangGo}].Auto {
+		t.Error("DEFLATE and Go Bpelate not marked as tried by Add")
+	}
+	if seen[key{MethodUNZLATE, ProgLangNone}].Auto || seen[key{MethodBPELATE, ProgLangPython}].Auto {
+		t.Error("Unzlate or Python Bpelate marked as tried by Add")
+	}
+
+	// Empty files are stored
+	if e := explanations[1]; e.Method != MethodStore || len(e.Candidates) != 1 {
+		t.Errorf("empty file: got %v with %d candidates", e.Method, len(e.Candidates))
+	}
+}
+
+func TestAddWith(t *testing.T) {
+	comp := New(testVocab())
+	goCode := []byte("package main\n\n" + strings.Repeat(`func main() {
+	for i, arg := range os.Args[1:] {
+		fmt.Printf("%d: %s\n", i, arg)
+	}
+}
+
+`, 10))
+	english := VocabInfo{NatLang: NatLangEnglish}
+
+	testCases := []struct {
+		name       string
+		opts       AddOptions
+		wantMethod Method
+		wantVocab  VocabInfo
+	}{
+		{"store", AddOptions{Method: MethodStore, ForceMethod: true}, MethodStore, english},
+		{"deflate", AddOptions{Method: MethodDEFLATE, ForceMethod: true}, MethodDEFLATE, english},
+		{"unzlate", AddOptions{Method: MethodUNZLATE, ForceMethod: true}, MethodUNZLATE, VocabInfo{NatLang: NatLangEnglish, ProgLang: ProgLangGo}},
+		{"bpelate python", AddOptions{
+			Method: MethodBPELATE, ForceMethod: true,
+			Vocab: VocabInfo{NatLang: NatLangSpanish, ProgLang: ProgLangPython}, ForceVocab: true,
+		}, MethodBPELATE, VocabInfo{NatLang: NatLangSpanish, ProgLang: ProgLangPython}},
+		// Mixlate records the detected language but keeps a vocabulary per segment
+		{"mixlate", AddOptions{Method: MethodMIXLATE, ForceMethod: true}, MethodMIXLATE, VocabInfo{NatLang: NatLangEnglish, ProgLang: ProgLangGo}},
+	}
+
+	archive := NewArchive(comp)
+	for _, tc := range testCases {
+		name := tc.name + ".go"
+		if err := archive.AddWith(goCode, name, testTime(), 0644, tc.opts); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		info, _ := archive.Lookup(name)
+		if info.Method != tc.wantMethod || (usesVocab(info.Method) && info.Vocab != tc.wantVocab) {
+			t.Errorf("%s: got %v %+v, want %v %+v", tc.name, info.Method, info.Vocab, tc.wantMethod, tc.wantVocab)
+		}
+	}
+
+	// A forced vocabulary alone still picks the smaller of Bpelate and DEFLATE
+	vocab := VocabInfo{ProgLang: ProgLangPython, DataFmt: DataFmtJSON}
+	if err := archive.AddWith(goCode, "vocab.go", testTime(), 0644, AddOptions{Vocab: vocab, ForceVocab: true}); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := archive.Lookup("vocab.go"); info.Method == MethodBPELATE && info.Vocab != vocab {
+		t.Errorf("forced vocabulary: got %+v, want %+v", info.Vocab, vocab)
+	}
+
+	if err := archive.AddWith(goCode, "bad.go", testTime(), 0644, AddOptions{Method: 99, ForceMethod: true}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("unknown method: got %v, want ErrUnsupported", err)
+	}
+
+	// Every entry decodes with the vocabulary it recorded
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := comp.DecompressAll(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if !bytes.Equal(content, goCode) {
+			t.Errorf("%s: content differs", name)
+		}
+	}
+}
+
+func TestCompressFileAsVocab(t *testing.T) {
+	comp := New(testVocab())
+	pySrc := []byte(strings.Repeat("def greet(name):\n    print(f\"hello {name}\")\n    return None\n\n", 20))
+	for _, method := range []Method{MethodUNZLATE, MethodBPELATE} {
+		compressed, err := comp.CompressFileAs(pySrc, "greet.py", testTime(), method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := GetFileInfo(compressed)
+		if err != nil || info.Vocab.ProgLang != ProgLangPython {
+			t.Errorf("%v: vocabulary %v, %v (want python)", method, info.Vocab.ProgLang, err)
+		}
+		if got, err := comp.Decompress(compressed); err != nil || !bytes.Equal(got, pySrc) {
+			t.Errorf("%v: roundtrip: %v", method, err)
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	comp := New(testVocab())
+	if comp.Level() != LevelDefault {
+		t.Errorf("default level: got %d, want %d", comp.Level(), LevelDefault)
+	}
+	for _, tc := range []struct{ set, want int }{{0, 1}, {-3, 1}, {5, 5}, {12, 9}} {
+		comp.SetLevel(tc.set)
+		if comp.Level() != tc.want {
+			t.Errorf("SetLevel(%d): got %d, want %d", tc.set, comp.Level(), tc.want)
+		}
+	}
+
+	goCode := []byte("package main\n\nimport \"fmt\"\n\n" + strings.Repeat(`func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", r.Form.Get("q"))
+}
+
+`, 10))
+	text := []byte(strings.Repeat("The archive keeps every file with the method that makes it smallest. ", 30))
+
+	sizes := make(map[int]int64)
+	for level := LevelFastest; level <= LevelBest; level++ {
+		comp.SetLevel(level)
+		archive := NewArchive(comp)
+		for _, f := range []struct {
+			name string
+			data []byte
+		}{{"server.go", goCode}, {"notes.txt", text}} {
+			if err := archive.Add(f.data, f.name, testTime(), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data, err := archive.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files, err := comp.DecompressAll(data)
+		if err != nil || !bytes.Equal(files["server.go"], goCode) || !bytes.Equal(files["notes.txt"], text) {
+			t.Fatalf("level %d: roundtrip: %v", level, err)
+		}
+		sizes[level] = int64(len(data))
+
+		info, _ := archive.Lookup("server.go")
+		switch {
+		case level == 1 && info.Method != MethodDEFLATE:
+			t.Errorf("level 1: got %v, want Deflate", info.Method)
+		case level == 2 && (info.Method != MethodBPELATE || info.Vocab.ProgLang != ProgLangGo):
+			// Confident Go code: Bpelate without a trial
+			t.Errorf("level 2: got %v %v, want Bpelate with the Go vocabulary", info.Method, info.Vocab.ProgLang)
+		}
+
+		// A single-file archive makes the same choice as Archive.Add
+		single, err := comp.CompressFile(goCode, "server.go", testTime())
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos, err := ListFiles(single)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := infos[0]; got.Method != info.Method || (usesVocab(got.Method) && got.Vocab != info.Vocab) {
+			t.Errorf("level %d: CompressFile chose %v %v, Archive.Add %v %v", level, got.Method, got.Vocab, info.Method, info.Vocab)
+		}
+
+		// Only the levels trying token layouts try Unzlate
+		unzlate := false
+		for _, cand := range comp.Explain(goCode, "server.go").Candidates {
+			unzlate = unzlate || (cand.Method == MethodUNZLATE && cand.Auto)
+		}
+		if unzlate != (level >= 7) {
+			t.Errorf("level %d: Unzlate tried: %v", level, unzlate)
+		}
+	}
+
+	// The higher levels try everything the lower ones do
+	if sizes[9] > sizes[6] || sizes[6] > sizes[4] {
+		t.Errorf("sizes by level: %v", sizes)
+	}
+}
+
+func TestPredict(t *testing.T) {
+	comp := New(testVocab())
+
+	// Nothing to estimate from a run of one byte
+	if p := comp.predict(bytes.Repeat([]byte("a"), 1000), ProgLangNone); p.Sure || p.Ratio != 1 {
+		t.Errorf("run of one byte: got %+v", p)
+	}
+	sampled := 0
+	for _, w := range predictWindows(make([]byte, 100000)) {
+		sampled += len(w)
+	}
+	if sampled > predictSample {
+		t.Errorf("sampled %d bytes, want at most %d", sampled, predictSample)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	names := []string{"count", "total", "index", "value", "result", "buffer", "reader", "offset"}
+	var goCode, words strings.Builder
+	goCode.WriteString("package main\n\nimport \"fmt\"\n\n")
+	for i := 0; i < 60; i++ {
+		a, b := names[rng.Intn(len(names))], names[rng.Intn(len(names))]
+		fmt.Fprintf(&goCode, "func %s%d(%s int) (int, error) {\n\tif %s > %d {\n\t\treturn 0, fmt.Errorf(\"%s too large\")\n\t}\n\treturn %s * %d, nil\n}\n\n",
+			a, i, b, b, rng.Intn(1000), b, b, rng.Intn(100))
+	}
+	for i := 0; i < 2000; i++ {
+		words.WriteString(names[rng.Intn(len(names))] + " ")
+	}
+
+	for _, f := range []struct {
+		name string
+		data []byte
+		lang ProgLang
+	}{{"calc.go", []byte(goCode.String()), ProgLangGo}, {"words.txt", []byte(words.String()), ProgLangNone}} {
+		comp.SetLevel(LevelDefault)
+		e := comp.Explain(f.data, f.name)
+		pred := e.Prediction
+		if pred == nil || pred.Vocab != f.lang || !pred.Sure {
+			t.Fatalf("%s: got prediction %+v, want a sure one with the %v vocabulary", f.name, pred, f.lang)
+		}
+		var deflate, bpelate Candidate
+		for _, c := range e.Candidates {
+			switch {
+			case c.Method == MethodDEFLATE:
+				deflate = c
+			case c.Method == MethodBPELATE && c.Vocab == f.lang:
+				bpelate = c
+			}
+		}
+		// Only the predicted method is tried
+		if deflate.Auto != (pred.Method == MethodDEFLATE) || bpelate.Auto != (pred.Method == MethodBPELATE) || e.Method != pred.Method {
+			t.Errorf("%s: predicted %v, tried Deflate %v, Bpelate %v, chose %v", f.name, pred.Method, deflate.Auto, bpelate.Auto, e.Method)
+		}
+		smaller := MethodDEFLATE
+		if bpelate.Size < deflate.Size {
+			smaller = MethodBPELATE
+		}
+		if e.Mispredicted() != (pred.Method != smaller && deflate.Size != bpelate.Size) {
+			t.Errorf("%s: predicted %v, Deflate %d, Bpelate %d bytes, mispredicted %v", f.name, pred.Method, deflate.Size, bpelate.Size, e.Mispredicted())
+		}
+
+		// The single file path predicts the same way
+		zipData, err := comp.CompressFile(f.data, f.name, testTime())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := comp.Decompress(zipData); err != nil || !bytes.Equal(got, f.data) {
+			t.Errorf("%s: roundtrip: %v", f.name, err)
+		}
+
+		// Levels 7 and up try both
+		comp.SetLevel(7)
+		e = comp.Explain(f.data, f.name)
+		if e.Prediction != nil {
+			t.Errorf("%s: level 7 predicted %+v", f.name, *e.Prediction)
+		}
+		for _, c := range e.Candidates {
+			if (c.Method == MethodDEFLATE || c.Method == MethodBPELATE && c.Vocab == f.lang) && !c.Auto {
+				t.Errorf("%s: level 7 did not try %v %v", f.name, c.Method, c.Vocab)
+			}
+		}
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Method
+		err  bool
+	}{
+		{"store", MethodStore, false},
+		{"Stored", MethodStore, false},
+		{"deflate", MethodDEFLATE, false},
+		{"UNZLATE", MethodUNZLATE, false},
+		{"bpelate", MethodBPELATE, false},
+		{"exelate", MethodEXELATE, false},
+		{"lzma", 0, true},
+	}
+	for _, tc := range testCases {
+		got, err := ParseMethod(tc.in)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("ParseMethod(%q): got %v, %v", tc.in, got, err)
+		}
+	}
+}
+
+func TestParseVocabInfo(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want VocabInfo
+		err  bool
+	}{
+		{"go", VocabInfo{ProgLang: ProgLangGo}, false},
+		{"es,Python", VocabInfo{NatLang: NatLangSpanish, ProgLang: ProgLangPython}, false},
+		{"en, json, markdown", VocabInfo{NatLang: NatLangEnglish, DataFmt: DataFmtJSON, Markup: MarkupMarkdown}, false},
+		{"c++", VocabInfo{ProgLang: ProgLangCPP}, false},
+		{"go,python", VocabInfo{}, true},
+		{"klingon", VocabInfo{}, true},
+		{"", VocabInfo{}, true},
+	}
+	for _, tc := range testCases {
+		got, err := ParseVocabInfo(tc.in)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("ParseVocabInfo(%q): got %+v, %v", tc.in, got, err)
+		}
+	}
+}
+
 // Test legacy 1-byte VocabInfo parsing
 func TestVocabInfoLegacy(t *testing.T) {
 	// Create a legacy 1-byte extra field
@@ -454,6 +1160,8 @@ func TestTypeStrings(t *testing.T) {
 		{MethodDEFLATE, "Deflate"},
 		{MethodUNZLATE, "Unzlate"},
 		{MethodBPELATE, "Bpelate"},
+		{MethodMIXLATE, "Mixlate"},
+		{MethodEXELATE, "Exelate"},
 		{Method(255), "Unknown"},
 	}
 	for _, tc := range methods {
@@ -647,6 +1355,42 @@ func TestArchiveSymlink(t *testing.T) {
 	}
 }
 
+func TestArchiveDuplicateName(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+
+	// A second Add of the same name replaces the first in place
+	archive.Add([]byte("first version"), "a.txt", testTime(), 0644)
+	archive.Add([]byte("other file"), "b.txt", testTime(), 0644)
+	archive.AddStore([]byte("second version"), "a.txt", testTime(), 0600)
+
+	if got := strings.Join(archive.Names(), ","); got != "a.txt,b.txt" {
+		t.Errorf("Names() = %s, want a.txt,b.txt", got)
+	}
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	infos, err := ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles(): %v", err)
+	}
+	if len(infos) != 2 || infos[0].Name != "a.txt" {
+		t.Fatalf("entries = %v, want a.txt then b.txt", infos)
+	}
+	if infos[0].Mode.Perm() != 0600 {
+		t.Errorf("a.txt mode = %v, want the second Add's 0600", infos[0].Mode)
+	}
+	content, err := comp.DecompressFile(data, infos[0])
+	if err != nil {
+		t.Fatalf("DecompressFile: %v", err)
+	}
+	if string(content) != "second version" {
+		t.Errorf("a.txt = %q, want the second version", content)
+	}
+}
+
 func TestDecompressAll(t *testing.T) {
 	vocab := testVocab()
 	comp := New(vocab)
@@ -705,6 +1449,11 @@ func TestModeConversion(t *testing.T) {
 		{"regular file", 0644, 0100000},
 		{"directory", os.ModeDir | 0755, 0040000},
 		{"symlink", os.ModeSymlink | 0777, 0120000},
+		{"fifo", os.ModeNamedPipe | 0644, 0010000},
+		{"char device", os.ModeDevice | os.ModeCharDevice | 0666, 0020000},
+		{"block device", os.ModeDevice | 0660, 0060000},
+		{"sticky directory", os.ModeDir | os.ModeSticky | 0777, 0040000},
+		{"setuid file", os.ModeSetuid | os.ModeSetgid | 0755, 0100000},
 	}
 
 	for _, tc := range tests {
@@ -719,5 +1468,1071 @@ func TestModeConversion(t *testing.T) {
 		if tc.goMode&os.ModeType != goMode&os.ModeType {
 			t.Errorf("%s: round-trip type mismatch: %v != %v", tc.name, tc.goMode&os.ModeType, goMode&os.ModeType)
 		}
+		if goMode != tc.goMode {
+			t.Errorf("%s: round-trip mode = %v, want %v", tc.name, goMode, tc.goMode)
+		}
+	}
+}
+
+// === Limits Tests ===
+
+func TestLimitsEntrySize(t *testing.T) {
+	comp := New(testVocab())
+	data := bytes.Repeat([]byte{0}, 1<<20)
+
+	for _, method := range []Method{MethodDEFLATE, MethodBPELATE, MethodUNZLATE, MethodMIXLATE, MethodEXELATE, MethodStore} {
+		t.Run(method.String(), func(t *testing.T) {
+			compressed, err := comp.CompressFileAs(data, "zeros.bin", testTime(), method)
+			if err != nil {
+				t.Fatalf("compress failed: %v", err)
+			}
+			infos, err := ListFiles(compressed)
+			if err != nil {
+				t.Fatalf("ListFiles: %v", err)
+			}
+
+			// Lie about the size so only the decode-time check can catch it
+			info := *infos[0]
+			info.Size = 10
+
+			comp.SetLimits(Limits{MaxEntrySize: 4096})
+			_, err = comp.DecompressFile(compressed, &info)
+			if !errors.Is(err, ErrEntryTooLarge) {
+				t.Fatalf("got %v, want ErrEntryTooLarge", err)
+			}
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Name != "zeros.bin" || limitErr.Limit != 4096 {
+				t.Errorf("unexpected LimitError: %#v", limitErr)
+			}
+
+			comp.SetLimits(Limits{MaxEntrySize: int64(len(data))})
+			got, err := comp.DecompressFile(compressed, infos[0])
+			if err != nil {
+				t.Fatalf("within limit: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("roundtrip failed within limit")
+			}
+		})
+	}
+}
+
+func TestLimitsRatio(t *testing.T) {
+	comp := New(testVocab())
+	data := bytes.Repeat([]byte("a"), 100000)
+
+	compressed, _ := comp.CompressFileAs(data, "a.txt", testTime(), MethodDEFLATE)
+	infos, _ := ListFiles(compressed)
+
+	comp.SetLimits(Limits{MaxRatio: 10})
+	if _, err := comp.DecompressFile(compressed, infos[0]); !errors.Is(err, ErrRatioExceeded) {
+		t.Errorf("got %v, want ErrRatioExceeded", err)
+	}
+
+	comp.SetLimits(Limits{MaxRatio: 100000})
+	if _, err := comp.DecompressFile(compressed, infos[0]); err != nil {
+		t.Errorf("within ratio: %v", err)
+	}
+}
+
+func TestLimitsOverflow(t *testing.T) {
+	// A hostile compressed size times the ratio must not wrap to a small
+	// or negative (unlimited) budget
+	comp := New(testVocab())
+	comp.SetLimits(Limits{MaxRatio: 1000})
+	b := comp.entryBudget(&FileInfo{Name: "x", CompSize: math.MaxInt64 / 10})
+	if b.max != math.MaxInt64 {
+		t.Errorf("ratio budget: got %d, want %d", b.max, int64(math.MaxInt64))
+	}
+	if got := b.scaled(5); got != math.MaxInt64 {
+		t.Errorf("scaled budget: got %d, want %d", got, int64(math.MaxInt64))
+	}
+	if got := (budget{max: 100}).scaled(5); got != 500 {
+		t.Errorf("scaled budget: got %d, want 500", got)
+	}
+
+	// Saturated budgets still decode: a huge entry size scales past the
+	// maximum for Bpelate's tokens, and a huge ratio for DEFLATE
+	text := []byte(strings.Repeat("The budget saturates but the entry still decodes. ", 40))
+	for _, tc := range []struct {
+		method Method
+		limits Limits
+	}{
+		{MethodBPELATE, Limits{MaxEntrySize: math.MaxInt64 / 2}},
+		{MethodDEFLATE, Limits{MaxRatio: math.MaxInt64}},
+	} {
+		data, err := New(testVocab()).CompressFileAs(text, "x.txt", testTime(), tc.method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		comp := New(testVocab())
+		comp.SetLimits(tc.limits)
+		if got, err := comp.Decompress(data); err != nil || !bytes.Equal(got, text) {
+			t.Errorf("%v with %+v: %v", tc.method, tc.limits, err)
+		}
+	}
+}
+
+func TestLimitsArchive(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		archive.Add(bytes.Repeat([]byte("x"), 1000), name, testTime(), 0644)
+	}
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+
+	comp.SetLimits(Limits{MaxArchiveSize: 2500})
+	_, err = comp.DecompressAll(data)
+	if !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("DecompressAll: got %v, want ErrArchiveTooLarge", err)
+	}
+
+	// DecompressAll resets the budget, so a generous limit passes twice
+	comp.SetLimits(Limits{MaxArchiveSize: 3000})
+	for i := 0; i < 2; i++ {
+		if _, err := comp.DecompressAll(data); err != nil {
+			t.Errorf("DecompressAll run %d: %v", i, err)
+		}
+	}
+
+	if _, err := ListFilesWithLimits(data, Limits{MaxEntries: 2}); !errors.Is(err, ErrTooManyEntries) {
+		t.Errorf("ListFilesWithLimits: got %v, want ErrTooManyEntries", err)
+	}
+	if infos, err := ListFilesWithLimits(data, Limits{MaxEntries: 3}); err != nil || len(infos) != 3 {
+		t.Errorf("ListFilesWithLimits: got %d entries, %v", len(infos), err)
+	}
+}
+
+// === Checksum Tests ===
+
+func TestChecksumVerification(t *testing.T) {
+	comp := New(testVocab())
+	data := []byte("checksummed content for every method")
+
+	for _, method := range []Method{MethodStore, MethodDEFLATE, MethodBPELATE, MethodUNZLATE, MethodMIXLATE, MethodEXELATE} {
+		t.Run(method.String(), func(t *testing.T) {
+			compressed, err := comp.CompressFileAs(data, "sum.txt", testTime(), method)
+			if err != nil {
+				t.Fatalf("compress failed: %v", err)
+			}
+			infos, _ := ListFiles(compressed)
+
+			if _, err := comp.DecompressFile(compressed, infos[0]); err != nil {
+				t.Fatalf("intact archive: %v", err)
+			}
+
+			badCRC := *infos[0]
+			badCRC.CRC32 ^= 1
+			_, err = comp.DecompressFile(compressed, &badCRC)
+			if !errors.Is(err, ErrChecksum) {
+				t.Fatalf("bad CRC: got %v, want ErrChecksum", err)
+			}
+			sumErr := err.(*ChecksumError)
+			if sumErr.WantCRC != badCRC.CRC32 || sumErr.CRC32 != infos[0].CRC32 {
+				t.Errorf("bad CRC: got %08x want %08x in %v", sumErr.CRC32, sumErr.WantCRC, err)
+			}
+			problem := fmt.Sprintf("bad CRC %08x  (should be %08x)", sumErr.CRC32, sumErr.WantCRC)
+			if sumErr.Problem() != problem || err.Error() != badCRC.Name+": "+problem {
+				t.Errorf("bad CRC: got %q, problem %q", err, sumErr.Problem())
+			}
+
+			badSize := *infos[0]
+			badSize.Size++
+			if _, err := comp.DecompressFile(compressed, &badSize); !errors.Is(err, ErrChecksum) {
+				t.Errorf("bad size: got %v, want ErrChecksum", err)
+			}
+		})
+	}
+}
+
+func TestChecksumCorruptedData(t *testing.T) {
+	comp := New(testVocab())
+	compressed, _ := comp.CompressFileAs([]byte("stored bytes"), "s.txt", testTime(), MethodStore)
+
+	// Flip a byte of the stored content (after the 30-byte header, name and extra)
+	infos, _ := ListFiles(compressed)
+	nameLen := int(compressed[26]) | int(compressed[27])<<8
+	extraLen := int(compressed[28]) | int(compressed[29])<<8
+	compressed[30+nameLen+extraLen] ^= 0xFF
+
+	if _, err := comp.DecompressFile(compressed, infos[0]); !errors.Is(err, ErrChecksum) {
+		t.Errorf("got %v, want ErrChecksum", err)
+	}
+	if _, err := comp.Decompress(compressed); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Decompress: got %v, want ErrChecksum", err)
+	}
+}
+
+// === Validation and Recovery Tests ===
+
+func buildTestArchive(t *testing.T, comp *Compressor, names ...string) []byte {
+	t.Helper()
+	archive := NewArchive(comp)
+	for _, name := range names {
+		content := bytes.Repeat([]byte("content of "+name+"\n"), 20)
+		if err := archive.Add(content, name, testTime(), 0640); err != nil {
+			t.Fatalf("Add(%s): %v", name, err)
+		}
+	}
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	return data
+}
+
+func TestListFilesStrict(t *testing.T) {
+	comp := New(testVocab())
+	data := buildTestArchive(t, comp, "a.txt", "b.txt")
+
+	if files, err := ListFilesStrict(data, Limits{}); err != nil || len(files) != 2 {
+		t.Fatalf("intact archive: %d files, %v", len(files), err)
+	}
+
+	// Local CRC differs from the central directory
+	bad := append([]byte(nil), data...)
+	bad[14] ^= 0xFF
+	_, err := ListFilesStrict(bad, Limits{})
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) || mismatch.Field != "crc" || !errors.Is(err, ErrInconsistent) {
+		t.Errorf("CRC mismatch: got %v", err)
+	}
+	if _, err := ListFiles(bad); err != nil {
+		t.Errorf("non-strict ListFiles should ignore local headers: %v", err)
+	}
+
+	// Claim one more entry than the central directory holds
+	bad = append([]byte(nil), data...)
+	eocd := len(bad) - 22
+	bad[eocd+10]++
+	if _, err := ListFilesStrict(bad, Limits{}); err != ErrCorrupted {
+		t.Errorf("short central directory: got %v, want ErrCorrupted", err)
+	}
+	if files, err := ListFiles(bad); err != nil || len(files) != 2 {
+		t.Errorf("non-strict: got %d files, %v", len(files), err)
+	}
+}
+
+func TestListFilesStrictExtras(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.SetSHA256(true)
+	if err := archive.Add(bytes.Repeat([]byte("hashed content\n"), 20), "a.txt", testTime(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListFilesStrict(data, Limits{}); err != nil {
+		t.Fatalf("intact archive: %v", err)
+	}
+
+	// The SHA-256 field of the local header, which comes first
+	nameLen := int(binary.LittleEndian.Uint16(data[26:28]))
+	extraLen := int(binary.LittleEndian.Uint16(data[28:30]))
+	id := binary.LittleEndian.AppendUint16(nil, extraContentHash)
+	field := 30 + nameLen + bytes.Index(data[30+nameLen:30+nameLen+extraLen], id)
+	if field < 30+nameLen {
+		t.Fatal("no SHA-256 extra field in the local header")
+	}
+
+	for _, tc := range []struct {
+		name  string
+		patch func(b []byte)
+		local string
+	}{
+		{"different digest", func(b []byte) { b[field+4] ^= 0xFF }, ""},
+		{"missing locally", func(b []byte) { b[field] ^= 0xFF }, "absent"},
+	} {
+		bad := append([]byte(nil), data...)
+		tc.patch(bad)
+		_, err := ListFilesStrict(bad, Limits{})
+		var mismatch *MismatchError
+		if !errors.As(err, &mismatch) || mismatch.Field != "sha256" || mismatch.Central == "absent" ||
+			(tc.local != "" && mismatch.Local != tc.local) {
+			t.Errorf("%s: got %v", tc.name, err)
+		}
+		if _, err := ListFiles(bad); err != nil {
+			t.Errorf("%s: non-strict ListFiles should ignore local headers: %v", tc.name, err)
+		}
+	}
+}
+
+func TestRecoverFiles(t *testing.T) {
+	comp := New(testVocab())
+	data := buildTestArchive(t, comp, "a.txt", "b.txt", "c.txt")
+	infos, _ := ListFiles(data)
+
+	// Cut the archive in the middle of the last entry's data
+	truncated := data[:infos[2].Offset+40]
+	if _, err := ListFiles(truncated); err == nil {
+		t.Fatal("ListFiles should fail without a central directory")
+	}
+
+	recovered, err := RecoverFiles(truncated, Limits{})
+	if err != nil {
+		t.Fatalf("RecoverFiles: %v", err)
+	}
+	if len(recovered) != 2 {
+		t.Fatalf("recovered %d entries, want 2", len(recovered))
+	}
+	for i, info := range recovered {
+		if info.Name != infos[i].Name || info.CRC32 != infos[i].CRC32 {
+			t.Errorf("entry %d: got %s/%08x, want %s/%08x", i, info.Name, info.CRC32, infos[i].Name, infos[i].CRC32)
+		}
+		content, err := comp.DecompressFile(truncated, info)
+		if err != nil {
+			t.Errorf("DecompressFile(%s): %v", info.Name, err)
+		}
+		want, _ := comp.DecompressFile(data, infos[i])
+		if !bytes.Equal(content, want) {
+			t.Errorf("%s: recovered content differs", info.Name)
+		}
+	}
+
+	// With the central directory intact, modes are taken from it
+	recovered, err = RecoverFiles(data, Limits{})
+	if err != nil || len(recovered) != 3 {
+		t.Fatalf("intact archive: %d entries, %v", len(recovered), err)
+	}
+	if recovered[0].Mode != infos[0].Mode {
+		t.Errorf("mode: got %v, want %v", recovered[0].Mode, infos[0].Mode)
+	}
+
+	if _, err := RecoverFiles(data, Limits{MaxEntries: 2}); !errors.Is(err, ErrTooManyEntries) {
+		t.Errorf("MaxEntries: got %v, want ErrTooManyEntries", err)
+	}
+}
+
+// streamedArchive rewrites the entries of data as a streaming writer would:
+// flag bit 3 set, zero CRC and sizes in the local header, and a data
+// descriptor, with or without its signature, after the data. The central
+// directory is left out.
+func streamedArchive(t *testing.T, data []byte, signed bool) []byte {
+	t.Helper()
+	infos, err := ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	var out []byte
+	for _, info := range infos {
+		off := int(info.Offset)
+		hdrLen := 30 + int(binary.LittleEndian.Uint16(data[off+26:])) + int(binary.LittleEndian.Uint16(data[off+28:]))
+		hdr := append([]byte(nil), data[off:off+hdrLen]...)
+		binary.LittleEndian.PutUint16(hdr[6:], binary.LittleEndian.Uint16(hdr[6:])|flagDataDesc)
+		copy(hdr[14:26], make([]byte, 12))
+		out = append(out, hdr...)
+		out = append(out, data[off+hdrLen:off+hdrLen+int(info.CompSize)]...)
+		if signed {
+			out = binary.LittleEndian.AppendUint32(out, sigDataDescriptor)
+		}
+		out = binary.LittleEndian.AppendUint32(out, info.CRC32)
+		out = binary.LittleEndian.AppendUint32(out, uint32(info.CompSize))
+		out = binary.LittleEndian.AppendUint32(out, uint32(info.Size))
+	}
+	return out
+}
+
+func TestRecoverDataDescriptors(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	contents := map[string][]byte{
+		"stored.txt":  []byte("stored\x00\x00\x00\x00 with zeros that look like a descriptor\n"),
+		"deflated.go": bytes.Repeat([]byte("func f() int { return 42 }\n"), 40),
+		"last.txt":    bytes.Repeat([]byte("the last entry runs to the end of the data\n"), 10),
+	}
+	archive.AddStore(contents["stored.txt"], "stored.txt", testTime(), 0644)
+	archive.Add(contents["deflated.go"], "deflated.go", testTime(), 0644)
+	archive.Add(contents["last.txt"], "last.txt", testTime(), 0644)
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+
+	for _, signed := range []bool{true, false} {
+		streamed := streamedArchive(t, data, signed)
+		recovered, err := RecoverFiles(streamed, Limits{})
+		if err != nil {
+			t.Fatalf("signed %v: RecoverFiles: %v", signed, err)
+		}
+		if len(recovered) != len(contents) {
+			t.Fatalf("signed %v: recovered %d entries, want %d", signed, len(recovered), len(contents))
+		}
+		for _, info := range recovered {
+			content, err := comp.DecompressFile(streamed, info)
+			if err != nil || !bytes.Equal(content, contents[info.Name]) {
+				t.Errorf("signed %v: %s: got %q, %v", signed, info.Name, content, err)
+			}
+		}
+	}
+}
+
+// === Archive Update Tests ===
+
+func TestOpenArchiveUnchanged(t *testing.T) {
+	comp := New(testVocab())
+	data := buildTestArchive(t, comp, "a.txt", "b.go", "c.md")
+
+	archive, err := OpenArchive(comp, data)
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+	rewritten, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	if !bytes.Equal(rewritten, data) {
+		t.Error("rewriting an unchanged archive should be byte-identical")
+	}
+}
+
+func TestOpenArchiveUpdate(t *testing.T) {
+	comp := New(testVocab())
+	data := buildTestArchive(t, comp, "a.txt", "b.txt", "c.txt")
+	before, _ := ListFiles(data)
+
+	archive, err := OpenArchive(comp, data)
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+
+	if info, ok := archive.Lookup("b.txt"); !ok || info.CRC32 != before[1].CRC32 {
+		t.Fatalf("Lookup(b.txt) = %v, %v", info, ok)
+	}
+	if _, ok := archive.Lookup("missing.txt"); ok {
+		t.Error("Lookup(missing.txt) should fail")
+	}
+
+	// Replace b.txt in place, delete a.txt, add d.txt
+	archive.Add([]byte("replacement"), "b.txt", testTime(), 0644)
+	if !archive.Remove("a.txt") {
+		t.Error("Remove(a.txt) should succeed")
+	}
+	if archive.Remove("a.txt") {
+		t.Error("second Remove(a.txt) should fail")
+	}
+	archive.Add([]byte("new file"), "d.txt", testTime(), 0644)
+
+	if got := strings.Join(archive.Names(), ","); got != "b.txt,c.txt,d.txt" {
+		t.Errorf("Names() = %s", got)
+	}
+
+	updated, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	if _, err := ListFilesStrict(updated, Limits{}); err != nil {
+		t.Fatalf("updated archive fails validation: %v", err)
+	}
+
+	files, err := comp.DecompressAll(updated)
+	if err != nil {
+		t.Fatalf("DecompressAll: %v", err)
+	}
+	want := map[string]string{
+		"b.txt": "replacement",
+		"c.txt": strings.Repeat("content of c.txt\n", 20),
+		"d.txt": "new file",
+	}
+	if len(files) != len(want) {
+		t.Errorf("got %d files, want %d", len(files), len(want))
+	}
+	for name, content := range want {
+		if string(files[name]) != content {
+			t.Errorf("%s: got %q", name, files[name])
+		}
+	}
+
+	// The untouched entry's compressed body is carried over verbatim
+	after, _ := ListFiles(updated)
+	oldBody := data[before[2].Offset : before[2].Offset+30+int64(len("c.txt"))+before[2].CompSize]
+	if !bytes.Contains(updated, oldBody) || after[1].CRC32 != before[2].CRC32 {
+		t.Error("c.txt was not copied verbatim")
+	}
+}
+
+func TestCopyRaw(t *testing.T) {
+	comp := New(testVocab())
+	first := buildTestArchive(t, comp, "a.txt", "b.txt")
+
+	// A BPELATE entry carries the 0x554E vocabulary record
+	goSrc := []byte("package main\n\nfunc main() {\n\tif err != nil {\n\t\treturn\n\t}\n}\n")
+	second, err := comp.CompressFileAs(goSrc, "main.go", testTime(), MethodBPELATE)
+	if err != nil {
+		t.Fatalf("compress failed: %v", err)
+	}
+
+	merged := NewArchive(comp)
+	firstFiles, _ := ListFiles(first)
+	for _, info := range firstFiles {
+		if info.Name == "b.txt" {
+			continue // filter
+		}
+		if err := merged.CopyRaw(first, info); err != nil {
+			t.Fatalf("CopyRaw(%s): %v", info.Name, err)
+		}
+	}
+	secondFiles, _ := ListFiles(second)
+	if err := merged.CopyRawAs(second, secondFiles[0], "cmd/main.go"); err != nil {
+		t.Fatalf("CopyRawAs: %v", err)
+	}
+
+	data, err := merged.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("merged archive fails validation: %v", err)
+	}
+	if len(files) != 2 || files[0].Name != "a.txt" || files[1].Name != "cmd/main.go" {
+		t.Fatalf("unexpected entries: %v", files)
+	}
+
+	copied := files[1]
+	if copied.Method != MethodBPELATE || copied.Vocab != secondFiles[0].Vocab || copied.CRC32 != secondFiles[0].CRC32 {
+		t.Errorf("copied entry metadata differs: %+v", copied)
+	}
+	if !bytes.Equal(copied.centralExtra, secondFiles[0].centralExtra) {
+		t.Error("extra field not copied byte-for-byte")
+	}
+
+	content, err := comp.DecompressFile(data, copied)
+	if err != nil || !bytes.Equal(content, goSrc) {
+		t.Errorf("copied entry: %v", err)
+	}
+
+	// An entry whose local header disagrees with the central directory is refused
+	bad := append([]byte(nil), first...)
+	bad[14] ^= 0xFF
+	if err := NewArchive(comp).CopyRaw(bad, firstFiles[0]); !errors.Is(err, ErrInconsistent) {
+		t.Errorf("CopyRaw of inconsistent entry: got %v, want ErrInconsistent", err)
+	}
+}
+
+// === Encryption Tests ===
+
+func TestPBKDF2SHA1(t *testing.T) {
+	// RFC 6070 test vectors
+	tests := []struct {
+		password, salt string
+		iter, keyLen   int
+		want           string
+	}{
+		{"password", "salt", 1, 20, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"password", "salt", 4096, 20, "4b007901b765489abead49d926f721d065a429c1"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 25,
+			"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+	}
+	for _, tc := range tests {
+		got := hex.EncodeToString(pbkdf2SHA1([]byte(tc.password), []byte(tc.salt), tc.iter, tc.keyLen))
+		if got != tc.want {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s, want %s", tc.password, tc.salt, tc.iter, got, tc.want)
+		}
+	}
+}
+
+func TestArchiveAESEncryption(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.SetPassword("correct horse")
+
+	goSrc := bytes.Repeat([]byte("func main() {\n\tif err != nil {\n\t\treturn err\n\t}\n}\n"), 20)
+	archive.AddDirectory("src", testTime(), 0755)
+	if err := archive.Add(goSrc, "src/main.go", testTime(), 0644); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	archive.AddStore([]byte("stored secret"), "src/secret.txt", testTime(), 0600)
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	if bytes.Contains(data, []byte("stored secret")) {
+		t.Fatal("plaintext leaked into archive")
+	}
+
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("ListFilesStrict: %v", err)
+	}
+	if files[0].Encryption != EncryptionNone {
+		t.Errorf("directory should not be encrypted, got %v", files[0].Encryption)
+	}
+	for _, info := range files[1:] {
+		if info.Encryption != EncryptionAES256 || info.CRC32 != 0 {
+			t.Errorf("%s: encryption %v, CRC %08x; want AES-256 with no CRC (AE-2)", info.Name, info.Encryption, info.CRC32)
+		}
+	}
+	if files[2].Method != MethodStore {
+		t.Errorf("real method should be reported, got %v", files[2].Method)
+	}
+
+	// Missing and wrong passwords
+	reader := New(testVocab())
+	if _, err := reader.DecompressFile(data, files[1]); err != ErrPassword {
+		t.Errorf("no password: got %v, want ErrPassword", err)
+	}
+	reader.SetPassword("wrong")
+	if _, err := reader.DecompressFile(data, files[1]); err != ErrPassword {
+		t.Errorf("wrong password: got %v, want ErrPassword", err)
+	}
+
+	reader.SetPassword("correct horse")
+	content, err := reader.DecompressFile(data, files[1])
+	if err != nil || !bytes.Equal(content, goSrc) {
+		t.Fatalf("main.go: %v", err)
+	}
+	content, err = reader.DecompressFile(data, files[2])
+	if err != nil || string(content) != "stored secret" {
+		t.Fatalf("secret.txt: %q, %v", content, err)
+	}
+
+	// Tampering with the ciphertext fails authentication
+	tampered := append([]byte(nil), data...)
+	hdr, _ := parseLocalHeader(tampered, int(files[2].Offset))
+	tampered[hdr.dataOffset+16+2] ^= 1
+	if _, err := reader.DecompressFile(tampered, files[2]); err != ErrAuthentication {
+		t.Errorf("tampered: got %v, want ErrAuthentication", err)
+	}
+
+	// Raw copies keep the encryption
+	copied := NewArchive(comp)
+	if err := copied.CopyRaw(data, files[1]); err != nil {
+		t.Fatalf("CopyRaw: %v", err)
+	}
+	copiedData, _ := copied.Bytes()
+	copiedFiles, err := ListFilesStrict(copiedData, Limits{})
+	if err != nil || copiedFiles[0].Encryption != EncryptionAES256 {
+		t.Fatalf("copied entry: %v, %v", copiedFiles, err)
+	}
+	if content, err := reader.DecompressFile(copiedData, copiedFiles[0]); err != nil || !bytes.Equal(content, goSrc) {
+		t.Errorf("copied entry content: %v", err)
+	}
+}
+
+func TestZipCryptoRead(t *testing.T) {
+	// Created by Info-ZIP: zip -P hunter2 -0 zc0.zip s.txt
+	data, _ := hex.DecodeString("" +
+		"504b03040a0009000000c46e525d3fd1db05390000002d00000005001c00732e" +
+		"74787455540900037fcfd46a7fcfd46a75780b0001040000000004000000004a" +
+		"5afa75860a41b5ce00244107ff8182c54ac5a01438da03a6c57ff47013e765d7" +
+		"5f8be84470706573111e1c55308bb958cf728e9acdd768fd504b07083fd1db05" +
+		"390000002d000000504b01021e030a0009000000c46e525d3fd1db0539000000" +
+		"2d000000050018000000000000000000a48100000000732e7478745554050003" +
+		"7fcfd46a75780b000104000000000400000000504b050600000000010001004b" +
+		"000000880000000000")
+
+	files, err := ListFiles(data)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if files[0].Encryption != EncryptionZipCrypto {
+		t.Fatalf("encryption = %v, want ZipCrypto", files[0].Encryption)
+	}
+
+	comp := New(testVocab())
+	comp.SetPassword("wrong")
+	if _, err := comp.DecompressFile(data, files[0]); err != ErrPassword {
+		t.Errorf("wrong password: got %v, want ErrPassword", err)
+	}
+
+	comp.SetPassword("hunter2")
+	content, err := comp.DecompressFile(data, files[0])
+	if err != nil {
+		t.Fatalf("DecompressFile: %v", err)
+	}
+	if want := "secret payload secret payload secret payload\n"; string(content) != want {
+		t.Errorf("got %q, want %q", content, want)
+	}
+}
+
+// === Signature Tests ===
+
+func TestArchiveSignature(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(nil)
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.Sign(key)
+	archive.Add(bytes.Repeat([]byte("package main\n\nfunc main() {}\n"), 10), "main.go", testTime(), 0644)
+	archive.AddStore([]byte("release notes"), "NOTES", testTime(), 0644)
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+
+	signer, err := VerifySignature(data, pub)
+	if err != nil {
+		t.Fatalf("VerifySignature: %v", err)
+	}
+	if !signer.Equal(pub) {
+		t.Error("wrong signer key returned")
+	}
+	if _, err := VerifySignature(data, nil); err != nil {
+		t.Errorf("VerifySignature without trusted key: %v", err)
+	}
+	if _, err := VerifySignature(data, otherPub); err != ErrUntrustedKey {
+		t.Errorf("other key: got %v, want ErrUntrustedKey", err)
+	}
+
+	// Signed archives still list and extract normally
+	if _, err := comp.DecompressAll(data); err != nil {
+		t.Fatalf("DecompressAll: %v", err)
+	}
+
+	// Changing entry data, the central directory or the key breaks it
+	files, _ := ListFiles(data)
+	hdr, _ := parseLocalHeader(data, int(files[1].Offset))
+	for name, pos := range map[string]int{
+		"data":    hdr.dataOffset,
+		"central": bytes.LastIndex(data, []byte("NOTES")),
+		"key":     findEndCentralDir(data) + 22 + len(sigCommentPrefix),
+	} {
+		tampered := append([]byte(nil), data...)
+		tampered[pos] ^= 0x01
+		if _, err := VerifySignature(tampered, nil); err != ErrSignature && err != ErrNotSigned {
+			t.Errorf("tampered %s: got %v, want ErrSignature", name, err)
+		}
+	}
+
+	unsigned := NewArchive(comp)
+	unsigned.AddStore([]byte("x"), "x", testTime(), 0644)
+	unsignedData, _ := unsigned.Bytes()
+	if _, err := VerifySignature(unsignedData, nil); err != ErrNotSigned {
+		t.Errorf("unsigned: got %v, want ErrNotSigned", err)
+	}
+}
+
+// === Content Hash Tests ===
+
+func TestContentHash(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.SetSHA256(true)
+
+	content := bytes.Repeat([]byte("def main():\n    print('hello')\n"), 10)
+	archive.Add(content, "main.py", testTime(), 0644)
+	archive.AddDirectory("docs", testTime(), 0755)
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("ListFilesStrict: %v", err)
+	}
+	want := sha256.Sum256(content)
+	if !bytes.Equal(files[0].SHA256, want[:]) {
+		t.Errorf("SHA256 = %x, want %x", files[0].SHA256, want)
+	}
+	if files[1].SHA256 != nil {
+		t.Errorf("directory should have no hash")
+	}
+	if _, err := comp.DecompressFile(data, files[0]); err != nil {
+		t.Fatalf("DecompressFile: %v", err)
+	}
+
+	// Raw copies keep the hash
+	copied, _ := OpenArchive(comp, data)
+	if info, _ := copied.Lookup("main.py"); !bytes.Equal(info.SHA256, want[:]) {
+		t.Errorf("raw copy lost the hash")
+	}
+
+	// A wrong digest is caught even though the CRC-32 still matches
+	tampered := bytes.ReplaceAll(data, want[:], make([]byte, sha256.Size))
+	files, _ = ListFiles(tampered)
+	_, err = comp.DecompressFile(tampered, files[0])
+	var sumErr *ChecksumError
+	if !errors.As(err, &sumErr) || sumErr.WantSHA256 == nil || !errors.Is(err, ErrChecksum) {
+		t.Fatalf("got %v, want SHA-256 ChecksumError", err)
+	}
+	if !strings.Contains(err.Error(), "bad SHA-256") {
+		t.Errorf("error message: %v", err)
+	}
+
+	// Not stored unless enabled, and never for encrypted entries
+	for _, password := range []string{"", "secret"} {
+		plain := NewArchive(comp)
+		plain.SetSHA256(password != "")
+		plain.SetPassword(password)
+		plain.AddStore(content, "x", testTime(), 0644)
+		out, _ := plain.Bytes()
+		files, _ := ListFiles(out)
+		if files[0].SHA256 != nil {
+			t.Errorf("password %q: unexpected hash", password)
+		}
+	}
+}
+
+// === Comment Tests ===
+
+func TestComments(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.AddStore([]byte("one"), "one.txt", testTime(), 0644)
+	archive.AddStore([]byte("two"), "two.txt", testTime(), 0644)
+	if err := archive.SetComment("Release 1.0\nBuilt by CI"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := archive.SetEntryComment("two.txt", "second file – UTF-8"); !ok {
+		t.Fatal("SetEntryComment: entry not found")
+	}
+	if ok, _ := archive.SetEntryComment("missing", "x"); ok {
+		t.Error("SetEntryComment found a missing entry")
+	}
+	if err := archive.SetComment(strings.Repeat("x", 0x10000)); err != ErrCommentTooLong {
+		t.Errorf("long comment: got %v, want ErrCommentTooLong", err)
+	}
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	if comment, err := ReadComment(data); err != nil || comment != "Release 1.0\nBuilt by CI" {
+		t.Errorf("ReadComment = %q, %v", comment, err)
+	}
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("ListFilesStrict: %v", err)
+	}
+	if files[0].Comment != "" || files[1].Comment != "second file – UTF-8" {
+		t.Errorf("file comments = %q, %q", files[0].Comment, files[1].Comment)
+	}
+
+	// Rewriting keeps both kinds of comment
+	reopened, err := OpenArchive(comp, data)
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+	rewritten, _ := reopened.Bytes()
+	if !bytes.Equal(rewritten, data) {
+		t.Error("rewritten archive differs")
+	}
+
+	// The signature follows the comment and covers it
+	_, key, _ := ed25519.GenerateKey(nil)
+	reopened.Sign(key)
+	signed, err := reopened.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	if comment, _ := ReadComment(signed); comment != "Release 1.0\nBuilt by CI" {
+		t.Errorf("ReadComment of signed archive = %q", comment)
+	}
+	if _, err := VerifySignature(signed, nil); err != nil {
+		t.Fatalf("VerifySignature: %v", err)
+	}
+	tampered := bytes.Replace(signed, []byte("Release 1.0"), []byte("Release 6.6"), 1)
+	if _, err := VerifySignature(tampered, nil); err != ErrSignature {
+		t.Errorf("tampered comment: got %v, want ErrSignature", err)
+	}
+}
+
+// === Unix Attribute Tests ===
+
+func TestUnixAttrs(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	archive.AddStore([]byte("config"), "app.conf", testTime(), 0640)
+	archive.AddStore([]byte("plain"), "plain.txt", testTime(), 0644)
+
+	attrs := UnixAttrs{
+		Atime:    testTime().Add(time.Hour),
+		Ctime:    testTime().Add(2 * time.Hour),
+		HasOwner: true,
+		UID:      1001,
+		GID:      100,
+		Xattrs: map[string][]byte{
+			"user.origin":             []byte("deploy"),
+			"system.posix_acl_access": {2, 0, 0, 0, 1, 0, 6, 0},
+		},
+	}
+	if ok, err := archive.SetUnixAttrs("app.conf", attrs); !ok || err != nil {
+		t.Fatalf("SetUnixAttrs: %v, %v", ok, err)
+	}
+	huge := UnixAttrs{Xattrs: map[string][]byte{"user.big": make([]byte, 0xFFFF)}}
+	if _, err := archive.SetUnixAttrs("plain.txt", huge); err != ErrExtraTooLarge {
+		t.Errorf("huge xattr: got %v, want ErrExtraTooLarge", err)
+	}
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("ListFilesStrict: %v", err)
+	}
+
+	got := files[0].Unix
+	if !got.HasOwner || got.UID != 1001 || got.GID != 100 {
+		t.Errorf("owner = %v %d:%d", got.HasOwner, got.UID, got.GID)
+	}
+	if !got.Atime.Equal(attrs.Atime) || !got.Ctime.Equal(attrs.Ctime) {
+		t.Errorf("atime/ctime = %v / %v", got.Atime, got.Ctime)
+	}
+	if !files[0].ModTime.Equal(testTime()) {
+		t.Errorf("mtime = %v", files[0].ModTime)
+	}
+	if len(got.Xattrs) != 2 || string(got.Xattrs["user.origin"]) != "deploy" {
+		t.Errorf("xattrs = %v", got.Xattrs)
+	}
+	if files[1].Unix.HasOwner || files[1].Unix.Xattrs != nil || !files[1].Unix.Atime.IsZero() {
+		t.Errorf("plain.txt has unexpected attributes: %+v", files[1].Unix)
+	}
+
+	// Local header only: times and owner, as recovery sees them
+	recovered, err := RecoverFiles(data, Limits{})
+	if err != nil || !recovered[0].Unix.HasOwner || !recovered[0].Unix.Atime.Equal(attrs.Atime) {
+		t.Errorf("RecoverFiles: %+v, %v", recovered, err)
+	}
+
+	// Info-ZIP writes the owner with the same layout
+	if uid, gid, ok := parseUnixOwner([]byte{0x75, 0x78, 0x0b, 0x00, 1, 4, 0xe8, 3, 0, 0, 4, 0x64, 0, 0, 0}); !ok || uid != 1000 || gid != 100 {
+		t.Errorf("parseUnixOwner = %d, %d, %v", uid, gid, ok)
+	}
+}
+
+// === Hard Link and Special File Tests ===
+
+func TestHardLinksAndSpecialFiles(t *testing.T) {
+	comp := New(testVocab())
+	archive := NewArchive(comp)
+	content := []byte("#!/bin/sh\necho shared content\n")
+	archive.AddStore(content, "bin/tool", testTime(), 0755)
+
+	if err := archive.AddHardLink("bin/tool-alias", "bin/tool", testTime(), 0755); err != nil {
+		t.Fatalf("AddHardLink: %v", err)
+	}
+	if err := archive.AddHardLink("bin/dangling", "bin/missing", testTime(), 0644); err != ErrLinkTarget {
+		t.Errorf("missing target: got %v, want ErrLinkTarget", err)
+	}
+	if err := archive.AddSpecial("dev/null", testTime(), os.ModeDevice|os.ModeCharDevice|0666, 1, 3); err != nil {
+		t.Fatalf("AddSpecial device: %v", err)
+	}
+	if err := archive.AddSpecial("run/pipe", testTime(), os.ModeNamedPipe|0600, 0, 0); err != nil {
+		t.Fatalf("AddSpecial fifo: %v", err)
+	}
+	if err := archive.AddSpecial("plain", testTime(), 0644, 0, 0); err != ErrUnsupported {
+		t.Errorf("regular file: got %v, want ErrUnsupported", err)
+	}
+
+	data, err := archive.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes(): %v", err)
+	}
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		t.Fatalf("ListFilesStrict: %v", err)
+	}
+
+	link := files[1]
+	if link.HardLink != "bin/tool" || link.Size != 0 || link.Mode != 0755 {
+		t.Errorf("hard link = %q, size %d, mode %v", link.HardLink, link.Size, link.Mode)
+	}
+	dev := files[2]
+	if dev.Mode&os.ModeCharDevice == 0 || dev.DevMajor != 1 || dev.DevMinor != 3 {
+		t.Errorf("device = %v %d:%d", dev.Mode, dev.DevMajor, dev.DevMinor)
+	}
+	if files[3].Mode&os.ModeNamedPipe == 0 || files[3].Mode.Perm() != 0600 {
+		t.Errorf("fifo mode = %v", files[3].Mode)
+	}
+
+	// The shared content is stored once
+	if n := bytes.Count(data, content); n != 1 {
+		t.Errorf("content stored %d times", n)
+	}
+
+	// Raw copies keep the link and the device number
+	reopened, _ := OpenArchive(comp, data)
+	if info, _ := reopened.Lookup("dev/null"); info.DevMajor != 1 || info.DevMinor != 3 {
+		t.Errorf("Lookup device = %d:%d", info.DevMajor, info.DevMinor)
+	}
+	if info, _ := reopened.Lookup("bin/tool-alias"); info.HardLink != "bin/tool" {
+		t.Errorf("Lookup hard link = %q", info.HardLink)
+	}
+}
+
+func TestReproducibleArchive(t *testing.T) {
+	epoch := time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)
+	text := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200))
+	code := []byte(strings.Repeat("func main() {\n\tfmt.Println(\"hello\")\n}\n", 100))
+	large := bytes.Repeat([]byte("output must not depend on the order of Add calls\n"), 40000)
+
+	build := func(reverse bool, modTime time.Time, zone *time.Location) []byte {
+		archive := NewArchive(New(testVocab()))
+		archive.SetReproducible(epoch)
+		adds := []func(){
+			func() { archive.AddDirectory("src", modTime, 0700) },
+			func() { archive.Add(code, "src/main.go", modTime.In(zone), 0600) },
+			func() { archive.Add(text, "README", modTime, 0664) },
+			func() { archive.Add(large, "large.txt", modTime, 0744) },
+			func() { archive.AddSymlink("link", "README", modTime, 0755) },
+		}
+		if reverse {
+			for i := len(adds) - 1; i >= 0; i-- {
+				adds[i]()
+			}
+		} else {
+			for _, add := range adds {
+				add()
+			}
+		}
+		archive.SetUnixAttrs("README", UnixAttrs{HasOwner: true, UID: 1000, GID: 1000, Atime: modTime})
+		data, err := archive.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes(): %v", err)
+		}
+		return data
+	}
+
+	first := build(false, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+	for i := 0; i < 3; i++ {
+		again := build(i%2 == 0, time.Now().Add(time.Duration(i)*time.Hour), time.FixedZone("X", 5*3600))
+		if !bytes.Equal(first, again) {
+			t.Fatalf("run %d: archive differs", i)
+		}
+	}
+
+	files, err := ListFiles(first)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	wantNames := []string{"README", "large.txt", "link", "src/", "src/main.go"}
+	wantModes := []os.FileMode{0644, 0755, os.ModeSymlink | 0777, os.ModeDir | 0755, 0644}
+	for i, f := range files {
+		if f.Name != wantNames[i] || f.Mode != wantModes[i] {
+			t.Errorf("entry %d: %s %v, want %s %v", i, f.Name, f.Mode, wantNames[i], wantModes[i])
+		}
+		if !f.ModTime.Equal(epoch) {
+			t.Errorf("%s: mtime %v, want %v", f.Name, f.ModTime, epoch)
+		}
+		if f.Unix.HasOwner || !f.Unix.Atime.IsZero() {
+			t.Errorf("%s: unix attributes stored: %+v", f.Name, f.Unix)
+		}
+	}
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	if got, err := SourceDateEpoch(); err != nil || !got.Equal(DefaultEpoch) {
+		t.Errorf("unset: got %v, %v", got, err)
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	if got, err := SourceDateEpoch(); err != nil || got.Unix() != 1700000000 || got.Location() != time.UTC {
+		t.Errorf("set: got %v, %v", got, err)
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, err := SourceDateEpoch(); err == nil {
+		t.Error("invalid value accepted")
 	}
 }
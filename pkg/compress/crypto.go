@@ -0,0 +1,320 @@
+package compress
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// Encryption identifies how an entry's data is encrypted.
+type Encryption uint8
+
+const (
+	EncryptionNone      Encryption = 0
+	EncryptionZipCrypto Encryption = 1 // Traditional PKWARE (read-only)
+	EncryptionAES128    Encryption = 2 // WinZip AES, strength 1
+	EncryptionAES192    Encryption = 3 // WinZip AES, strength 2
+	EncryptionAES256    Encryption = 4 // WinZip AES, strength 3
+)
+
+func (e Encryption) String() string {
+	names := []string{"none", "ZipCrypto", "AES-128", "AES-192", "AES-256"}
+	if int(e) < len(names) {
+		return names[e]
+	}
+	return "unknown"
+}
+
+// Encryption constants
+const (
+	flagEncrypted  = 0x0001 // Bit 0: entry is encrypted
+	methodWinZipAE = 99     // method field of WinZip AES entries
+	extraWinZipAES = 0x9901 // WinZip AES extra field
+
+	aesVersionAE2    = 0x0002 // AE-2: CRC not stored, HMAC only
+	aesVendorID      = 0x4541 // "AE"
+	aesStrength256   = 3
+	aesPBKDF2Rounds  = 1000
+	aesVerifierLen   = 2
+	aesAuthCodeLen   = 10
+	zipCryptoHdrLen  = 12
+	zipCryptoKeyInit = 0x12345678
+)
+
+// Encryption errors
+var (
+	ErrPassword       = errors.New("compress: incorrect or missing password")
+	ErrAuthentication = errors.New("compress: encrypted data failed authentication")
+)
+
+// SetPassword sets the password used to decrypt encrypted entries.
+func (c *Compressor) SetPassword(password string) {
+	c.password = password
+}
+
+// SetPassword makes the archive encrypt entries added from now on with
+// WinZip AES-256 (AE-2). Entries are compressed first, then encrypted, so
+// Bpelate and the other methods work unchanged underneath. Directories are
+// not encrypted, and entries copied with CopyRaw keep their own encryption.
+// An empty password turns encryption off.
+func (a *Archive) SetPassword(password string) {
+	a.password = password
+}
+
+// seal encrypts an entry's compressed data if the archive has a password.
+func (a *Archive) seal(entry *archiveEntry) error {
+	if a.password == "" {
+		return nil
+	}
+
+	sealed, err := encryptAES(entry.compressed, a.password, aesStrength256)
+	if err != nil {
+		return err
+	}
+	if len(sealed) > 0xFFFFFFFF {
+		return ErrFileTooLarge
+	}
+
+	entry.compressed = sealed
+	entry.encryption = EncryptionAES256
+	entry.crc = 0 // AE-2 relies on the HMAC instead
+	return nil
+}
+
+// makeAESExtra creates the WinZip AES extra field (0x9901).
+// Format: 2 bytes ID + 2 bytes size + version(2) + vendor(2) + strength(1) + method(2)
+func makeAESExtra(method Method, strength byte) []byte {
+	extra := make([]byte, 11)
+	binary.LittleEndian.PutUint16(extra[0:2], extraWinZipAES)
+	binary.LittleEndian.PutUint16(extra[2:4], 7)
+	binary.LittleEndian.PutUint16(extra[4:6], aesVersionAE2)
+	binary.LittleEndian.PutUint16(extra[6:8], aesVendorID)
+	extra[8] = strength
+	binary.LittleEndian.PutUint16(extra[9:11], uint16(method))
+	return extra
+}
+
+// parseAESExtra extracts the WinZip AES extra field (0x9901).
+func parseAESExtra(extra []byte) (version uint16, strength byte, method Method, ok bool) {
+	field, ok := findExtra(extra, extraWinZipAES)
+	if !ok || len(field) < 7 {
+		return 0, 0, 0, false
+	}
+	return binary.LittleEndian.Uint16(field[0:2]), field[4],
+		Method(binary.LittleEndian.Uint16(field[5:7])), true
+}
+
+// resolveEncryption maps a header's method and flags to the real
+// compression method and the encryption in use.
+func resolveEncryption(method Method, flags uint16, extra []byte) (Method, Encryption, uint16) {
+	if flags&flagEncrypted == 0 {
+		return method, EncryptionNone, 0
+	}
+	if method == methodWinZipAE {
+		if version, strength, actual, ok := parseAESExtra(extra); ok && strength >= 1 && strength <= 3 {
+			return actual, EncryptionAES128 + Encryption(strength-1), version
+		}
+	}
+	return method, EncryptionZipCrypto, 0
+}
+
+// aesKeyLen returns the AES key length in bytes for a WinZip strength.
+func aesKeyLen(strength byte) int {
+	return 8 + 8*int(strength) // 16, 24 or 32
+}
+
+// deriveAESKeys derives the encryption key, HMAC key and password verifier.
+func deriveAESKeys(password string, salt []byte, keyLen int) (encKey, authKey, verifier []byte) {
+	dk := pbkdf2SHA1([]byte(password), salt, aesPBKDF2Rounds, 2*keyLen+aesVerifierLen)
+	return dk[:keyLen], dk[keyLen : 2*keyLen], dk[2*keyLen:]
+}
+
+// encryptAES encrypts data in WinZip AES format:
+// salt + password verifier + ciphertext + 10-byte HMAC-SHA1.
+func encryptAES(data []byte, password string, strength byte) ([]byte, error) {
+	keyLen := aesKeyLen(strength)
+	salt := make([]byte, keyLen/2)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	encKey, authKey, verifier := deriveAESKeys(password, salt, keyLen)
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(salt)+aesVerifierLen+len(data)+aesAuthCodeLen)
+	out = append(out, salt...)
+	out = append(out, verifier...)
+	ciphertext := make([]byte, len(data))
+	winzipCTR(block, ciphertext, data)
+	out = append(out, ciphertext...)
+
+	mac := hmac.New(sha1.New, authKey)
+	mac.Write(ciphertext)
+	out = append(out, mac.Sum(nil)[:aesAuthCodeLen]...)
+	return out, nil
+}
+
+// decryptAES reverses encryptAES, checking the password verifier and HMAC.
+func decryptAES(data []byte, password string, strength byte) ([]byte, error) {
+	keyLen := aesKeyLen(strength)
+	saltLen := keyLen / 2
+	if len(data) < saltLen+aesVerifierLen+aesAuthCodeLen {
+		return nil, ErrCorrupted
+	}
+
+	salt := data[:saltLen]
+	encKey, authKey, verifier := deriveAESKeys(password, salt, keyLen)
+	if subtle.ConstantTimeCompare(verifier, data[saltLen:saltLen+aesVerifierLen]) != 1 {
+		return nil, ErrPassword
+	}
+
+	ciphertext := data[saltLen+aesVerifierLen : len(data)-aesAuthCodeLen]
+	mac := hmac.New(sha1.New, authKey)
+	mac.Write(ciphertext)
+	if !hmac.Equal(mac.Sum(nil)[:aesAuthCodeLen], data[len(data)-aesAuthCodeLen:]) {
+		return nil, ErrAuthentication
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(ciphertext))
+	winzipCTR(block, plain, ciphertext)
+	return plain, nil
+}
+
+// winzipCTR applies AES in counter mode as WinZip does: the 16-byte counter
+// is little-endian and starts at 1, unlike cipher.NewCTR.
+func winzipCTR(block cipher.Block, dst, src []byte) {
+	var counter, stream [aes.BlockSize]byte
+	for i := 0; i < len(src); i += aes.BlockSize {
+		for j := range counter {
+			counter[j]++
+			if counter[j] != 0 {
+				break
+			}
+		}
+		block.Encrypt(stream[:], counter[:])
+
+		end := i + aes.BlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		for j := i; j < end; j++ {
+			dst[j] = src[j] ^ stream[j-i]
+		}
+	}
+}
+
+// pbkdf2SHA1 implements PBKDF2 (RFC 8018) with HMAC-SHA1.
+func pbkdf2SHA1(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha1.New, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = u[:0]
+			u = prf.Sum(u)
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
+
+// zipCryptoKeys is the traditional PKWARE stream cipher state.
+type zipCryptoKeys [3]uint32
+
+func newZipCryptoKeys(password string) *zipCryptoKeys {
+	k := &zipCryptoKeys{zipCryptoKeyInit, 0x23456789, 0x34567890}
+	for i := 0; i < len(password); i++ {
+		k.update(password[i])
+	}
+	return k
+}
+
+func (k *zipCryptoKeys) update(b byte) {
+	k[0] = crc32.IEEETable[byte(k[0])^b] ^ (k[0] >> 8)
+	k[1] = (k[1]+(k[0]&0xFF))*134775813 + 1
+	k[2] = crc32.IEEETable[byte(k[2])^byte(k[1]>>24)] ^ (k[2] >> 8)
+}
+
+func (k *zipCryptoKeys) streamByte() byte {
+	t := uint16(k[2] | 2)
+	return byte((uint32(t) * uint32(t^1)) >> 8)
+}
+
+func (k *zipCryptoKeys) decrypt(b byte) byte {
+	p := b ^ k.streamByte()
+	k.update(p)
+	return p
+}
+
+// decryptZipCrypto decrypts traditional PKWARE encrypted data. The last
+// byte of the 12-byte header must equal check (the high byte of the CRC, or
+// of the DOS time when a data descriptor is used).
+func decryptZipCrypto(data []byte, password string, check byte) ([]byte, error) {
+	if len(data) < zipCryptoHdrLen {
+		return nil, ErrCorrupted
+	}
+
+	keys := newZipCryptoKeys(password)
+	var last byte
+	for _, b := range data[:zipCryptoHdrLen] {
+		last = keys.decrypt(b)
+	}
+	if last != check {
+		return nil, ErrPassword
+	}
+
+	plain := make([]byte, len(data)-zipCryptoHdrLen)
+	for i, b := range data[zipCryptoHdrLen:] {
+		plain[i] = keys.decrypt(b)
+	}
+	return plain, nil
+}
+
+// decryptEntry decrypts an entry's data with the compressor's password.
+func (c *Compressor) decryptEntry(data []byte, info *FileInfo) ([]byte, error) {
+	if c.password == "" {
+		return nil, ErrPassword
+	}
+
+	switch info.Encryption {
+	case EncryptionZipCrypto:
+		check := byte(info.CRC32 >> 24)
+		if info.flags&flagDataDesc != 0 {
+			check = byte(info.dosTime >> 8)
+		}
+		return decryptZipCrypto(data, c.password, check)
+	case EncryptionAES128, EncryptionAES192, EncryptionAES256:
+		return decryptAES(data, c.password, byte(info.Encryption-EncryptionAES128+1))
+	default:
+		return nil, ErrUnsupported
+	}
+}
@@ -0,0 +1,107 @@
+package compress
+
+import "encoding/binary"
+
+// Exelate compresses x86 and x86-64 machine code. Calls and jumps (E8 and
+// E9 opcodes) carry a 32-bit displacement relative to the next
+// instruction, so calls to the same function look different everywhere
+// they appear. The filter rewrites each displacement as an absolute target
+// before DEFLATE, turning those calls into repeated byte strings, and the
+// decompressor converts them back. As in the BCJ filter of xz, only
+// displacements within 16 MiB (top byte 0x00 or 0xFF) are converted, and
+// arithmetic is modulo 2^25 with sign extension, so converted values keep
+// that form and the decoder finds exactly the positions the encoder did.
+
+// x86Filter converts E8/E9 displacements in place: to absolute targets if
+// encode, back to displacements otherwise.
+func x86Filter(data []byte, encode bool) {
+	for i := 0; i+5 <= len(data); {
+		if data[i] != 0xe8 && data[i] != 0xe9 {
+			i++
+			continue
+		}
+		// The operand is skipped whether converted or not, so that
+		// converting one operand never changes what the decoder sees at
+		// another opcode
+		top := data[i+4]
+		if top != 0x00 && top != 0xff {
+			i += 5
+			continue
+		}
+
+		v := binary.LittleEndian.Uint32(data[i+1:])
+		pos := uint32(i + 5)
+		if encode {
+			v += pos
+		} else {
+			v -= pos
+		}
+		// Sign-extend from 25 bits
+		v &= 0x01ffffff
+		if v&0x01000000 != 0 {
+			v |= 0xff000000
+		}
+		binary.LittleEndian.PutUint32(data[i+1:], v)
+		i += 5
+	}
+}
+
+// isX86Executable reports whether data is an ELF, PE or Mach-O file for
+// x86 or x86-64, the machines x86Filter is designed for.
+func isX86Executable(data []byte) bool {
+	switch {
+	case len(data) >= 20 && string(data[:4]) == "\x7fELF":
+		// e_machine, in the byte order given by EI_DATA
+		var machine uint16
+		if data[5] == 2 {
+			machine = binary.BigEndian.Uint16(data[18:])
+		} else {
+			machine = binary.LittleEndian.Uint16(data[18:])
+		}
+		return machine == 3 || machine == 62 // EM_386, EM_X86_64
+
+	case len(data) >= 0x40 && string(data[:2]) == "MZ":
+		off := int(binary.LittleEndian.Uint32(data[0x3c:]))
+		if off < 0x40 || off > len(data)-6 || string(data[off:off+4]) != "PE\x00\x00" {
+			return false
+		}
+		machine := binary.LittleEndian.Uint16(data[off+4:])
+		return machine == 0x14c || machine == 0x8664 // i386, AMD64
+
+	case len(data) >= 8 && (string(data[:4]) == "\xce\xfa\xed\xfe" || string(data[:4]) == "\xcf\xfa\xed\xfe"):
+		// Little-endian Mach-O; CPU_TYPE_X86 with or without the 64-bit flag
+		return binary.LittleEndian.Uint32(data[4:])&^0x01000000 == 7
+	}
+	return false
+}
+
+// compressEXELATE compresses machine code with the x86 filter and DEFLATE.
+func (c *Compressor) compressEXELATE(data []byte) ([]byte, error) {
+	filtered := append([]byte(nil), data...)
+	x86Filter(filtered, true)
+	return c.compressDEFLATE(filtered)
+}
+
+// decompressEXELATEMax inflates Exelate data within max bytes (-1: no
+// limit) and undoes the x86 filter.
+func (c *Compressor) decompressEXELATEMax(data []byte, max int64) ([]byte, error) {
+	content, err := c.decompressDEFLATEMax(data, max)
+	if err != nil {
+		return nil, err
+	}
+	x86Filter(content, false)
+	return content, nil
+}
+
+// compressExecutableBest compresses an executable with DEFLATE and, for
+// x86 machine code, Exelate.
+func (c *Compressor) compressExecutableBest(data []byte) *choice {
+	ch := &choice{}
+	compressed, err := c.compressDEFLATE(data)
+	ch.try(MethodDEFLATE, ProgLangNone, compressed, err)
+	if isX86Executable(data) {
+		compressed, err = c.compressEXELATE(data)
+		ch.try(MethodEXELATE, ProgLangNone, compressed, err)
+	}
+	return ch
+}
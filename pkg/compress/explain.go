@@ -0,0 +1,170 @@
+package compress
+
+import (
+	"sort"
+
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+// Candidate is one way of compressing an entry and the size it gives.
+type Candidate struct {
+	Method Method
+	Vocab  ProgLang // vocabulary of Bpelate and Unzlate; ProgLangNone is the default (text) one
+	Size   int64    // compressed size in bytes
+	Err    error    // why the method could not compress the entry
+	Auto   bool     // tried by automatic method selection
+}
+
+// Explanation reports how Archive.Add compresses an entry: what the
+// detector made of it, the size every method gives, and the method chosen.
+type Explanation struct {
+	Name       string
+	Size       int64 // uncompressed size
+	Profile    detect.Profile
+	Candidates []Candidate // ordered by method, then vocabulary
+	Method     Method      // the method Archive.Add picks
+	Vocab      VocabInfo
+	CompSize   int64
+	Prediction *Prediction // of DEFLATE against Bpelate; nil if none was made
+}
+
+// Explain compresses data as Archive.Add would, and with the methods and
+// vocabularies automatic selection leaves out, and reports the results.
+// It is meant for tuning vocabularies and catching misdetections; it
+// compresses data several times over.
+func (c *Compressor) Explain(data []byte, name string) Explanation {
+	profile := c.nameMap.DetectWith(name, data, c.sampling)
+	ch, _ := c.selectMethod(data, profile, AddOptions{}) // fails only with a forced method
+	return c.explain(data, name, profile, ch)
+}
+
+// Mispredicted reports whether a sure prediction ruled out the smaller of
+// DEFLATE and Bpelate with the predicted vocabulary.
+func (e Explanation) Mispredicted() bool {
+	p := e.Prediction
+	if p == nil || !p.Sure {
+		return false
+	}
+	deflate, bpelate := int64(-1), int64(-1)
+	for _, cand := range e.Candidates {
+		switch {
+		case cand.Err != nil:
+		case cand.Method == MethodDEFLATE:
+			deflate = cand.Size
+		case cand.Method == MethodBPELATE && cand.Vocab == p.Vocab:
+			bpelate = cand.Size
+		}
+	}
+	if deflate < 0 || bpelate < 0 {
+		return false
+	}
+	if p.Method == MethodDEFLATE {
+		return bpelate < deflate
+	}
+	return deflate < bpelate
+}
+
+// SetExplain makes Add record an Explanation of each entry it compresses,
+// at the cost of trying every method on it.
+func (a *Archive) SetExplain(on bool) {
+	a.explain = on
+}
+
+// Explanations returns the explanations recorded by Add since SetExplain,
+// in the order the entries were added.
+func (a *Archive) Explanations() []Explanation {
+	return a.explanations
+}
+
+// choice is the outcome of automatic method selection: every candidate
+// tried, and the smallest of them.
+type choice struct {
+	candidates []Candidate
+	found      bool
+	compressed []byte
+	method     Method
+	vocab      VocabInfo
+	prediction *Prediction
+}
+
+// try records a candidate, keeping it if it is smaller than the best so
+// far. Ties go to the earlier, simpler method. The BPE methods set the
+// programming language of the vocabulary information.
+func (ch *choice) try(method Method, lang ProgLang, compressed []byte, err error) {
+	ch.candidates = append(ch.candidates, Candidate{
+		Method: method, Vocab: lang, Size: int64(len(compressed)), Err: err, Auto: true,
+	})
+	if err != nil || (ch.found && len(compressed) >= len(ch.compressed)) {
+		return
+	}
+	ch.found, ch.compressed, ch.method = true, compressed, method
+	if usesVocab(method) {
+		ch.vocab.ProgLang = lang
+	}
+}
+
+// tried reports whether the candidate was tried with the vocabulary.
+func (ch *choice) tried(method Method, lang ProgLang) bool {
+	for _, cand := range ch.candidates {
+		if cand.Method == method && cand.Vocab == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// explain completes the candidates of automatic selection with the methods
+// and vocabularies it left out.
+func (c *Compressor) explain(data []byte, name string, profile detect.Profile, ch *choice) Explanation {
+	candidates := append([]Candidate(nil), ch.candidates...)
+	extra := func(method Method, lang ProgLang, compressed []byte, err error) {
+		if !ch.tried(method, lang) {
+			candidates = append(candidates, Candidate{Method: method, Vocab: lang, Size: int64(len(compressed)), Err: err})
+		}
+	}
+
+	if len(data) > 0 {
+		extra(MethodStore, ProgLangNone, data, nil)
+		if !ch.tried(MethodDEFLATE, ProgLangNone) {
+			compressed, err := c.compressDEFLATE(data)
+			extra(MethodDEFLATE, ProgLangNone, compressed, err)
+		}
+		if !ch.tried(MethodUNZLATE, ProgLangNone) {
+			compressed, err := c.compressUNZLATE(data)
+			extra(MethodUNZLATE, ProgLangNone, compressed, err)
+		}
+		for _, lang := range vocabLangs {
+			if !ch.tried(MethodBPELATE, lang) {
+				compressed, err := c.compressBPELATEVocab(data, lang)
+				extra(MethodBPELATE, lang, compressed, err)
+			}
+		}
+		if !ch.tried(MethodMIXLATE, ProgLangNone) {
+			if compressed, ok := c.compressMIXLATE(data, profile.Segments); ok {
+				extra(MethodMIXLATE, ProgLangNone, compressed, nil)
+			}
+		}
+		if !ch.tried(MethodEXELATE, ProgLangNone) && isX86Executable(data) {
+			compressed, err := c.compressEXELATE(data)
+			extra(MethodEXELATE, ProgLangNone, compressed, err)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Method != candidates[j].Method {
+			return candidates[i].Method < candidates[j].Method
+		}
+		return candidates[i].Vocab < candidates[j].Vocab
+	})
+
+	return Explanation{
+		Name:       name,
+		Size:       int64(len(data)),
+		Profile:    profile,
+		Candidates: candidates,
+		Method:     ch.method,
+		Vocab:      ch.vocab,
+		CompSize:   int64(len(ch.compressed)),
+		Prediction: ch.prediction,
+	}
+}
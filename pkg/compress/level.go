@@ -0,0 +1,113 @@
+package compress
+
+import (
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+// Compression levels
+const (
+	LevelFastest = 1 // fast DEFLATE only
+	LevelDefault = 6 // trials of the methods suited to the content
+	LevelBest    = 9 // trials of every vocabulary, parse and token layout
+)
+
+// strategy is what a compression level does.
+type strategy struct {
+	deflate   int  // flate level of every DEFLATE stream
+	bpe       bool // consider the BPE methods at all
+	trials    bool // compress with each candidate and keep the smallest, instead of guessing
+	predict   bool // skip the trials of DEFLATE or Bpelate when a sample says which wins
+	runnerUp  bool // try the vocabulary of the runner-up language of unsure code
+	mixlate   bool // try Mixlate on mixed content
+	allVocabs bool // try Bpelate with every vocabulary
+	shortest  bool // try the shortest BPE parse as well as greedy
+	layouts   bool // try each vocabulary's tokens ANS-coded (Unzlate) too, not only DEFLATEd (Bpelate)
+}
+
+// strategies are indexed by level:
+//
+//	1-3  a guess from the profile, with fast DEFLATE: Bpelate for code the
+//	     detector is sure of that has a vocabulary of its own (from
+//	     level 2), DEFLATE otherwise
+//	4-6  DEFLATE, Bpelate with the detected language's vocabulary and
+//	     Exelate compared; from level 5 the runner-up language's vocabulary
+//	     and Mixlate too. DEFLATE and Bpelate are only both tried when a
+//	     prediction from a sample finds them close
+//	7    every trial, the shortest parse as well as greedy, and each
+//	     vocabulary's tokens ANS-coded (Unzlate) too, not only DEFLATEd
+//	     (Bpelate)
+//	8    and every vocabulary
+//	9    and both
+var strategies = [...]strategy{
+	1: {deflate: 1},
+	2: {deflate: 2, bpe: true},
+	3: {deflate: 4, bpe: true},
+	4: {deflate: 6, bpe: true, trials: true, predict: true},
+	5: {deflate: 8, bpe: true, trials: true, predict: true, runnerUp: true, mixlate: true},
+	6: {deflate: 9, bpe: true, trials: true, predict: true, runnerUp: true, mixlate: true},
+	7: {deflate: 9, bpe: true, trials: true, runnerUp: true, mixlate: true, shortest: true, layouts: true},
+	8: {deflate: 9, bpe: true, trials: true, runnerUp: true, mixlate: true, allVocabs: true, layouts: true},
+	9: {deflate: 9, bpe: true, trials: true, runnerUp: true, mixlate: true, allVocabs: true, shortest: true, layouts: true},
+}
+
+// SetLevel sets the compression level, from LevelFastest to LevelBest;
+// levels out of range are clamped. Low levels guess the method from the
+// content profile and use fast DEFLATE, high levels compress with more
+// candidates and keep the smallest. The zero Compressor has LevelDefault.
+func (c *Compressor) SetLevel(level int) {
+	c.level = min(max(level, LevelFastest), LevelBest)
+}
+
+// Level returns the compression level.
+func (c *Compressor) Level() int {
+	if c.level == 0 {
+		return LevelDefault
+	}
+	return c.level
+}
+
+// strategy returns what the compression level does.
+func (c *Compressor) strategy() strategy {
+	return strategies[c.Level()]
+}
+
+// vocabLangs are the programming languages with a vocabulary of their own,
+// and ProgLangNone for the default (text) vocabulary.
+var vocabLangs = []ProgLang{ProgLangNone, ProgLangGo, ProgLangPython, ProgLangJavaScript}
+
+// guessMethod picks the method of an entry from its profile alone, as
+// levels without trials do.
+func (c *Compressor) guessMethod(data []byte, profile detect.Profile, vocab VocabInfo, forceVocab bool) *choice {
+	ch := &choice{vocab: vocab}
+	bpelate := forceVocab && (profile.Type == detect.TypeText || profile.Type == detect.TypeCode)
+	if profile.Type == detect.TypeCode && profile.LanguageConfidence >= lowCodeConfidence {
+		lang := makeVocabInfoFromDetect(profile.Language, profile.NatLang).ProgLang
+		bpelate = bpelate || lang != ProgLangNone
+	}
+
+	if bpelate && c.strategy().bpe {
+		compressed, err := c.compressBPELATEVocab(data, vocab.ProgLang)
+		ch.try(MethodBPELATE, vocab.ProgLang, compressed, err)
+	}
+	if !ch.found {
+		compressed, err := c.compressDEFLATE(data)
+		ch.try(MethodDEFLATE, ProgLangNone, compressed, err)
+	}
+	return ch
+}
+
+// compressBPELATEVocab compresses with Bpelate in the vocabulary of lang,
+// with the greedy parse and, if the level says so, the shortest parse,
+// returning the smaller. Either decodes the same way.
+func (c *Compressor) compressBPELATEVocab(data []byte, lang ProgLang) ([]byte, error) {
+	encoder := c.getEncoderForProgLang(lang)
+	compressed, err := c.compressBPELATEWith(data, encoder)
+	if err != nil || !c.strategy().shortest || len(data) == 0 {
+		return compressed, err
+	}
+	shortest, err := c.compressDEFLATE(encodeVarints(encoder.EncodeShortest(data)))
+	if err == nil && len(shortest) < len(compressed) {
+		return shortest, nil
+	}
+	return compressed, nil
+}
@@ -0,0 +1,143 @@
+package compress
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Limits bounds the resources spent extracting an untrusted archive.
+// A zero field means no limit.
+type Limits struct {
+	MaxEntrySize   int64 // max uncompressed bytes per entry
+	MaxArchiveSize int64 // max uncompressed bytes across all entries
+	MaxRatio       int64 // max uncompressed/compressed size ratio per entry
+	MaxEntries     int   // max entries in the central directory
+}
+
+// Limit errors (wrapped in *LimitError)
+var (
+	ErrEntryTooLarge   = errors.New("compress: entry exceeds size limit")
+	ErrArchiveTooLarge = errors.New("compress: archive exceeds size limit")
+	ErrRatioExceeded   = errors.New("compress: entry exceeds compression ratio limit")
+	ErrTooManyEntries  = errors.New("compress: archive exceeds entry limit")
+)
+
+// errOverBudget is returned by the bounded decoders; decompressEntry maps it
+// to a *LimitError for the limit that was hit.
+var errOverBudget = errors.New("compress: over budget")
+
+// LimitError reports which limit an archive or entry violated.
+// Use errors.Is with the Err* sentinels to test the kind.
+type LimitError struct {
+	Name  string // entry name (empty for archive-wide limits)
+	Limit int64  // configured limit
+	Err   error  // ErrEntryTooLarge, ErrArchiveTooLarge, ErrRatioExceeded or ErrTooManyEntries
+}
+
+func (e *LimitError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%v (limit %d)", e.Err, e.Limit)
+	}
+	return fmt.Sprintf("%s: %v (limit %d)", e.Name, e.Err, e.Limit)
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// maxVarintLen is the most bytes encodeVarints spends on one token.
+const maxVarintLen = 5
+
+// SetLimits sets the extraction limits and resets the archive-wide budget.
+// The budget accumulates across DecompressFile calls until SetLimits is
+// called again; DecompressAll resets it itself.
+func (c *Compressor) SetLimits(l Limits) {
+	c.limits = l
+	c.extracted = 0
+}
+
+// Limits returns the current extraction limits.
+func (c *Compressor) Limits() Limits {
+	return c.limits
+}
+
+// budget is the most output one entry may produce under the current limits.
+type budget struct {
+	name  string
+	max   int64 // -1 if unlimited
+	limit int64 // the configured limit that sets max
+	err   error // sentinel for the limit that sets max
+}
+
+// entryBudget computes the output budget for an entry.
+func (c *Compressor) entryBudget(info *FileInfo) budget {
+	b := budget{name: info.Name, max: -1}
+
+	tighten := func(max, limit int64, err error) {
+		if b.max < 0 || max < b.max {
+			b.max, b.limit, b.err = max, limit, err
+		}
+	}
+
+	if c.limits.MaxEntrySize > 0 {
+		tighten(c.limits.MaxEntrySize, c.limits.MaxEntrySize, ErrEntryTooLarge)
+	}
+	if c.limits.MaxArchiveSize > 0 {
+		remaining := c.limits.MaxArchiveSize - c.extracted
+		if remaining < 0 {
+			remaining = 0
+		}
+		tighten(remaining, c.limits.MaxArchiveSize, ErrArchiveTooLarge)
+	}
+	if c.limits.MaxRatio > 0 {
+		compSize := info.CompSize
+		if compSize < 1 {
+			compSize = 1
+		}
+		tighten(mulSat(compSize, c.limits.MaxRatio), c.limits.MaxRatio, ErrRatioExceeded)
+	}
+
+	return b
+}
+
+// allows reports whether n bytes of output fit the budget.
+func (b budget) allows(n int64) bool {
+	return b.max < 0 || n <= b.max
+}
+
+// scaled returns the bound for an intermediate stream that may be up to
+// factor times larger than the final output.
+func (b budget) scaled(factor int64) int64 {
+	if b.max < 0 {
+		return -1
+	}
+	return mulSat(b.max, factor)
+}
+
+// mulSat returns x*k for x >= 0 and k > 0, saturating at math.MaxInt64
+// instead of wrapping: sizes from a hostile header must not turn a large
+// budget into a small or negative (unlimited) one.
+func mulSat(x, k int64) int64 {
+	if x > math.MaxInt64/k {
+		return math.MaxInt64
+	}
+	return x * k
+}
+
+// exceeded returns the error for an entry that went over budget.
+func (b budget) exceeded() error {
+	name := b.name
+	if b.err == ErrArchiveTooLarge {
+		name = ""
+	}
+	return &LimitError{Name: name, Limit: b.limit, Err: b.err}
+}
+
+// checkEntries enforces MaxEntries against a central directory count.
+func (l Limits) checkEntries(n int) error {
+	if l.MaxEntries > 0 && n > l.MaxEntries {
+		return &LimitError{Limit: int64(l.MaxEntries), Err: ErrTooManyEntries}
+	}
+	return nil
+}
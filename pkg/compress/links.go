@@ -0,0 +1,93 @@
+package compress
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"time"
+)
+
+// Extra field IDs for link and device entries
+const (
+	extraHardLink = 0x554C // 'UL' - hard link: name of the entry it links to
+	extraDevice   = 0x5544 // 'UD' - device number: major(4) + minor(4)
+)
+
+// ErrLinkTarget is returned when a hard link refers to a missing entry.
+var ErrLinkTarget = errors.New("compress: hard link target is not in the archive")
+
+// AddHardLink adds a hard link to an entry already in the archive. The link
+// is stored as an empty entry naming its target, so the content is stored
+// only once; unz recreates it with link(2). Tools that do not know the
+// record extract an empty file.
+func (a *Archive) AddHardLink(name, target string, modTime time.Time, mode os.FileMode) error {
+	if _, ok := a.index[target]; !ok || target == name {
+		return ErrLinkTarget
+	}
+
+	a.put(archiveEntry{
+		name:     name,
+		method:   MethodStore,
+		modTime:  modTime,
+		mode:     mode &^ os.ModeType,
+		hardLink: target,
+	})
+	return nil
+}
+
+// AddSpecial adds a device node or named pipe as a metadata-only entry. mode
+// carries the file type (os.ModeDevice, os.ModeCharDevice or
+// os.ModeNamedPipe); major and minor are the device number and are ignored
+// for pipes.
+func (a *Archive) AddSpecial(name string, modTime time.Time, mode os.FileMode, major, minor uint32) error {
+	if mode&(os.ModeDevice|os.ModeNamedPipe) == 0 {
+		return ErrUnsupported
+	}
+
+	entry := archiveEntry{
+		name:    name,
+		method:  MethodStore,
+		modTime: modTime,
+		mode:    mode,
+	}
+	if mode&os.ModeDevice != 0 {
+		entry.device = &[2]uint32{major, minor}
+	}
+	a.put(entry)
+	return nil
+}
+
+// makeHardLink creates the hard link extra field (0x554C).
+// Format: 2 bytes ID + 2 bytes size + target name
+func makeHardLink(target string) []byte {
+	extra := make([]byte, 4, 4+len(target))
+	binary.LittleEndian.PutUint16(extra[0:2], extraHardLink)
+	binary.LittleEndian.PutUint16(extra[2:4], uint16(len(target)))
+	return append(extra, target...)
+}
+
+// parseHardLink extracts the hard link target from extra field 0x554C.
+func parseHardLink(extra []byte) string {
+	field, _ := findExtra(extra, extraHardLink)
+	return string(field)
+}
+
+// makeDevice creates the device number extra field (0x5544).
+// Format: 2 bytes ID + 2 bytes size + major(4) + minor(4)
+func makeDevice(major, minor uint32) []byte {
+	extra := make([]byte, 12)
+	binary.LittleEndian.PutUint16(extra[0:2], extraDevice)
+	binary.LittleEndian.PutUint16(extra[2:4], 8)
+	binary.LittleEndian.PutUint32(extra[4:8], major)
+	binary.LittleEndian.PutUint32(extra[8:12], minor)
+	return extra
+}
+
+// parseDevice extracts the device number from extra field 0x5544.
+func parseDevice(extra []byte) (major, minor uint32) {
+	field, ok := findExtra(extra, extraDevice)
+	if !ok || len(field) < 8 {
+		return 0, 0
+	}
+	return binary.LittleEndian.Uint32(field[0:4]), binary.LittleEndian.Uint32(field[4:8])
+}
@@ -0,0 +1,142 @@
+package compress
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/ha1tch/unz/pkg/bpe"
+	"github.com/ha1tch/unz/pkg/detect"
+)
+
+// Mixlate is Bpelate for files that mix languages, such as Markdown with
+// fenced code, HTML with scripts and Jupyter notebooks. Each region is
+// tokenized with the vocabulary of its own language, and the tokens of all
+// regions are compressed together with DEFLATE. The entry data starts with
+// the segment map:
+//
+//	uvarint   number of segments
+//	per segment:
+//	  uvarint length of its varint tokens, in bytes
+//	  byte    ProgLang of its vocabulary
+//
+// followed by the DEFLATE stream.
+
+// vocabSegment is a run of data tokenized with one vocabulary.
+type vocabSegment struct {
+	data []byte
+	lang ProgLang
+}
+
+// vocabSegments maps detected regions to the vocabularies that encode
+// them, joining neighbours that share one.
+func (c *Compressor) vocabSegments(data []byte, segs []detect.Segment) []vocabSegment {
+	var out []vocabSegment
+	var encoders []*bpe.Encoder
+	for _, s := range segs {
+		lang := makeVocabInfoFromDetect(s.Language, detect.NatLangUnknown).ProgLang
+		encoder := c.getEncoderForProgLang(lang)
+		region := data[s.Offset : s.Offset+s.Length]
+		if n := len(out); n > 0 && encoders[n-1] == encoder {
+			out[n-1].data = data[s.Offset-len(out[n-1].data) : s.Offset+s.Length]
+			continue
+		}
+		out = append(out, vocabSegment{region, lang})
+		encoders = append(encoders, encoder)
+	}
+	return out
+}
+
+// compressMIXLATE compresses data split into regions by detect.Segments.
+// It reports false if the regions all use the same vocabulary, when
+// Bpelate does as well.
+func (c *Compressor) compressMIXLATE(data []byte, segs []detect.Segment) ([]byte, bool) {
+	vsegs := c.vocabSegments(data, segs)
+	if len(vsegs) < 2 {
+		return nil, false
+	}
+	compressed, err := c.encodeMIXLATE(vsegs)
+	return compressed, err == nil
+}
+
+// compressMIXLATEAny compresses data with Mixlate even if it has a single
+// region, for callers that ask for the method.
+func (c *Compressor) compressMIXLATEAny(data []byte) ([]byte, error) {
+	vsegs := c.vocabSegments(data, detect.Segments(data))
+	if len(vsegs) == 0 {
+		vsegs = []vocabSegment{{data, ProgLangNone}}
+	}
+	return c.encodeMIXLATE(vsegs)
+}
+
+// encodeMIXLATE writes the segment map and the compressed tokens.
+func (c *Compressor) encodeMIXLATE(vsegs []vocabSegment) ([]byte, error) {
+	header := binary.AppendUvarint(nil, uint64(len(vsegs)))
+	var tokenBytes []byte
+	for _, s := range vsegs {
+		tokens := encodeVarints(c.getEncoderForProgLang(s.lang).Encode(s.data))
+		header = binary.AppendUvarint(header, uint64(len(tokens)))
+		header = append(header, byte(s.lang))
+		tokenBytes = append(tokenBytes, tokens...)
+	}
+
+	compressed, err := c.compressDEFLATE(tokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, compressed...), nil
+}
+
+// decompressMIXLATEMax decompresses Mixlate data within budget b.
+func (c *Compressor) decompressMIXLATEMax(data []byte, b budget) ([]byte, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)) {
+		return nil, ErrCorrupted
+	}
+	data = data[n:]
+
+	type segment struct {
+		size uint64
+		lang ProgLang
+	}
+	segs := make([]segment, count)
+	total := uint64(0)
+	for i := range segs {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || n >= len(data) {
+			return nil, ErrCorrupted
+		}
+		if size > math.MaxUint64-total {
+			// The sizes would wrap around to a plausible total
+			return nil, ErrCorrupted
+		}
+		segs[i] = segment{size, ProgLang(data[n])}
+		total += size
+		data = data[n+1:]
+	}
+
+	tokenBytes, err := c.decompressDEFLATEMax(data, b.scaled(maxVarintLen))
+	if err != nil {
+		return nil, err
+	}
+	if total != uint64(len(tokenBytes)) {
+		return nil, ErrCorrupted
+	}
+
+	var content []byte
+	for _, s := range segs {
+		if s.size > uint64(len(tokenBytes)) {
+			return nil, ErrCorrupted
+		}
+		max := b.max
+		if max >= 0 {
+			max -= int64(len(content))
+		}
+		part, err := decodeTokensMax(c.getEncoderForProgLang(s.lang), tokenBytes[:s.size], max)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, part...)
+		tokenBytes = tokenBytes[s.size:]
+	}
+	return content, nil
+}
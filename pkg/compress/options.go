@@ -0,0 +1,102 @@
+package compress
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddOptions overrides automatic method selection in Archive.AddWith. The
+// zero value forces nothing.
+type AddOptions struct {
+	// Method is used for the entry, whatever its content, if ForceMethod
+	// is set.
+	Method      Method
+	ForceMethod bool
+
+	// Vocab is recorded for the entry, and its programming language picks
+	// the vocabulary of Unzlate and Bpelate, if ForceVocab is set. Text and
+	// code are then compressed with Bpelate in that vocabulary or DEFLATE,
+	// whichever is smaller. Mixlate keeps a vocabulary per segment.
+	Vocab      VocabInfo
+	ForceVocab bool
+}
+
+// compressAs compresses data with method, tokenizing with the vocabulary
+// of vocab for Unzlate and Bpelate.
+func (c *Compressor) compressAs(data []byte, method Method, vocab VocabInfo) ([]byte, error) {
+	switch method {
+	case MethodUNZLATE:
+		return c.compressUNZLATEWith(data, c.getEncoderForProgLang(vocab.ProgLang))
+	case MethodBPELATE:
+		return c.compressBPELATEWith(data, c.getEncoderForProgLang(vocab.ProgLang))
+	case MethodMIXLATE:
+		return c.compressMIXLATEAny(data)
+	case MethodEXELATE:
+		return c.compressEXELATE(data)
+	case MethodDEFLATE:
+		return c.compressDEFLATE(data)
+	case MethodStore:
+		return data, nil
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+// methods are the methods the compressor writes, in ParseMethod's order.
+var methods = []Method{MethodStore, MethodDEFLATE, MethodUNZLATE, MethodBPELATE, MethodMIXLATE, MethodEXELATE}
+
+// ParseMethod reads a method by name, in any case: "deflate", "bpelate",
+// and so on. "store" is Stored.
+func ParseMethod(name string) (Method, error) {
+	if strings.EqualFold(name, "store") {
+		return MethodStore, nil
+	}
+	for _, m := range methods {
+		if strings.EqualFold(name, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("compress: unknown method %q", name)
+}
+
+// ParseVocabInfo reads a VocabInfo written as a comma-separated list of the
+// names its fields' String methods give, in any order and case, each field
+// at most once: "go", or "es,python", or "en,json,markdown". Fields not
+// given are left unset.
+func ParseVocabInfo(str string) (VocabInfo, error) {
+	var info VocabInfo
+	var set [4]bool // NatLang, ProgLang, DataFmt, Markup
+	for _, name := range strings.Split(str, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		field := -1
+		for n := NatLang(1); n.String() != "unknown"; n++ {
+			if n.String() == name {
+				info.NatLang, field = n, 0
+			}
+		}
+		for p := ProgLang(1); p.String() != "unknown"; p++ {
+			if p.String() == name {
+				info.ProgLang, field = p, 1
+			}
+		}
+		for d := DataFmt(1); d.String() != "unknown"; d++ {
+			if d.String() == name {
+				info.DataFmt, field = d, 2
+			}
+		}
+		for m := MarkupLang(1); m.String() != "unknown"; m++ {
+			if m.String() == name {
+				info.Markup, field = m, 3
+			}
+		}
+
+		if field < 0 {
+			return VocabInfo{}, fmt.Errorf("compress: unknown vocabulary %q", name)
+		}
+		if set[field] {
+			return VocabInfo{}, fmt.Errorf("compress: vocabulary %q sets a field twice", str)
+		}
+		set[field] = true
+	}
+	return info, nil
+}
@@ -0,0 +1,113 @@
+package compress
+
+import "math"
+
+// Prediction is an estimate, from a sample of a file, of whether Bpelate
+// or DEFLATE compresses it smaller.
+type Prediction struct {
+	Method Method   // MethodBPELATE or MethodDEFLATE, whichever is estimated smaller
+	Vocab  ProgLang // vocabulary of the Bpelate estimated
+	Ratio  float64  // estimated Bpelate size over DEFLATE size
+	Sure   bool     // far enough from a tie that only Method is tried
+}
+
+// predictSample is the number of bytes the predictor samples, in three
+// windows at the start, middle and end of larger files.
+const predictSample = 8192
+
+// predictMargin is how far from 1 the estimated ratio must be for the
+// prediction to be sure. Closer calls are left to the trials; large files
+// are the closest calls. cmd/benchmark reports how many predictions are
+// sure, and what the wrong ones cost.
+const predictMargin = 0.03
+
+// Coefficients of the estimate of the log of the ratio. The estimate only
+// has to fall on the right side of 1 when it is sure; cmd/benchmark
+// measures how often it does.
+const (
+	predictConst   = 0.17
+	predictEntropy = 0.45   // of the log of the ratio of entropy-coded sizes
+	predictTokens  = -0.10  // of the log of tokens per byte
+	predictSize    = -0.016 // of the log of the file size
+)
+
+// predict estimates the ratio of the Bpelate size of data, with the
+// vocabulary of lang, to its DEFLATE size. The estimate combines three
+// measures of a sample: the ratio of the order-0 entropy-coded sizes of
+// its token varints and of its bytes, the number of tokens per byte, and
+// the size of the file, as DEFLATE finds more matches in larger files.
+// Neither method is run in full.
+func (c *Compressor) predict(data []byte, lang ProgLang) Prediction {
+	encoder := c.getEncoderForProgLang(lang)
+	var byteCounts, varintCounts [256]int
+	var n, tokens int
+	for _, window := range predictWindows(data) {
+		for _, b := range window {
+			byteCounts[b]++
+		}
+		ids := encoder.Encode(window)
+		for _, b := range encodeVarints(ids) {
+			varintCounts[b]++
+		}
+		n += len(window)
+		tokens += len(ids)
+	}
+
+	pred := Prediction{Method: MethodDEFLATE, Vocab: lang, Ratio: 1}
+	plain, coded := entropySize(byteCounts[:]), entropySize(varintCounts[:])
+	if plain == 0 || coded == 0 {
+		// A run of one byte; nothing to estimate from
+		return pred
+	}
+	pred.Ratio = math.Exp(predictConst +
+		predictEntropy*math.Log(coded/plain) +
+		predictTokens*math.Log(float64(tokens)/float64(n)) +
+		predictSize*math.Log(float64(len(data))))
+	if pred.Ratio < 1 {
+		pred.Method = MethodBPELATE
+	}
+	pred.Sure = math.Abs(pred.Ratio-1) > predictMargin
+	return pred
+}
+
+// predictWindows returns the sample of data the predictor looks at.
+func predictWindows(data []byte) [][]byte {
+	n := len(data)
+	if n <= predictSample {
+		return [][]byte{data}
+	}
+	w := predictSample / 3
+	m := (n - w) / 2
+	return [][]byte{data[:w], data[m : m+w], data[n-w:]}
+}
+
+// entropySize returns the order-0 entropy-coded size in bits of the bytes
+// counted in counts.
+func entropySize(counts []int) float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	bits := 0.0
+	for _, count := range counts {
+		if count > 0 {
+			bits -= float64(count) * math.Log2(float64(count)/float64(total))
+		}
+	}
+	return bits
+}
+
+// predictMethod returns the prediction for data with the vocabulary of
+// lang, or nil at levels that run every trial.
+func (c *Compressor) predictMethod(data []byte, lang ProgLang) *Prediction {
+	if !c.strategy().predict {
+		return nil
+	}
+	pred := c.predict(data, lang)
+	return &pred
+}
+
+// skips reports whether the prediction rules out trying method.
+func (p *Prediction) skips(method Method) bool {
+	return p != nil && p.Sure && p.Method != method
+}
@@ -0,0 +1,81 @@
+package compress
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultEpoch is the timestamp of reproducible archives when
+// SOURCE_DATE_EPOCH is not set: the earliest time a DOS date can hold.
+var DefaultEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SourceDateEpoch returns the time given by the SOURCE_DATE_EPOCH
+// environment variable (seconds since the Unix epoch), or DefaultEpoch if
+// it is unset or empty.
+// See https://reproducible-builds.org/specs/source-date-epoch/.
+func SourceDateEpoch() (time.Time, error) {
+	s := os.Getenv("SOURCE_DATE_EPOCH")
+	if s == "" {
+		return DefaultEpoch, nil
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || sec < 0 {
+		return time.Time{}, fmt.Errorf("compress: invalid SOURCE_DATE_EPOCH %q", s)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// SetReproducible makes Bytes produce the same output for the same names
+// and contents, whatever the order of Add calls or the state of the files
+// they came from: entries are written sorted by name, every timestamp is
+// epoch (in UTC, so the DOS time does not depend on the local zone),
+// permissions are normalised to 0644 or 0755 for files (0755 if any
+// execute bit is set), 0755 for directories and 0777 for symlinks, and
+// owner, access/change times and extended attributes are left out.
+//
+// Method selection only depends on the content, so nothing else varies
+// between runs. Entries copied with CopyRaw keep their original headers,
+// and encrypted entries are never reproducible: their salt is random.
+func (a *Archive) SetReproducible(epoch time.Time) {
+	a.reproducible = true
+	a.epoch = epoch.UTC()
+}
+
+// reproducibleEntries returns the entries to write, sorted and normalised
+// if the archive is reproducible.
+func (a *Archive) reproducibleEntries() []archiveEntry {
+	if !a.reproducible {
+		return a.entries
+	}
+	entries := make([]archiveEntry, len(a.entries))
+	copy(entries, a.entries)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	for i := range entries {
+		if entries[i].raw {
+			continue
+		}
+		entries[i].modTime = a.epoch
+		entries[i].mode = normaliseMode(entries[i].mode)
+		entries[i].unix = UnixAttrs{}
+	}
+	return entries
+}
+
+// normaliseMode keeps the file type of mode and replaces its permissions
+// with the reproducible ones.
+func normaliseMode(mode os.FileMode) os.FileMode {
+	typ := mode & os.ModeType
+	switch {
+	case mode&os.ModeSymlink != 0:
+		return typ | 0777
+	case mode.IsDir(), mode&0111 != 0:
+		return typ | 0755
+	default:
+		return typ | 0644
+	}
+}
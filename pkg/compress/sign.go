@@ -0,0 +1,155 @@
+package compress
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// Signatures are stored as the last line of the archive comment:
+//
+//	UNZSIG1 <base64 public key> <base64 signature>
+//
+// The signed message is a SHA-256 digest of the central directory (names,
+// methods, CRC-32s, sizes, extra fields, file comments) followed by the
+// SHA-256 of every entry's local header and compressed data, in central
+// directory order, and the comment text before the signature line. Any
+// change to the data, the headers, the directory or the comment invalidates
+// it.
+const (
+	sigCommentPrefix = "UNZSIG1 "
+	sigDomain        = "unz archive signature v1\x00"
+)
+
+// Signature errors
+var (
+	ErrNotSigned    = errors.New("compress: archive is not signed")
+	ErrSignature    = errors.New("compress: archive signature is invalid")
+	ErrUntrustedKey = errors.New("compress: archive is signed by an untrusted key")
+)
+
+// Sign makes Bytes sign the archive with an Ed25519 key. The signature is
+// written to the archive comment and covers the central directory (with
+// each entry's CRC-32) and a SHA-256 of each entry's headers and data.
+// A nil key turns signing off.
+func (a *Archive) Sign(key ed25519.PrivateKey) {
+	a.signKey = key
+}
+
+// VerifySignature checks the signature of an archive written by a signing
+// Archive and returns the public key that made it. If trusted is not nil the
+// archive must also have been signed by that key; with a nil trusted key the
+// check only proves the archive is intact, not who signed it.
+func VerifySignature(data []byte, trusted ed25519.PublicKey) (ed25519.PublicKey, error) {
+	comment, eocdOffset, err := eocdComment(data)
+	if err != nil {
+		return nil, err
+	}
+	text, line, ok := splitSignature(comment)
+	if !ok {
+		return nil, ErrNotSigned
+	}
+	pub, sig, ok := parseSignatureComment(line)
+	if !ok {
+		return nil, ErrNotSigned
+	}
+
+	files, err := listFiles(data, Limits{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	centralDirSize := int(binary.LittleEndian.Uint32(data[eocdOffset+12 : eocdOffset+16]))
+	centralDirOffset := int(binary.LittleEndian.Uint32(data[eocdOffset+16 : eocdOffset+20]))
+	if centralDirOffset+centralDirSize > eocdOffset {
+		return nil, ErrCorrupted
+	}
+
+	spans := make([][2]int, len(files))
+	for i, info := range files {
+		hdr, err := parseLocalHeader(data, int(info.Offset))
+		if err != nil {
+			return nil, err
+		}
+		end := hdr.dataOffset + int(info.CompSize)
+		if end > len(data) {
+			return nil, ErrCorrupted
+		}
+		spans[i] = [2]int{int(info.Offset), end}
+	}
+
+	digest := signatureDigest(data, spans, data[centralDirOffset:centralDirOffset+centralDirSize], text)
+	if !ed25519.Verify(pub, digest, sig) {
+		return nil, ErrSignature
+	}
+	if trusted != nil && !pub.Equal(trusted) {
+		return pub, ErrUntrustedKey
+	}
+	return pub, nil
+}
+
+// signatureDigest computes the message that is signed: the central
+// directory, the SHA-256 of each entry span of data and the comment text
+// preceding the signature line.
+func signatureDigest(data []byte, spans [][2]int, centralDir []byte, comment string) []byte {
+	h := sha256.New()
+	h.Write([]byte(sigDomain))
+
+	var n [8]byte
+	binary.LittleEndian.PutUint64(n[:], uint64(len(centralDir)))
+	h.Write(n[:])
+	h.Write(centralDir)
+
+	for _, span := range spans {
+		sum := sha256.Sum256(data[span[0]:span[1]])
+		h.Write(sum[:])
+	}
+
+	binary.LittleEndian.PutUint64(n[:], uint64(len(comment)))
+	h.Write(n[:])
+	h.Write([]byte(comment))
+	return h.Sum(nil)
+}
+
+// splitSignature splits an archive comment into the text before the
+// signature line and the signature line itself.
+func splitSignature(comment string) (text, line string, ok bool) {
+	i := strings.LastIndex(comment, sigCommentPrefix)
+	if i < 0 || (i > 0 && comment[i-1] != '\n') {
+		return comment, "", false
+	}
+	return comment[:i], comment[i:], true
+}
+
+// signatureComment signs digest and formats the archive comment line.
+func signatureComment(key ed25519.PrivateKey, digest []byte) string {
+	pub := key.Public().(ed25519.PublicKey)
+	return sigCommentPrefix + base64.StdEncoding.EncodeToString(pub) + " " +
+		base64.StdEncoding.EncodeToString(ed25519.Sign(key, digest))
+}
+
+// parseSignatureComment extracts the public key and signature from an
+// archive comment.
+func parseSignatureComment(comment string) (ed25519.PublicKey, []byte, bool) {
+	rest, ok := strings.CutPrefix(comment, sigCommentPrefix)
+	if !ok {
+		return nil, nil, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 2 {
+		return nil, nil, false
+	}
+
+	pub, err := base64.StdEncoding.DecodeString(fields[0])
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, nil, false
+	}
+	sig, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, nil, false
+	}
+	return ed25519.PublicKey(pub), sig, true
+}
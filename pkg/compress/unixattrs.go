@@ -0,0 +1,184 @@
+package compress
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+	"time"
+)
+
+// UnixAttrs holds Unix metadata beyond the permission bits and mtime.
+type UnixAttrs struct {
+	Atime time.Time // access time, zero if not recorded
+	Ctime time.Time // inode change time, zero if not recorded (informational)
+
+	HasOwner bool // UID and GID are recorded
+	UID      int
+	GID      int
+
+	// Extended attributes by name. POSIX ACLs are stored here too, as the
+	// system.posix_acl_access and system.posix_acl_default attributes.
+	Xattrs map[string][]byte
+}
+
+// Extra field IDs for Unix metadata
+const (
+	extraUnixOwner = 0x7875 // Info-ZIP new Unix extra field ("ux"): UID/GID
+	extraXattrs    = 0x5558 // 'UX' - extended attributes (central directory only)
+)
+
+// ErrExtraTooLarge is returned when metadata does not fit in an extra field.
+var ErrExtraTooLarge = errors.New("compress: metadata exceeds the 64 KiB extra field limit")
+
+// maxXattrExtra leaves room for the other extra fields of an entry.
+const maxXattrExtra = 0xF000
+
+// SetUnixAttrs records owner, access/change times and extended attributes
+// for the named entry. It reports whether the entry exists. Times go in the
+// 0x5455 extended timestamp field, the owner in Info-ZIP's 0x7875 field, and
+// extended attributes in a 0x5558 record in the central directory.
+func (a *Archive) SetUnixAttrs(name string, attrs UnixAttrs) (bool, error) {
+	if len(attrs.Xattrs) > 0 && len(makeXattrs(attrs.Xattrs)) > maxXattrExtra {
+		return false, ErrExtraTooLarge
+	}
+	for attr := range attrs.Xattrs {
+		if len(attr) == 0 || len(attr) > 0xFF {
+			return false, ErrExtraTooLarge
+		}
+	}
+
+	i, ok := a.index[name]
+	if !ok {
+		return false, nil
+	}
+	a.entries[i].unix = attrs
+	return true, nil
+}
+
+// makeUnixOwner creates the Info-ZIP Unix extra field (0x7875).
+// Format: 2 bytes ID + 2 bytes size + version(1) + UID size(1) + UID(4) +
+// GID size(1) + GID(4)
+func makeUnixOwner(uid, gid int) []byte {
+	extra := make([]byte, 15)
+	binary.LittleEndian.PutUint16(extra[0:2], extraUnixOwner)
+	binary.LittleEndian.PutUint16(extra[2:4], 11)
+	extra[4] = 1 // version
+	extra[5] = 4
+	binary.LittleEndian.PutUint32(extra[6:10], uint32(uid))
+	extra[10] = 4
+	binary.LittleEndian.PutUint32(extra[11:15], uint32(gid))
+	return extra
+}
+
+// parseUnixOwner extracts UID and GID from extra field 0x7875.
+func parseUnixOwner(extra []byte) (uid, gid int, ok bool) {
+	field, ok := findExtra(extra, extraUnixOwner)
+	if !ok || len(field) < 2 || field[0] != 1 {
+		return 0, 0, false
+	}
+
+	readID := func(b []byte) (int, []byte, bool) {
+		if len(b) < 1 || int(b[0]) > 8 || len(b) < 1+int(b[0]) {
+			return 0, nil, false
+		}
+		var v uint64
+		for i := int(b[0]); i >= 1; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		return int(v), b[1+int(b[0]):], true
+	}
+
+	uid, rest, ok := readID(field[1:])
+	if !ok {
+		return 0, 0, false
+	}
+	gid, _, ok = readID(rest)
+	return uid, gid, ok
+}
+
+// makeXattrs creates the extended attribute extra field (0x5558).
+// Format: 2 bytes ID + 2 bytes size + for each attribute, sorted by name:
+// name length(1) + name + value length(2) + value
+func makeXattrs(xattrs map[string][]byte) []byte {
+	names := make([]string, 0, len(xattrs))
+	for name := range xattrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	extra := make([]byte, 4)
+	binary.LittleEndian.PutUint16(extra[0:2], extraXattrs)
+	for _, name := range names {
+		value := xattrs[name]
+		extra = append(extra, byte(len(name)))
+		extra = append(extra, name...)
+		extra = binary.LittleEndian.AppendUint16(extra, uint16(len(value)))
+		extra = append(extra, value...)
+	}
+	binary.LittleEndian.PutUint16(extra[2:4], uint16(len(extra)-4))
+	return extra
+}
+
+// parseXattrs extracts extended attributes from extra field 0x5558.
+func parseXattrs(extra []byte) map[string][]byte {
+	field, ok := findExtra(extra, extraXattrs)
+	if !ok {
+		return nil
+	}
+
+	xattrs := make(map[string][]byte)
+	for len(field) > 0 {
+		nameLen := int(field[0])
+		if len(field) < 1+nameLen+2 {
+			break
+		}
+		name := string(field[1 : 1+nameLen])
+		valueLen := int(binary.LittleEndian.Uint16(field[1+nameLen : 3+nameLen]))
+		if len(field) < 3+nameLen+valueLen {
+			break
+		}
+		xattrs[name] = append([]byte(nil), field[3+nameLen:3+nameLen+valueLen]...)
+		field = field[3+nameLen+valueLen:]
+	}
+	return xattrs
+}
+
+// parseUnixAttrs reads owner and extended attributes from a central extra
+// field. Access and change times are only in the local header; see
+// parseAccessTimes.
+func parseUnixAttrs(extra []byte) UnixAttrs {
+	var attrs UnixAttrs
+	attrs.UID, attrs.GID, attrs.HasOwner = parseUnixOwner(extra)
+	attrs.Xattrs = parseXattrs(extra)
+	return attrs
+}
+
+// hasAccessTimes reports whether a 0x5455 field's flags announce atime or
+// ctime in the local header.
+func hasAccessTimes(extra []byte) bool {
+	field, ok := findExtra(extra, extraExtendedTS)
+	return ok && len(field) >= 1 && field[0]&0x06 != 0
+}
+
+// parseAccessTimes extracts atime and ctime from a local header's 0x5455
+// extra field.
+func parseAccessTimes(extra []byte) (atime, ctime time.Time) {
+	field, ok := findExtra(extra, extraExtendedTS)
+	if !ok || len(field) < 1 {
+		return
+	}
+	flags, times := field[0], field[1:]
+
+	next := func(bit byte) time.Time {
+		if flags&bit == 0 || len(times) < 4 {
+			return time.Time{}
+		}
+		t := time.Unix(int64(binary.LittleEndian.Uint32(times[0:4])), 0)
+		times = times[4:]
+		return t
+	}
+	next(0x01) // mtime
+	atime = next(0x02)
+	ctime = next(0x04)
+	return
+}
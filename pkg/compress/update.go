@@ -0,0 +1,149 @@
+package compress
+
+import "os"
+
+// OpenArchive returns an Archive holding the entries of an existing ZIP
+// archive, for adding, replacing and removing entries. Existing entries keep
+// their compressed data, so Bytes writes unchanged entries back verbatim
+// instead of recompressing them; only the central directory is rebuilt.
+// Archive and file comments are kept; a signature is not, so call Sign again
+// to re-sign the result.
+func OpenArchive(c *Compressor, data []byte) (*Archive, error) {
+	files, err := ListFilesStrict(data, Limits{})
+	if err != nil {
+		return nil, err
+	}
+
+	a := NewArchive(c)
+	for _, info := range files {
+		if err := a.addRaw(data, info, info.Name); err != nil {
+			return nil, err
+		}
+	}
+	if a.comment, err = ReadComment(data); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// CopyRaw adds an entry of another archive without recompressing it.
+// src is the source archive and info one of its entries, as returned by
+// ListFiles or RecoverFiles. The compressed data, CRC-32, DOS time and extra
+// fields (including the 0x554E vocabulary record) are written byte-for-byte,
+// and the file comment is kept.
+// An entry with the same name is replaced.
+func (a *Archive) CopyRaw(src []byte, info *FileInfo) error {
+	return a.CopyRawAs(src, info, info.Name)
+}
+
+// CopyRawAs is like CopyRaw but stores the entry under a new name.
+func (a *Archive) CopyRawAs(src []byte, info *FileInfo, name string) error {
+	if err := checkLocalHeader(src, info); err != nil {
+		return err
+	}
+	return a.addRaw(src, info, name)
+}
+
+// addRaw adds the entry described by info under name, copying its
+// compressed data, CRC-32, DOS time and extra fields from src.
+func (a *Archive) addRaw(src []byte, info *FileInfo, name string) error {
+	hdr, err := parseLocalHeader(src, int(info.Offset))
+	if err != nil {
+		return err
+	}
+
+	end := hdr.dataOffset + int(info.CompSize)
+	if end > len(src) {
+		return ErrCorrupted
+	}
+
+	central := info.centralExtra
+	if central == nil {
+		central = hdr.extra
+	}
+
+	var device *[2]uint32
+	if info.Mode&os.ModeDevice != 0 {
+		device = &[2]uint32{info.DevMajor, info.DevMinor}
+	}
+
+	a.put(archiveEntry{
+		name:         name,
+		compressed:   src[hdr.dataOffset:end],
+		size:         info.Size,
+		method:       info.Method,
+		crc:          info.CRC32,
+		modTime:      info.ModTime,
+		mode:         info.Mode,
+		vocabInfo:    info.Vocab,
+		sha256:       info.SHA256,
+		comment:      info.Comment,
+		unix:         info.Unix,
+		hardLink:     info.HardLink,
+		device:       device,
+		isSymlink:    info.Mode&os.ModeSymlink != 0,
+		encryption:   info.Encryption,
+		raw:          true,
+		headerMethod: hdr.rawMethod,
+		flags:        hdr.flags &^ flagDataDesc,
+		dosTime:      hdr.dosTime,
+		dosDate:      hdr.dosDate,
+		extraLocal:   hdr.extra,
+		extraCentral: central,
+	})
+	return nil
+}
+
+// Lookup returns metadata for the named entry. Directory names end in "/".
+func (a *Archive) Lookup(name string) (*FileInfo, bool) {
+	i, ok := a.index[name]
+	if !ok {
+		return nil, false
+	}
+	entry := &a.entries[i]
+	info := &FileInfo{
+		Name:     entry.name,
+		Size:     entry.size,
+		CompSize: int64(len(entry.compressed)),
+		Method:   entry.method,
+		CRC32:    entry.crc,
+		ModTime:  entry.modTime,
+		Mode:     entry.mode,
+		Vocab:    entry.vocabInfo,
+		SHA256:   entry.sha256,
+		Comment:  entry.comment,
+		Unix:     entry.unix,
+		HardLink: entry.hardLink,
+
+		Encryption: entry.encryption,
+	}
+	if entry.device != nil {
+		info.DevMajor, info.DevMinor = entry.device[0], entry.device[1]
+	}
+	return info, true
+}
+
+// Remove deletes the named entry and reports whether it was present.
+// Hard links to a removed entry are left dangling.
+func (a *Archive) Remove(name string) bool {
+	i, ok := a.index[name]
+	if !ok {
+		return false
+	}
+
+	a.entries = append(a.entries[:i], a.entries[i+1:]...)
+	delete(a.index, name)
+	for j := i; j < len(a.entries); j++ {
+		a.index[a.entries[j].name] = j
+	}
+	return true
+}
+
+// Names returns the entry names in archive order.
+func (a *Archive) Names() []string {
+	names := make([]string, len(a.entries))
+	for i, entry := range a.entries {
+		names[i] = entry.name
+	}
+	return names
+}
@@ -0,0 +1,313 @@
+package compress
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"strings"
+)
+
+// Data descriptor (written after entry data when flag bit 3 is set)
+const (
+	sigDataDescriptor = 0x08074b50
+	flagDataDesc      = 0x0008 // Bit 3: sizes and CRC follow the data
+)
+
+// ErrInconsistent reports a local header that disagrees with its central
+// directory record.
+var ErrInconsistent = errors.New("compress: local header does not match central directory")
+
+// MismatchError describes one field that differs between an entry's local
+// header and its central directory record. It wraps ErrInconsistent.
+type MismatchError struct {
+	Name    string // entry name from the central directory
+	Field   string // "name", "method", "crc", "compressed size", ...
+	Local   string // value in the local header
+	Central string // value in the central directory
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s: %s mismatch (local %s, central %s)", e.Name, e.Field, e.Local, e.Central)
+}
+
+func (e *MismatchError) Unwrap() error {
+	return ErrInconsistent
+}
+
+// localHeader holds the fields of a ZIP local file header.
+type localHeader struct {
+	flags      uint16
+	method     Method // real compression method
+	rawMethod  Method // method field as stored (99 for WinZip AES)
+	encryption Encryption
+	aesVersion uint16
+	dosTime    uint16
+	dosDate    uint16
+	crc        uint32
+	compSize   uint32
+	uncompSize uint32
+	name       string
+	extra      []byte
+	dataOffset int // offset of the entry data
+}
+
+// parseLocalHeader reads the local file header at offset.
+func parseLocalHeader(data []byte, offset int) (*localHeader, error) {
+	if offset < 0 || offset+30 > len(data) {
+		return nil, ErrCorrupted
+	}
+	if binary.LittleEndian.Uint32(data[offset:offset+4]) != sigLocalFile {
+		return nil, ErrCorrupted
+	}
+
+	nameLen := int(binary.LittleEndian.Uint16(data[offset+26 : offset+28]))
+	extraLen := int(binary.LittleEndian.Uint16(data[offset+28 : offset+30]))
+	if offset+30+nameLen+extraLen > len(data) {
+		return nil, ErrCorrupted
+	}
+
+	hdr := &localHeader{
+		flags:      binary.LittleEndian.Uint16(data[offset+6 : offset+8]),
+		method:     Method(binary.LittleEndian.Uint16(data[offset+8 : offset+10])),
+		dosTime:    binary.LittleEndian.Uint16(data[offset+10 : offset+12]),
+		dosDate:    binary.LittleEndian.Uint16(data[offset+12 : offset+14]),
+		crc:        binary.LittleEndian.Uint32(data[offset+14 : offset+18]),
+		compSize:   binary.LittleEndian.Uint32(data[offset+18 : offset+22]),
+		uncompSize: binary.LittleEndian.Uint32(data[offset+22 : offset+26]),
+		name:       string(data[offset+30 : offset+30+nameLen]),
+		extra:      data[offset+30+nameLen : offset+30+nameLen+extraLen],
+		dataOffset: offset + 30 + nameLen + extraLen,
+	}
+	hdr.rawMethod = hdr.method
+	hdr.method, hdr.encryption, hdr.aesVersion = resolveEncryption(hdr.method, hdr.flags, hdr.extra)
+	return hdr, nil
+}
+
+// ListFilesStrict is like ListFilesWithLimits but validates the archive
+// instead of returning whatever it can read. A truncated or malformed
+// central directory returns ErrCorrupted, and every record is cross-checked
+// against its local header (name, method, sizes, CRC-32 and the extra
+// fields that affect decoding: vocabulary, AES, SHA-256, hard link and
+// device); the first difference is returned as a *MismatchError.
+func ListFilesStrict(data []byte, limits Limits) ([]*FileInfo, error) {
+	files, err := listFiles(data, limits, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range files {
+		if err := checkLocalHeader(data, info); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// checkLocalHeader compares an entry's local header with its central record.
+func checkLocalHeader(data []byte, info *FileInfo) error {
+	hdr, err := parseLocalHeader(data, int(info.Offset))
+	if err != nil {
+		return fmt.Errorf("%s: local header: %w", info.Name, err)
+	}
+
+	mismatch := func(field, local, central string) error {
+		return &MismatchError{Name: info.Name, Field: field, Local: local, Central: central}
+	}
+
+	if hdr.name != info.Name {
+		return mismatch("name", hdr.name, info.Name)
+	}
+	if hdr.method != info.Method {
+		return mismatch("method", hdr.method.String(), info.Method.String())
+	}
+	if hdr.encryption != info.Encryption {
+		return mismatch("encryption", hdr.encryption.String(), info.Encryption.String())
+	}
+
+	// With a data descriptor the local CRC and sizes are zero
+	if hdr.flags&flagDataDesc == 0 {
+		if hdr.crc != info.CRC32 {
+			return mismatch("crc", fmt.Sprintf("%08x", hdr.crc), fmt.Sprintf("%08x", info.CRC32))
+		}
+		if int64(hdr.compSize) != info.CompSize {
+			return mismatch("compressed size", fmt.Sprint(hdr.compSize), fmt.Sprint(info.CompSize))
+		}
+		if int64(hdr.uncompSize) != info.Size {
+			return mismatch("uncompressed size", fmt.Sprint(hdr.uncompSize), fmt.Sprint(info.Size))
+		}
+	}
+
+	if hdr.dataOffset+int(info.CompSize) > len(data) {
+		return fmt.Errorf("%s: %w", info.Name, ErrCorrupted)
+	}
+
+	// Extra fields that change how the entry is decoded or extracted must
+	// agree: the local copy is what a streaming reader would use.
+	for _, f := range decodingExtras {
+		local, inLocal := findExtra(hdr.extra, f.id)
+		central, inCentral := findExtra(info.centralExtra, f.id)
+		if inLocal != inCentral || !bytes.Equal(local, central) {
+			return mismatch(f.field, extraString(local, inLocal), extraString(central, inCentral))
+		}
+	}
+
+	return nil
+}
+
+// decodingExtras are the extra fields checkLocalHeader compares. The
+// others (timestamps, owner, extended attributes) only describe the file,
+// and some legitimately differ: the central timestamp has no access time.
+var decodingExtras = []struct {
+	id    uint16
+	field string
+}{
+	{extraVocabInfo, "vocabulary"},
+	{extraWinZipAES, "aes"},
+	{extraContentHash, "sha256"},
+	{extraHardLink, "hard link"},
+	{extraDevice, "device"},
+}
+
+// extraString formats an extra field's data for a *MismatchError.
+func extraString(field []byte, ok bool) string {
+	if !ok {
+		return "absent"
+	}
+	return fmt.Sprintf("%x", field)
+}
+
+// RecoverFiles salvages entries from an archive whose central directory is
+// damaged or missing, like zip -FF. It scans for local file signatures and
+// returns every entry whose data is complete, skipping truncated ones.
+// Unix modes, file comments and extended attributes come from any central
+// directory records that are still readable; otherwise entries get 0644 (0755 for directories).
+func RecoverFiles(data []byte, limits Limits) ([]*FileInfo, error) {
+	// Modes and comments live only in the central directory; use what
+	// survives of it
+	central := make(map[int64]*FileInfo)
+	if cdFiles, err := listFiles(data, Limits{}, false); err == nil {
+		for _, f := range cdFiles {
+			central[f.Offset] = f
+		}
+	}
+
+	var files []*FileInfo
+	for offset := 0; offset+30 <= len(data); offset++ {
+		if binary.LittleEndian.Uint32(data[offset:offset+4]) != sigLocalFile {
+			continue
+		}
+
+		hdr, err := parseLocalHeader(data, offset)
+		if err != nil {
+			continue
+		}
+
+		crc, compSize, uncompSize := hdr.crc, hdr.compSize, hdr.uncompSize
+		if hdr.flags&flagDataDesc != 0 {
+			var ok bool
+			crc, compSize, uncompSize, ok = findDataDescriptor(data, hdr)
+			if !ok {
+				continue
+			}
+		}
+
+		end := hdr.dataOffset + int(compSize)
+		if end > len(data) {
+			continue // truncated entry
+		}
+
+		info := &FileInfo{
+			Name:     hdr.name,
+			Size:     int64(uncompSize),
+			CompSize: int64(compSize),
+			Method:   hdr.method,
+			CRC32:    crc,
+			ModTime:  dosToTime(hdr.dosTime, hdr.dosDate),
+			Mode:     0644,
+			Offset:   int64(offset),
+
+			Encryption: hdr.encryption,
+
+			flags:      hdr.flags,
+			dosTime:    hdr.dosTime,
+			aesVersion: hdr.aesVersion,
+		}
+		if mtime, ok := parseExtendedTimestamp(hdr.extra); ok {
+			info.ModTime = mtime
+		}
+		if vocab, ok := parseVocabInfo(hdr.extra); ok {
+			info.Vocab = vocab
+		}
+		info.SHA256, _ = parseContentHash(hdr.extra)
+		info.Unix = parseUnixAttrs(hdr.extra)
+		info.Unix.Atime, info.Unix.Ctime = parseAccessTimes(hdr.extra)
+		info.HardLink = parseHardLink(hdr.extra)
+		info.DevMajor, info.DevMinor = parseDevice(hdr.extra)
+		if cd, ok := central[info.Offset]; ok {
+			info.Mode = cd.Mode
+			info.Comment = cd.Comment
+			info.Unix.Xattrs = cd.Unix.Xattrs
+		} else if strings.HasSuffix(info.Name, "/") {
+			info.Mode = os.ModeDir | 0755
+		}
+
+		files = append(files, info)
+		if err := limits.checkEntries(len(files)); err != nil {
+			return nil, err
+		}
+
+		// Resume after this entry's data
+		offset = end - 1
+	}
+
+	if len(files) == 0 {
+		return nil, ErrInvalidFormat
+	}
+
+	return files, nil
+}
+
+// findDataDescriptor locates the data descriptor that follows the data of
+// the entry with local header hdr. It accepts a descriptor only if its
+// compressed size matches its distance from the data start. The descriptor
+// signature is optional: an unsigned descriptor must also check out, by
+// the CRC-32 of the data before it for unencrypted stored entries, and
+// otherwise by the ZIP record that follows it (or the end of the data).
+func findDataDescriptor(data []byte, hdr *localHeader) (crc, compSize, uncompSize uint32, ok bool) {
+	dataOffset := hdr.dataOffset
+	for pos := dataOffset; pos+12 <= len(data); pos++ {
+		desc := data[pos:]
+		if len(desc) >= 16 && binary.LittleEndian.Uint32(desc) == sigDataDescriptor {
+			size := binary.LittleEndian.Uint32(desc[8:12])
+			if int(size) == pos-dataOffset {
+				return binary.LittleEndian.Uint32(desc[4:8]), size, binary.LittleEndian.Uint32(desc[12:16]), true
+			}
+		}
+
+		crc, size, uncompSize := binary.LittleEndian.Uint32(desc), binary.LittleEndian.Uint32(desc[4:8]),
+			binary.LittleEndian.Uint32(desc[8:12])
+		if int(size) != pos-dataOffset {
+			continue
+		}
+		if hdr.method == MethodStore && hdr.encryption == EncryptionNone {
+			if uncompSize == size && crc32.ChecksumIEEE(data[dataOffset:pos]) == crc {
+				return crc, size, uncompSize, true
+			}
+			continue
+		}
+		if next := desc[12:]; len(next) == 0 || (len(next) >= 4 && isRecordSignature(binary.LittleEndian.Uint32(next))) {
+			return crc, size, uncompSize, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// isRecordSignature reports whether sig starts a local header, central
+// directory record or end of central directory record.
+func isRecordSignature(sig uint32) bool {
+	return sig == sigLocalFile || sig == sigCentralDir || sig == sigEndCentralD
+}
@@ -0,0 +1,171 @@
+package detect
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// BinaryFormat represents a binary file format recognised by its magic
+// number.
+type BinaryFormat int
+
+const (
+	BinaryNone BinaryFormat = iota
+
+	// Compressed data and archives (TypeCompressed)
+	BinaryGzip
+	BinaryBzip2
+	BinaryXZ
+	BinaryZstd
+	BinaryLZ4
+	BinaryZIP // also .unz, .jar, .docx and other ZIP containers
+	Binary7z
+	BinaryRAR
+
+	// Images, audio, video and fonts (TypeMedia)
+	BinaryPNG
+	BinaryJPEG
+	BinaryGIF
+	BinaryWebP
+	BinaryMP4 // ISO base media: MP4, MOV, M4A, HEIC
+	BinaryMP3
+	BinaryOgg
+	BinaryFLAC
+	BinaryMatroska // MKV, WebM
+	BinaryWOFF
+	BinaryWOFF2
+
+	// Executables (TypeExecutable)
+	BinaryELF
+	BinaryPE
+	BinaryMachO
+)
+
+func (f BinaryFormat) String() string {
+	names := []string{
+		"None", "gzip", "bzip2", "xz", "zstd", "LZ4", "ZIP", "7z", "RAR",
+		"PNG", "JPEG", "GIF", "WebP", "MP4", "MP3", "Ogg", "FLAC", "Matroska",
+		"WOFF", "WOFF2", "ELF", "PE", "Mach-O",
+	}
+	if int(f) < len(names) {
+		return names[f]
+	}
+	return "Unknown"
+}
+
+// Type returns the data type of the format: TypeCompressed, TypeMedia or
+// TypeExecutable.
+func (f BinaryFormat) Type() Type {
+	switch {
+	case f >= BinaryGzip && f <= BinaryRAR:
+		return TypeCompressed
+	case f >= BinaryPNG && f <= BinaryWOFF2:
+		return TypeMedia
+	case f >= BinaryELF && f <= BinaryMachO:
+		return TypeExecutable
+	default:
+		return TypeBinary
+	}
+}
+
+// magicNumbers lists formats identified by a fixed prefix. Formats whose
+// magic is plain ASCII text are checked with their header structure in
+// detectBinaryFormat instead.
+var magicNumbers = []struct {
+	magic  string
+	format BinaryFormat
+}{
+	{"\x1f\x8b", BinaryGzip},
+	{"\xfd7zXZ\x00", BinaryXZ},
+	{"\x28\xb5\x2f\xfd", BinaryZstd},
+	{"\x04\x22\x4d\x18", BinaryLZ4},
+	{"PK\x03\x04", BinaryZIP},
+	{"PK\x05\x06", BinaryZIP}, // empty archive
+	{"PK\x07\x08", BinaryZIP}, // spanned archive
+	{"7z\xbc\xaf\x27\x1c", Binary7z},
+	{"Rar!\x1a\x07", BinaryRAR},
+	{"\x89PNG\r\n\x1a\n", BinaryPNG},
+	{"\xff\xd8\xff", BinaryJPEG},
+	{"GIF87a", BinaryGIF},
+	{"GIF89a", BinaryGIF},
+	{"\x1a\x45\xdf\xa3", BinaryMatroska},
+	{"\x7fELF", BinaryELF},
+	{"\xfe\xed\xfa\xce", BinaryMachO},
+	{"\xfe\xed\xfa\xcf", BinaryMachO},
+	{"\xce\xfa\xed\xfe", BinaryMachO},
+	{"\xcf\xfa\xed\xfe", BinaryMachO},
+}
+
+// detectBinaryFormat identifies compressed, media and executable formats
+// by their magic numbers.
+func detectBinaryFormat(data []byte) BinaryFormat {
+	for _, m := range magicNumbers {
+		if bytes.HasPrefix(data, []byte(m.magic)) {
+			return m.format
+		}
+	}
+
+	switch {
+	case len(data) >= 4 && string(data[:3]) == "BZh" && data[3] >= '1' && data[3] <= '9':
+		return BinaryBzip2
+	case isID3(data):
+		return BinaryMP3
+	case len(data) >= 27 && string(data[:4]) == "OggS" && data[4] == 0 && data[5]&^0x07 == 0:
+		// Stream structure version 0, and only the three defined header
+		// type flags
+		return BinaryOgg
+	case len(data) >= 8 && string(data[:4]) == "fLaC" && data[4]&0x7f == 0 && string(data[5:8]) == "\x00\x00\x22":
+		// The first metadata block is always a 34-byte STREAMINFO
+		return BinaryFLAC
+	case len(data) >= 8 && string(data[:4]) == "wOFF" && isSfntFlavor(data[4:8]):
+		return BinaryWOFF
+	case len(data) >= 8 && string(data[:4]) == "wOF2" && isSfntFlavor(data[4:8]):
+		return BinaryWOFF2
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return BinaryWebP
+	case len(data) >= 12 && string(data[4:8]) == "ftyp":
+		return BinaryMP4
+	case len(data) >= 4 && data[0] == 0xff && (data[1] == 0xfb || data[1] == 0xf3 || data[1] == 0xf2):
+		// MPEG-1/2 Layer III frame sync without an ID3 tag
+		return BinaryMP3
+	case isPE(data):
+		return BinaryPE
+	case len(data) >= 8 && string(data[:4]) == "\xca\xfe\xba\xbe" && binary.BigEndian.Uint32(data[4:]) < 0x20:
+		// Universal Mach-O; a Java class file has the same magic, followed
+		// by a version of at least 45
+		return BinaryMachO
+	}
+	return BinaryNone
+}
+
+// isID3 checks for an ID3v2 tag header: version 2 to 4, no undefined flag
+// bits and a syncsafe size, whose bytes never have the top bit set.
+func isID3(data []byte) bool {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return false
+	}
+	if data[3] < 2 || data[3] > 4 || data[4] == 0xff || data[5]&0x0f != 0 {
+		return false
+	}
+	return (data[6]|data[7]|data[8]|data[9])&0x80 == 0
+}
+
+// isSfntFlavor reports whether a WOFF header's flavor names an sfnt font:
+// TrueType (0x00010000 or "true"), CFF ("OTTO") or PostScript ("typ1").
+func isSfntFlavor(flavor []byte) bool {
+	switch string(flavor) {
+	case "\x00\x01\x00\x00", "OTTO", "true", "typ1":
+		return true
+	}
+	return false
+}
+
+// isPE checks for an MZ stub whose e_lfanew points at a PE signature, as
+// text can start with "MZ".
+func isPE(data []byte) bool {
+	if len(data) < 0x40 || string(data[:2]) != "MZ" {
+		return false
+	}
+	off := int(binary.LittleEndian.Uint32(data[0x3c:]))
+	return off >= 0x40 && off <= len(data)-4 && string(data[off:off+4]) == "PE\x00\x00"
+}
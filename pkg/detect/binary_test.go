@@ -0,0 +1,88 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectBinaryFormats(t *testing.T) {
+	pe := make([]byte, 0x100)
+	copy(pe, "MZ")
+	pe[0x3c] = 0x80
+	copy(pe[0x80:], "PE\x00\x00")
+
+	testCases := []struct {
+		name string
+		data string
+		want BinaryFormat
+	}{
+		{"gzip", "\x1f\x8b\x08\x00\x00\x00\x00\x00", BinaryGzip},
+		{"bzip2", "BZh91AY&SY", BinaryBzip2},
+		{"xz", "\xfd7zXZ\x00\x00\x04", BinaryXZ},
+		{"zstd", "\x28\xb5\x2f\xfd\x04\x00", BinaryZstd},
+		{"LZ4", "\x04\x22\x4d\x18\x64\x40", BinaryLZ4},
+		{"ZIP", "PK\x03\x04\x14\x00\x00\x00", BinaryZIP},
+		{"7z", "7z\xbc\xaf\x27\x1c\x00\x04", Binary7z},
+		{"RAR", "Rar!\x1a\x07\x01\x00", BinaryRAR},
+		{"PNG", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", BinaryPNG},
+		{"JPEG", "\xff\xd8\xff\xe0\x00\x10JFIF", BinaryJPEG},
+		{"GIF", "GIF89a\x01\x00\x01\x00", BinaryGIF},
+		{"WebP", "RIFF\x24\x00\x00\x00WEBPVP8 ", BinaryWebP},
+		{"MP4", "\x00\x00\x00\x20ftypisom\x00\x00\x02\x00", BinaryMP4},
+		{"MP3 with ID3", "ID3\x04\x00\x00\x00\x00\x01\x76", BinaryMP3},
+		{"MP3 frame", "\xff\xfb\x90\x64\x00", BinaryMP3},
+		{"Ogg", "OggS\x00\x02" + strings.Repeat("\x00", 21), BinaryOgg},
+		{"FLAC", "fLaC\x00\x00\x00\x22", BinaryFLAC},
+		{"Matroska", "\x1a\x45\xdf\xa3\x9f\x42\x86", BinaryMatroska},
+		{"WOFF", "wOFFOTTO\x00\x00\x10\x00", BinaryWOFF},
+		{"WOFF2", "wOF2\x00\x01\x00\x00", BinaryWOFF2},
+		{"ELF", "\x7fELF\x02\x01\x01\x00", BinaryELF},
+		{"PE", string(pe), BinaryPE},
+		{"Mach-O 64", "\xcf\xfa\xed\xfe\x07\x00\x00\x01", BinaryMachO},
+		{"universal Mach-O", "\xca\xfe\xba\xbe\x00\x00\x00\x02", BinaryMachO},
+		{"Java class", "\xca\xfe\xba\xbe\x00\x00\x00\x34", BinaryNone},
+		{"text starting with MZ", "MZ is a text file" + strings.Repeat(" ", 100), BinaryNone},
+		{"text", "Hello, world", BinaryNone},
+		{"text starting with ID3", "ID3 tags hold the title and artist of an MP3 file.", BinaryNone},
+		{"text starting with OggS", "OggS pages carry the Vorbis and Opus streams.", BinaryNone},
+		{"text starting with fLaC", "fLaC is the marker of a FLAC stream.", BinaryNone},
+		{"text starting with wOFF", "wOFF files wrap TrueType and CFF fonts.", BinaryNone},
+		{"uncompressed image", "BM\x36\x00\x0c\x00\x00\x00", BinaryNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := detectBinaryFormat([]byte(tc.data)); got != tc.want {
+				t.Errorf("detectBinaryFormat: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectBinaryTypes(t *testing.T) {
+	testCases := []struct {
+		data string
+		want Type
+	}{
+		{"\x1f\x8b\x08\x00" + strings.Repeat("package main\n", 20), TypeCompressed},
+		{"\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 100), TypeMedia},
+		{"\x7fELF\x02\x01\x01\x00" + strings.Repeat("\x00", 100), TypeExecutable},
+	}
+
+	for _, tc := range testCases {
+		profile := Detect([]byte(tc.data))
+		if profile.Type != tc.want {
+			t.Errorf("%v: got type %v, want %v", profile.Binary, profile.Type, tc.want)
+		}
+		if profile.Binary.Type() != tc.want {
+			t.Errorf("%v.Type(): got %v, want %v", profile.Binary, profile.Binary.Type(), tc.want)
+		}
+	}
+
+	// A text file starting with a media magic is still text, and is
+	// compressed rather than stored
+	text := strings.Repeat("ID3 tags hold the title, the artist and the album of a song. ", 5)
+	if profile := Detect([]byte(text)); profile.Type != TypeText || profile.Binary != BinaryNone {
+		t.Errorf("text starting with ID3: got type %v, format %v", profile.Type, profile.Binary)
+	}
+}
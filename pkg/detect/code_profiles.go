@@ -0,0 +1,2233 @@
+// Code generated by mkngram. DO NOT EDIT.
+
+package detect
+
+// codeLangProfiles holds up to 500 of the most frequent tokens of each programming
+// language's training code, with their frequency in parts per million.
+var codeLangProfiles = map[CodeLang][]NGram{
+	CodeLangGo: {
+		{".", 64893},
+		{"(", 54255},
+		{")", 54255},
+		{"{", 31914},
+		{"}", 31914},
+		{",", 30851},
+		{"}$", 28723},
+		{"s", 26595},
+		{"^}", 25531},
+		{"{$", 25531},
+		{")$", 24468},
+		{"[", 13829},
+		{"]", 13829},
+		{"string", 13829},
+		{":=", 11702},
+		{"\"", 10638},
+		{"Store", 10638},
+		{"^return", 10638},
+		{"key", 10638},
+		{"nil", 10638},
+		{"return", 10638},
+		{".$", 9574},
+		{"//", 9574},
+		{"^//", 9574},
+		{"^s", 9574},
+		{"err", 9574},
+		{"mu", 9574},
+		{"^func", 8510},
+		{"func", 8510},
+		{"item", 8510},
+		{"time", 8510},
+		{"\"$", 7446},
+		{"*", 7446},
+		{"^\"", 7446},
+		{"^if", 7446},
+		{"if", 7446},
+		{"items", 7446},
+		{":", 6382},
+		{"keys", 6382},
+		{"value", 6382},
+		{"=", 5319},
+		{"Item", 5319},
+		{"byte", 5319},
+		{"error", 5319},
+		{"for", 5319},
+		{"k", 5319},
+		{";", 4255},
+		{"Expires", 4255},
+		{"^for", 4255},
+		{"i", 4255},
+		{"interval", 4255},
+		{"janitor", 4255},
+		{"m", 4255},
+		{"map", 4255},
+		{"stop", 4255},
+		{"struct", 4255},
+		{"the", 4255},
+		{"ttl", 4255},
+		{"!=", 3191},
+		{"Duration", 3191},
+		{"ErrNotFound", 3191},
+		{"New", 3191},
+		{"Value", 3191},
+		{"^defer", 3191},
+		{"^item", 3191},
+		{"^type", 3191},
+		{"data", 3191},
+		{"defer", 3191},
+		{"interface", 3191},
+		{"is", 3191},
+		{"nil$", 3191},
+		{"path", 3191},
+		{"prefix", 3191},
+		{"returns", 3191},
+		{"store", 3191},
+		{"ticker", 3191},
+		{"type", 3191},
+		{"!", 2127},
+		{"&", 2127},
+		{",$", 2127},
+		{":$", 2127},
+		{"<-", 2127},
+		{"Close", 2127},
+		{"Codec", 2127},
+		{"Decode", 2127},
+		{"Errorf", 2127},
+		{"Get", 2127},
+		{"Key", 2127},
+		{"Keys", 2127},
+		{"Lock", 2127},
+		{"Now", 2127},
+		{"RLock", 2127},
+		{"RUnlock", 2127},
+		{"Set", 2127},
+		{"Time", 2127},
+		{"Unlock", 2127},
+		{"^case", 2127},
+		{"^items", 2127},
+		{"^stop", 2127},
+		{"^var", 2127},
+		{"a", 2127},
+		{"c", 2127},
+		{"case", 2127},
+		{"chan", 2127},
+		{"ctx", 2127},
+		{"err$", 2127},
+		{"expire", 2127},
+		{"fmt", 2127},
+		{"make", 2127},
+		{"now", 2127},
+		{"ok", 2127},
+		{"range", 2127},
+		{"string$", 2127},
+		{"v", 2127},
+		{"var", 2127},
+		{"with", 2127},
+		{"&&", 1063},
+		{"($", 1063},
+		{"++", 1063},
+		{"/", 1063},
+		{"<", 1063},
+		{">", 1063},
+		{"A", 1063},
+		{"Add", 1063},
+		{"After", 1063},
+		{"C", 1063},
+		{"Context", 1063},
+		{"Encode", 1063},
+		{"Err", 1063},
+		{"HasPrefix", 1063},
+		{"IsZero", 1063},
+		{"Item$", 1063},
+		{"NewTicker", 1063},
+		{"Package", 1063},
+		{"RWMutex", 1063},
+		{"RWMutex$", 1063},
+		{"Stop", 1063},
+		{"Strings", 1063},
+		{"Time$", 1063},
+		{"]$", 1063},
+		{"^)", 1063},
+		{"^Decode", 1063},
+		{"^Encode", 1063},
+		{"^Expires", 1063},
+		{"^Key", 1063},
+		{"^Value", 1063},
+		{"^_", 1063},
+		{"^close", 1063},
+		{"^data", 1063},
+		{"^delete", 1063},
+		{"^go", 1063},
+		{"^import", 1063},
+		{"^keys", 1063},
+		{"^m", 1063},
+		{"^mu", 1063},
+		{"^package", 1063},
+		{"^select", 1063},
+		{"^sort", 1063},
+		{"^ticker", 1063},
+		{"_", 1063},
+		{"an", 1063},
+		{"and", 1063},
+		{"append", 1063},
+		{"byte$", 1063},
+		{"close", 1063},
+		{"concurrent", 1063},
+		{"context", 1063},
+		{"delete", 1063},
+		{"does", 1063},
+		{"empty", 1063},
+		{"error$", 1063},
+		{"errors", 1063},
+		{"exist", 1063},
+		{"expires", 1063},
+		{"expiry", 1063},
+		{"given", 1063},
+		{"go", 1063},
+		{"i$", 1063},
+		{"import", 1063},
+		{"in", 1063},
+		{"item$", 1063},
+		{"its", 1063},
+		{"keeps", 1063},
+		{"keys$", 1063},
+		{"len", 1063},
+		{"load", 1063},
+		{"memory", 1063},
+		{"never", 1063},
+		{"not", 1063},
+		{"of", 1063},
+		{"optional", 1063},
+		{"package", 1063},
+		{"pairs", 1063},
+		{"readFile", 1063},
+		{"return$", 1063},
+		{"returned", 1063},
+		{"s$", 1063},
+		{"safe", 1063},
+		{"select", 1063},
+		{"sort", 1063},
+		{"sorted", 1063},
+		{"starts", 1063},
+		{"stops", 1063},
+		{"store$", 1063},
+		{"stored", 1063},
+		{"stores", 1063},
+		{"strings", 1063},
+		{"sync", 1063},
+		{"under", 1063},
+		{"use", 1063},
+		{"when", 1063},
+		{"zero", 1063},
+	},
+	CodeLangPython: {
+		{":", 57309},
+		{"(", 56140},
+		{")", 56140},
+		{".", 54970},
+		{",", 37426},
+		{":$", 33918},
+		{"=", 33918},
+		{"\"", 32748},
+		{"self", 31578},
+		{")$", 26900},
+		{"[", 17543},
+		{"]", 17543},
+		{"item", 16374},
+		{"quantity", 16374},
+		{"def", 12865},
+		{"sku", 12865},
+		{"str", 12865},
+		{"->", 11695},
+		{"^def", 11695},
+		{"None", 10526},
+		{"items", 10526},
+		{"f", 9356},
+		{"if", 9356},
+		{"import", 9356},
+		{"^return", 8187},
+		{"i", 8187},
+		{"path", 8187},
+		{"return", 8187},
+		{"Item", 7017},
+		{"^if", 7017},
+		{"^item", 7017},
+		{"in", 7017},
+		{"int", 7017},
+		{"^import", 5847},
+		{"^self", 5847},
+		{"for", 5847},
+		{"inventory", 5847},
+		{"name", 5847},
+		{"record", 5847},
+		{"InventoryError", 4678},
+		{"List", 4678},
+		{"from", 4678},
+		{"parser", 4678},
+		{"tag", 4678},
+		{"\"$", 3508},
+		{"Dict", 3508},
+		{"Inventory", 3508},
+		{"Optional", 3508},
+		{"Path", 3508},
+		{"^\"", 3508},
+		{"^class", 3508},
+		{"^from", 3508},
+		{"^parser", 3508},
+		{"^raise", 3508},
+		{"args", 3508},
+		{"class", 3508},
+		{"count", 3508},
+		{"get", 3508},
+		{"item$", 3508},
+		{"json", 3508},
+		{"load", 3508},
+		{"raise", 3508},
+		{"tags", 3508},
+		{"tmp", 3508},
+		{"{", 3508},
+		{"}", 3508},
+		{"+=", 2339},
+		{",$", 2339},
+		{"/", 2339},
+		{"=$", 2339},
+		{"==", 2339},
+		{"]$", 2339},
+		{"^async", 2339},
+		{"^count", 2339},
+		{"^for", 2339},
+		{"^inventory", 2339},
+		{"^log", 2339},
+		{"^with", 2339},
+		{"__name__", 2339},
+		{"add", 2339},
+		{"add_argument", 2339},
+		{"argparse", 2339},
+		{"argv", 2339},
+		{"as", 2339},
+		{"async", 2339},
+		{"data", 2339},
+		{"dataclass", 2339},
+		{"else", 2339},
+		{"encoding", 2339},
+		{"entry", 2339},
+		{"field", 2339},
+		{"find", 2339},
+		{"is", 2339},
+		{"len", 2339},
+		{"log", 2339},
+		{"logging", 2339},
+		{"main", 2339},
+		{"open", 2339},
+		{"os", 2339},
+		{"quantity$", 2339},
+		{"source", 2339},
+		{"str$", 2339},
+		{"sys", 2339},
+		{"to_dict", 2339},
+		{"values", 2339},
+		{"with", 2339},
+		{"}$", 2339},
+		{"#!/", 1169},
+		{"**", 1169},
+		{"+=$", 1169},
+		{"-=", 1169},
+		{"<", 1169},
+		{"<=", 1169},
+		{"@", 1169},
+		{"ArgumentParser", 1169},
+		{"Exception", 1169},
+		{"KeyError", 1169},
+		{"None$", 1169},
+		{"Optional$", 1169},
+		{"Path$", 1169},
+		{"^#!/", 1169},
+		{"^@", 1169},
+		{"^args", 1169},
+		{"^continue", 1169},
+		{"^data", 1169},
+		{"^del", 1169},
+		{"^elif", 1169},
+		{"^else", 1169},
+		{"^except", 1169},
+		{"^json", 1169},
+		{"^key", 1169},
+		{"^name", 1169},
+		{"^os", 1169},
+		{"^print", 1169},
+		{"^quantity", 1169},
+		{"^sku", 1169},
+		{"^sys", 1169},
+		{"^tags", 1169},
+		{"^tmp", 1169},
+		{"^try", 1169},
+		{"__doc__", 1169},
+		{"__init__", 1169},
+		{"__len__", 1169},
+		{"__repr__", 1169},
+		{"argparse$", 1169},
+		{"bin", 1169},
+		{"continue", 1169},
+		{"continue$", 1169},
+		{"count$", 1169},
+		{"dataclass$", 1169},
+		{"dataclasses", 1169},
+		{"default", 1169},
+		{"default_factory", 1169},
+		{"del", 1169},
+		{"description", 1169},
+		{"dump", 1169},
+		{"elif", 1169},
+		{"else$", 1169},
+		{"env", 1169},
+		{"except", 1169},
+		{"exists", 1169},
+		{"exit", 1169},
+		{"field$", 1169},
+		{"getLogger", 1169},
+		{"indent", 1169},
+		{"info", 1169},
+		{"json$", 1169},
+		{"key", 1169},
+		{"lambda", 1169},
+		{"list", 1169},
+		{"logging$", 1169},
+		{"not", 1169},
+		{"object", 1169},
+		{"or", 1169},
+		{"os$", 1169},
+		{"parse_args", 1169},
+		{"path$", 1169},
+		{"pathlib", 1169},
+		{"print", 1169},
+		{"python3", 1169},
+		{"python3$", 1169},
+		{"refresh", 1169},
+		{"remove", 1169},
+		{"replace", 1169},
+		{"save", 1169},
+		{"sorted", 1169},
+		{"store", 1169},
+		{"sys$", 1169},
+		{"try", 1169},
+		{"type", 1169},
+		{"typing", 1169},
+		{"usr", 1169},
+		{"with_suffix", 1169},
+	},
+	CodeLangJavaScript: {
+		{"(", 80754},
+		{")", 80754},
+		{".", 56603},
+		{";", 50566},
+		{";$", 50566},
+		{"{", 34716},
+		{"}", 34716},
+		{"^}", 26415},
+		{"{$", 26415},
+		{"=", 24905},
+		{",", 22641},
+		{"this", 18113},
+		{"=>", 16603},
+		{"}$", 16603},
+		{"'", 12830},
+		{"const", 12830},
+		{"^const", 12075},
+		{":", 10566},
+		{"^this", 10566},
+		{"<", 8301},
+		{">", 8301},
+		{"return", 7547},
+		{"^return", 6792},
+		{"job", 6792},
+		{"queue", 6792},
+		{"^if", 5283},
+		{"count", 5283},
+		{"if", 5283},
+		{"^export", 4528},
+		{"button", 4528},
+		{"err", 4528},
+		{"export", 4528},
+		{"reject", 4528},
+		{"===", 3773},
+		{">$", 3773},
+		{"T", 3773},
+		{"await", 3773},
+		{"new", 3773},
+		{"res", 3773},
+		{"resolve", 3773},
+		{"running", 3773},
+		{",$", 3018},
+		{"/", 3018},
+		{"Promise", 3018},
+		{"[", 3018},
+		{"]", 3018},
+		{"^<", 3018},
+		{"async", 3018},
+		{"c", 3018},
+		{"concurrency", 3018},
+		{"f", 3018},
+		{"function", 3018},
+		{"limit", 3018},
+		{"next", 3018},
+		{"number", 3018},
+		{"onChange", 3018},
+		{"push", 3018},
+		{"retries", 3018},
+		{"step", 3018},
+		{"task", 3018},
+		{"value", 3018},
+		{"Counter", 2264},
+		{"Handler", 2264},
+		{"PropTypes", 2264},
+		{"TaskQueue", 2264},
+		{"User", 2264},
+		{"^import", 2264},
+		{"^job", 2264},
+		{"`", 2264},
+		{"attempts", 2264},
+		{"data", 2264},
+		{"dir", 2264},
+		{"document", 2264},
+		{"from", 2264},
+		{"fs", 2264},
+		{"handler", 2264},
+		{"import", 2264},
+		{"initial", 2264},
+		{"listeners", 2264},
+		{"name", 2264},
+		{"options", 2264},
+		{"r", 2264},
+		{"readJSON", 2264},
+		{"require", 2264},
+		{"setCount", 2264},
+		{"super", 2264},
+		{"undefined", 2264},
+		{"values", 2264},
+		{"||", 2264},
+		{"&&", 1509},
+		{"($", 1509},
+		{"+", 1509},
+		{"++;", 1509},
+		{"++;$", 1509},
+		{"---", 1509},
+		{"//", 1509},
+		{"</", 1509},
+		{"EventEmitter", 1509},
+		{"^)", 1509},
+		{"^//", 1509},
+		{"^console", 1509},
+		{"^constructor", 1509},
+		{"^document", 1509},
+		{"^name", 1509},
+		{"^super", 1509},
+		{"^try", 1509},
+		{"a", 1509},
+		{"addEventListener", 1509},
+		{"b", 1509},
+		{"catch", 1509},
+		{"class", 1509},
+		{"console", 1509},
+		{"constructor", 1509},
+		{"delete", 1509},
+		{"div", 1509},
+		{"event", 1509},
+		{"extends", 1509},
+		{"fetch", 1509},
+		{"file", 1509},
+		{"files", 1509},
+		{"formatNumber", 1509},
+		{"id", 1509},
+		{"json", 1509},
+		{"key", 1509},
+		{"length", 1509},
+		{"map", 1509},
+		{"oldest", 1509},
+		{"onClick", 1509},
+		{"path", 1509},
+		{"result", 1509},
+		{"results", 1509},
+		{"run", 1509},
+		{"set", 1509},
+		{"span", 1509},
+		{"string", 1509},
+		{"sum", 1509},
+		{"text", 1509},
+		{"totals", 1509},
+		{"try", 1509},
+		{"useEffect", 1509},
+		{"useState", 1509},
+		{"user", 1509},
+		{"void", 1509},
+		{"!", 754},
+		{"\"", 754},
+		{")$", 754},
+		{"*", 754},
+		{"*/", 754},
+		{"*/$", 754},
+		{"+=", 754},
+		{"-", 754},
+		{"--;", 754},
+		{"--;$", 754},
+		{".$", 754},
+		{"/**", 754},
+		{"/**$", 754},
+		{"<=", 754},
+		{">+<", 754},
+		{">-<", 754},
+		{">=", 754},
+		{">>", 754},
+		{"?:", 754},
+		{"??", 754},
+		{"A", 754},
+		{"Cache", 754},
+		{"Error", 754},
+		{"JSON", 754},
+		{"Map", 754},
+		{"React", 754},
+		{"Set", 754},
+		{"String", 754},
+		{"^'", 754},
+		{"^*", 754},
+		{"^*/", 754},
+		{"^/**", 754},
+		{"^</", 754},
+		{"^Counter", 754},
+		{"^async", 754},
+		{"^button", 754},
+		{"^class", 754},
+		{"^count", 754},
+		{"^email", 754},
+		{"^event", 754},
+		{"^files", 754},
+		{"^fs", 754},
+		{"^function", 754},
+		{"^id", 754},
+		{"^initial", 754},
+		{"^let", 754},
+		{"^listeners", 754},
+		{"^module", 754},
+		{"^next", 754},
+		{"^onChange", 754},
+		{"^push", 754},
+		{"^reject", 754},
+		{"^resolve", 754},
+		{"^set", 754},
+		{"^step", 754},
+		{"^total", 754},
+		{"^type", 754},
+		{"^useEffect", 754},
+		{"^while", 754},
+		{"add", 754},
+		{"all", 754},
+		{"api", 754},
+		{"className", 754},
+		{"client", 754},
+		{"components", 754},
+		{"default", 754},
+		{"e", 754},
+		{"else", 754},
+		{"email", 754},
+		{"emit", 754},
+		{"endsWith", 754},
+		{"exports", 754},
+		{"filter", 754},
+		{"finally", 754},
+		{"getElementById", 754},
+		{"getUser", 754},
+		{"interface", 754},
+		{"join", 754},
+		{"jsx", 754},
+		{"jsx$", 754},
+		{"keys", 754},
+		{"let", 754},
+		{"log", 754},
+		{"message", 754},
+		{"module", 754},
+		{"null", 754},
+		{"ok", 754},
+		{"parse", 754},
+		{"preventDefault", 754},
+		{"propTypes", 754},
+		{"querySelector", 754},
+		{"readFile", 754},
+		{"readdirSync", 754},
+		{"reduce", 754},
+		{"shift", 754},
+		{"size", 754},
+		{"subscribe", 754},
+		{"textContent", 754},
+		{"then", 754},
+		{"tiny", 754},
+		{"total", 754},
+		{"ts", 754},
+		{"ts$", 754},
+		{"type", 754},
+		{"typeof", 754},
+		{"warn", 754},
+		{"while", 754},
+		{"with", 754},
+		{"|", 754},
+	},
+	CodeLangJava: {
+		{"(", 79196},
+		{")", 79196},
+		{".", 76832},
+		{";", 52009},
+		{";$", 46099},
+		{"{", 27186},
+		{"}", 27186},
+		{"}$", 26004},
+		{"String", 21276},
+		{"^}", 21276},
+		{"{$", 21276},
+		{"^public", 15366},
+		{"public", 15366},
+		{"book", 14184},
+		{",", 13002},
+		{"=", 13002},
+		{"Book", 13002},
+		{"\"", 11820},
+		{"isbn", 11820},
+		{"new", 11820},
+		{"^import", 10638},
+		{"import", 10638},
+		{"java", 10638},
+		{"LoanException", 8274},
+		{"^private", 8274},
+		{"author", 8274},
+		{"private", 8274},
+		{"<", 7092},
+		{"books", 7092},
+		{"final", 7092},
+		{"library", 7092},
+		{"return", 7092},
+		{"util", 7092},
+		{"lent", 5910},
+		{"title", 5910},
+		{"void", 5910},
+		{")$", 4728},
+		{">", 4728},
+		{"Library", 4728},
+		{"List", 4728},
+		{"^.", 4728},
+		{"append", 4728},
+		{"borrowed", 4728},
+		{"file", 4728},
+		{"getTitle", 4728},
+		{"this", 4728},
+		{"+", 3546},
+		{"->", 3546},
+		{"<>", 3546},
+		{"HashMap", 3546},
+		{"Map", 3546},
+		{"Optional", 3546},
+		{"^if", 3546},
+		{"^this", 3546},
+		{"^throw", 3546},
+		{"add", 3546},
+		{"boolean", 3546},
+		{"class", 3546},
+		{"getIsbn", 3546},
+		{"if", 3546},
+		{"member", 3546},
+		{"sb", 3546},
+		{"throw", 3546},
+		{"'", 2364},
+		{"@", 2364},
+		{"ArrayList", 2364},
+		{"Collectors", 2364},
+		{"Files", 2364},
+		{"IOException", 2364},
+		{"MAX_LOANS", 2364},
+		{"Override", 2364},
+		{"Override$", 2364},
+		{"Path", 2364},
+		{"StringBuilder", 2364},
+		{"System", 2364},
+		{"^@", 2364},
+		{"^Book", 2364},
+		{"^System", 2364},
+		{"^class", 2364},
+		{"^library", 2364},
+		{"^return", 2364},
+		{"b", 2364},
+		{"e", 2364},
+		{"find", 2364},
+		{"getAuthor", 2364},
+		{"isLent", 2364},
+		{"lend", 2364},
+		{"loans", 2364},
+		{"message", 2364},
+		{"nio", 2364},
+		{"println", 2364},
+		{"setLent", 2364},
+		{"static", 2364},
+		{"stream", 2364},
+		{"throws", 2364},
+		{"titlesBy", 2364},
+		{"values", 2364},
+		{"*", 1182},
+		{"*/", 1182},
+		{"*/$", 1182},
+		{".$", 1182},
+		{"/**", 1182},
+		{"/**$", 1182},
+		{":", 1182},
+		{"::", 1182},
+		{"==", 1182},
+		{">=", 1182},
+		{">>", 1182},
+		{"Catalog", 1182},
+		{"Exception", 1182},
+		{"IllegalArgumentException", 1182},
+		{"Keeps", 1182},
+		{"[", 1182},
+		{"]", 1182},
+		{"^*", 1182},
+		{"^*/", 1182},
+		{"^/**", 1182},
+		{"^Files", 1182},
+		{"^Library", 1182},
+		{"^List", 1182},
+		{"^LoanException", 1182},
+		{"^StringBuilder", 1182},
+		{"^book", 1182},
+		{"^books", 1182},
+		{"^borrowed", 1182},
+		{"^for", 1182},
+		{"^package", 1182},
+		{"^sb", 1182},
+		{"^super", 1182},
+		{"^try", 1182},
+		{"a", 1182},
+		{"and", 1182},
+		{"args", 1182},
+		{"catch", 1182},
+		{"collect", 1182},
+		{"com", 1182},
+		{"computeIfAbsent", 1182},
+		{"equals", 1182},
+		{"err", 1182},
+		{"example", 1182},
+		{"export", 1182},
+		{"extends", 1182},
+		{"filter", 1182},
+		{"for", 1182},
+		{"get", 1182},
+		{"getMessage", 1182},
+		{"implements", 1182},
+		{"in", 1182},
+		{"int", 1182},
+		{"io", 1182},
+		{"k", 1182},
+		{"main", 1182},
+		{"map", 1182},
+		{"null", 1182},
+		{"of", 1182},
+		{"ofNullable", 1182},
+		{"orElseThrow", 1182},
+		{"out", 1182},
+		{"package", 1182},
+		{"put", 1182},
+		{"size", 1182},
+		{"sorted", 1182},
+		{"super", 1182},
+		{"synchronized", 1182},
+		{"the", 1182},
+		{"them", 1182},
+		{"toList", 1182},
+		{"toString", 1182},
+		{"track", 1182},
+		{"true", 1182},
+		{"try", 1182},
+		{"who", 1182},
+		{"writeString", 1182},
+	},
+	CodeLangC: {
+		{";", 64596},
+		{"(", 62111},
+		{")", 62111},
+		{";$", 62111},
+		{"r", 50931},
+		{",", 32298},
+		{"->", 32298},
+		{"=", 24844},
+		{"size_t", 21118},
+		{"*", 17391},
+		{"^}", 17391},
+		{"{", 17391},
+		{"{$", 17391},
+		{"}", 17391},
+		{"}$", 16149},
+		{"^size_t", 14906},
+		{"n", 14906},
+		{"size", 12422},
+		{")$", 11180},
+		{"<", 11180},
+		{"^r", 11180},
+		{"^return", 11180},
+		{"p", 11180},
+		{"return", 11180},
+		{"#", 9937},
+		{"^#", 9937},
+		{"[", 8695},
+		{"]", 8695},
+		{"buf", 8695},
+		{"ring_t", 8695},
+		{"-", 7453},
+		{".", 7453},
+		{"NULL", 7453},
+		{"^if", 7453},
+		{"^{", 7453},
+		{"char", 7453},
+		{"count", 7453},
+		{"if", 7453},
+		{"include", 7453},
+		{"tail", 7453},
+		{">", 6211},
+		{">$", 6211},
+		{"fp", 6211},
+		{"h", 6211},
+		{"head", 6211},
+		{"i", 6211},
+		{"line", 6211},
+		{"+", 4968},
+		{"MIN", 4968},
+		{"argv", 4968},
+		{"first", 4968},
+		{"len", 4968},
+		{"tmp", 4968},
+		{"unsigned", 4968},
+		{"\"", 3726},
+		{"==", 3726},
+		{"^free", 3726},
+		{"^ring_t", 3726},
+		{"^unsigned", 3726},
+		{"a", 3726},
+		{"b", 3726},
+		{"free", 3726},
+		{"ring", 3726},
+		{"sizeof", 3726},
+		{"void", 3726},
+		{"!=", 2484},
+		{"&", 2484},
+		{"RING_MIN", 2484},
+		{"^memcpy", 2484},
+		{"^while", 2484},
+		{"argc", 2484},
+		{"const", 2484},
+		{"data", 2484},
+		{"define", 2484},
+		{"errno", 2484},
+		{"for", 2484},
+		{"got", 2484},
+		{"int", 2484},
+		{"memcpy", 2484},
+		{"out", 2484},
+		{"ring_free", 2484},
+		{"ring_new", 2484},
+		{"ring_read", 2484},
+		{"ring_write", 2484},
+		{"round_up", 2484},
+		{"while", 2484},
+		{"!", 1242},
+		{"\"$", 1242},
+		{"**", 1242},
+		{"*/", 1242},
+		{"*/$", 1242},
+		{"++", 1242},
+		{"+=", 1242},
+		{"-=", 1242},
+		{"/*", 1242},
+		{"/*$", 1242},
+		{":", 1242},
+		{"<<=", 1242},
+		{"?", 1242},
+		{"ENOMEM", 1242},
+		{"FILE", 1242},
+		{"RING_MIN$", 1242},
+		{"^*", 1242},
+		{"^*/", 1242},
+		{"^/*", 1242},
+		{"^FILE", 1242},
+		{"^char", 1242},
+		{"^const", 1242},
+		{"^errno", 1242},
+		{"^fclose", 1242},
+		{"^for", 1242},
+		{"^fp", 1242},
+		{"^fprintf", 1242},
+		{"^fwrite", 1242},
+		{"^int", 1242},
+		{"^p", 1242},
+		{"^perror", 1242},
+		{"^ring_free", 1242},
+		{"^static", 1242},
+		{"^typedef", 1242},
+		{"^void", 1242},
+		{"buffer", 1242},
+		{"byte", 1242},
+		{"c", 1242},
+		{"calloc", 1242},
+		{"fclose", 1242},
+		{"fgets", 1242},
+		{"fixed", 1242},
+		{"fopen", 1242},
+		{"fprintf", 1242},
+		{"fwrite", 1242},
+		{"main", 1242},
+		{"malloc", 1242},
+		{"perror", 1242},
+		{"static", 1242},
+		{"stderr", 1242},
+		{"stdint", 1242},
+		{"stdio", 1242},
+		{"stdlib", 1242},
+		{"stdout", 1242},
+		{"streams", 1242},
+		{"streams$", 1242},
+		{"string", 1242},
+		{"strlen", 1242},
+		{"struct", 1242},
+		{"typedef", 1242},
+	},
+	CodeLangCPP: {
+		{"(", 53724},
+		{")", 53724},
+		{";", 51282},
+		{";$", 47619},
+		{".", 35409},
+		{"::", 34188},
+		{"std", 34188},
+		{"<", 29304},
+		{",", 25641},
+		{"{", 24420},
+		{"}", 24420},
+		{">", 18315},
+		{"^}", 17094},
+		{"{$", 17094},
+		{"=", 15873},
+		{"[", 14652},
+		{"]", 14652},
+		{"dist", 14652},
+		{"}$", 14652},
+		{"^std", 13431},
+		{"size_t", 13431},
+		{"T", 12210},
+		{">$", 10989},
+		{"#", 9768},
+		{"^#", 9768},
+		{"include", 9768},
+		{"\"", 8547},
+		{"const", 8547},
+		{"e", 8547},
+		{"graph", 8547},
+		{"id", 8547},
+		{"names", 8547},
+		{"to", 8547},
+		{"vector", 8547},
+		{":", 7326},
+		{"adj_", 7326},
+		{"pq", 7326},
+		{"->", 6105},
+		{"auto", 6105},
+		{"i", 6105},
+		{"ids_", 6105},
+		{"return", 6105},
+		{"size", 6105},
+		{"u", 6105},
+		{"weight", 6105},
+		{":$", 4884},
+		{"<<", 4884},
+		{"Graph", 4884},
+		{"Item", 4884},
+		{"^return", 4884},
+		{"addEdge", 4884},
+		{"int", 4884},
+		{">>", 3663},
+		{"Edge", 3663},
+		{"^auto", 3663},
+		{"^const", 3663},
+		{"^graph", 3663},
+		{"^if", 3663},
+		{"^pq", 3663},
+		{"counts", 3663},
+		{"emplace", 3663},
+		{"if", 3663},
+		{"it", 3663},
+		{"name", 3663},
+		{"namespace", 3663},
+		{"next", 3663},
+		{"raw", 3663},
+		{"routing", 3663},
+		{"shortest", 3663},
+		{"source", 3663},
+		{"string", 3663},
+		{"&", 2442},
+		{"+", 2442},
+		{"//", 2442},
+		{"Names", 2442},
+		{"^class", 2442},
+		{"^dist", 2442},
+		{"^for", 2442},
+		{"^int", 2442},
+		{"^private", 2442},
+		{"^public", 2442},
+		{"^using", 2442},
+		{"class", 2442},
+		{"d", 2442},
+		{"end", 2442},
+		{"for", 2442},
+		{"from", 2442},
+		{"inf", 2442},
+		{"map", 2442},
+		{"n", 2442},
+		{"private", 2442},
+		{"public", 2442},
+		{"using", 2442},
+		{"!", 1221},
+		{"!=", 1221},
+		{"*", 1221},
+		{"++", 1221},
+		{"-", 1221},
+		{">::", 1221},
+		{">;", 1221},
+		{">;$", 1221},
+		{"^//", 1221},
+		{"^Names", 1221},
+		{"^T", 1221},
+		{"^adj_", 1221},
+		{"^continue", 1221},
+		{"^delete", 1221},
+		{"^explicit", 1221},
+		{"^ids_", 1221},
+		{"^namespace", 1221},
+		{"^raw", 1221},
+		{"^struct", 1221},
+		{"^template", 1221},
+		{"^virtual", 1221},
+		{"^void", 1221},
+		{"^while", 1221},
+		{"a", 1221},
+		{"algorithm", 1221},
+		{"at", 1221},
+		{"begin", 1221},
+		{"continue", 1221},
+		{"cout", 1221},
+		{"cpp", 1221},
+		{"default", 1221},
+		{"delete", 1221},
+		{"double", 1221},
+		{"empty", 1221},
+		{"endl", 1221},
+		{"explicit", 1221},
+		{"find", 1221},
+		{"graph$", 1221},
+		{"greater", 1221},
+		{"iostream", 1221},
+		{"limits", 1221},
+		{"main", 1221},
+		{"make_unique", 1221},
+		{"max", 1221},
+		{"memory", 1221},
+		{"new", 1221},
+		{"noexcept", 1221},
+		{"nullptr", 1221},
+		{"numeric_limits", 1221},
+		{"over", 1221},
+		{"pair", 1221},
+		{"paths", 1221},
+		{"pop", 1221},
+		{"priority_queue", 1221},
+		{"push_back", 1221},
+		{"queue", 1221},
+		{"routing$", 1221},
+		{"second", 1221},
+		{"sort", 1221},
+		{"struct", 1221},
+		{"template", 1221},
+		{"top", 1221},
+		{"typename", 1221},
+		{"virtual", 1221},
+		{"void", 1221},
+		{"weighted", 1221},
+		{"while", 1221},
+		{"~", 1221},
+	},
+	CodeLangCSharp: {
+		{";", 59817},
+		{"(", 55916},
+		{")", 55916},
+		{";$", 48114},
+		{".", 42912},
+		{"{", 29908},
+		{"}", 29908},
+		{"}$", 26007},
+		{"=", 23407},
+		{"^public", 23407},
+		{"public", 23407},
+		{"^{", 22106},
+		{"^}", 22106},
+		{"{$", 22106},
+		{",", 18205},
+		{")$", 15604},
+		{"var", 14304},
+		{"^var", 11703},
+		{"new", 11703},
+		{"order", 11703},
+		{"Order", 10403},
+		{"\"", 9102},
+		{"=>", 7802},
+		{">", 7802},
+		{"^using", 7802},
+		{"string", 7802},
+		{"using", 7802},
+		{"$", 6501},
+		{"<", 6501},
+		{"OrderLine", 6501},
+		{"OrderStatus", 6501},
+		{"System", 6501},
+		{"Task", 6501},
+		{"[", 6501},
+		{"]", 6501},
+		{"_lines", 6501},
+		{"decimal", 6501},
+		{"get", 6501},
+		{"l", 6501},
+		{"private", 6501},
+		{"quantity", 6501},
+		{"Add", 5201},
+		{"Guid", 5201},
+		{"Parse", 5201},
+		{"Quantity", 5201},
+		{"^private", 5201},
+		{"_directory", 5201},
+		{"class", 5201},
+		{"parts", 5201},
+		{"path", 5201},
+		{"text", 5201},
+		{"FileOrderRepository", 3901},
+		{"Pending", 3901},
+		{"Price", 3901},
+		{"Product", 3901},
+		{"SaveAsync", 3901},
+		{"Status", 3901},
+		{"Total", 3901},
+		{"^if", 3901},
+		{"^return", 3901},
+		{"args", 3901},
+		{"async", 3901},
+		{"await", 3901},
+		{"directory", 3901},
+		{"if", 3901},
+		{"int", 3901},
+		{"line", 3901},
+		{"product", 3901},
+		{"return", 3901},
+		{"set", 3901},
+		{"static", 3901},
+		{"throw", 3901},
+		{"!=", 2600},
+		{"'", 2600},
+		{",$", 2600},
+		{":", 2600},
+		{"Combine", 2600},
+		{"GetAsync", 2600},
+		{"IOrderRepository", 2600},
+		{"IOrderRepository$", 2600},
+		{"Lines", 2600},
+		{"List", 2600},
+		{"Paid", 2600},
+		{"Path", 2600},
+		{"Split", 2600},
+		{"^Task", 2600},
+		{"^await", 2600},
+		{"^order", 2600},
+		{"^throw", 2600},
+		{"id", 2600},
+		{"lines", 2600},
+		{"nameof", 2600},
+		{"price", 2600},
+		{"reader", 2600},
+		{"readonly", 2600},
+		{"repo", 2600},
+		{"row", 2600},
+		{"void", 2600},
+		{"*", 1300},
+		{"+=", 1300},
+		{"<=", 1300},
+		{"==", 1300},
+		{"?", 1300},
+		{"??", 1300},
+		{"ArgumentNullException", 1300},
+		{"ArgumentOutOfRangeException", 1300},
+		{"Collections", 1300},
+		{"Console", 1300},
+		{"File", 1300},
+		{"FirstOrDefault", 1300},
+		{"Generic", 1300},
+		{"IO", 1300},
+		{"IReadOnlyList", 1300},
+		{"Id", 1300},
+		{"InvalidOperationException", 1300},
+		{"Length", 1300},
+		{"Linq", 1300},
+		{"Main", 1300},
+		{"MarkPaid", 1300},
+		{"NewGuid", 1300},
+		{"Order$", 1300},
+		{"OrderLine$", 1300},
+		{"OrderStatus$", 1300},
+		{"Orders", 1300},
+		{"Orders$", 1300},
+		{"Program", 1300},
+		{"Program$", 1300},
+		{"ReadToEndAsync", 1300},
+		{"RemoveEmptyEntries", 1300},
+		{"Select", 1300},
+		{"Shipped", 1300},
+		{"Shipped$", 1300},
+		{"Shop", 1300},
+		{"StreamReader", 1300},
+		{"StringSplitOptions", 1300},
+		{"Sum", 1300},
+		{"Tasks", 1300},
+		{"Threading", 1300},
+		{"WriteAllLinesAsync", 1300},
+		{"WriteLine", 1300},
+		{"^Console", 1300},
+		{"^Paid", 1300},
+		{"^Pending", 1300},
+		{"^Shipped", 1300},
+		{"^Status", 1300},
+		{"^_directory", 1300},
+		{"^_lines", 1300},
+		{"^foreach", 1300},
+		{"^internal", 1300},
+		{"^line", 1300},
+		{"^namespace", 1300},
+		{"enum", 1300},
+		{"foreach", 1300},
+		{"in", 1300},
+		{"init", 1300},
+		{"interface", 1300},
+		{"internal", 1300},
+		{"namespace", 1300},
+		{"null", 1300},
+		{"sealed", 1300},
+	},
+	CodeLangRuby: {
+		{".", 52980},
+		{"(", 34768},
+		{")", 34768},
+		{"@", 33112},
+		{"end", 31456},
+		{"^end", 29801},
+		{"end$", 29801},
+		{":", 26490},
+		{"url", 24834},
+		{",", 23178},
+		{"|", 23178},
+		{")$", 21523},
+		{"=", 19867},
+		{"^@", 18211},
+		{"'", 14900},
+		{"^def", 14900},
+		{"def", 14900},
+		{"title", 13245},
+		{"[", 11589},
+		{"]", 11589},
+		{"path", 9933},
+		{"{", 9933},
+		{"}", 9933},
+		{"}$", 9933},
+		{"?", 8278},
+		{"entries", 8278},
+		{"last_checked", 8278},
+		{"options", 8278},
+		{"\"", 6622},
+		{"'$", 6622},
+		{"File", 6622},
+		{"do", 6622},
+		{"each", 6622},
+		{"if", 6622},
+		{"new", 6622},
+		{"subscriptions", 6622},
+		{"|$", 6622},
+		{"\"$", 4966},
+		{"JSON", 4966},
+		{"Subscription", 4966},
+		{"]$", 4966},
+		{"^class", 4966},
+		{"class", 4966},
+		{"e", 4966},
+		{"h", 4966},
+		{"item", 4966},
+		{"library", 4966},
+		{"opts", 4966},
+		{"parse", 4966},
+		{"response", 4966},
+		{"true", 4966},
+		{"verbose", 4966},
+		{"!", 3311},
+		{"#", 3311},
+		{"&", 3311},
+		{"&:", 3311},
+		{"**", 3311},
+		{"/", 3311},
+		{"::", 3311},
+		{"<<", 3311},
+		{"==", 3311},
+		{"?$", 3311},
+		{"ARGV", 3311},
+		{"Error", 3311},
+		{"Library", 3311},
+		{"Reader", 3311},
+		{"^#", 3311},
+		{"^if", 3311},
+		{"^library", 3311},
+		{"^opts", 3311},
+		{"^puts", 3311},
+		{"^require", 3311},
+		{"^return", 3311},
+		{"^warn", 3311},
+		{"add", 3311},
+		{"block", 3311},
+		{"client", 3311},
+		{"id", 3311},
+		{"initialize", 3311},
+		{"load", 3311},
+		{"load$", 3311},
+		{"map", 3311},
+		{"nil", 3311},
+		{"puts", 3311},
+		{"read", 3311},
+		{"require", 3311},
+		{"return", 3311},
+		{"s", 3311},
+		{"save", 3311},
+		{"save$", 3311},
+		{"to_h", 3311},
+		{"unless", 3311},
+		{"url$", 3311},
+		{"warn", 3311},
+		{"!$", 1655},
+		{"#!/", 1655},
+		{"&.", 1655},
+		{".$", 1655},
+		{";", 1655},
+		{"<", 1655},
+		{"=>", 1655},
+		{"A", 1655},
+		{"Atom", 1655},
+		{"Enumerable", 1655},
+		{"Enumerable$", 1655},
+		{"Feed", 1655},
+		{"Library$", 1655},
+		{"OptionParser", 1655},
+		{"ParserError", 1655},
+		{"RSS", 1655},
+		{"Reader$", 1655},
+		{"StandardError", 1655},
+		{"Subscription$", 1655},
+		{"Time", 1655},
+		{"^#!/", 1655},
+		{"^ARGV", 1655},
+		{"^Feed", 1655},
+		{"^File", 1655},
+		{"^JSON", 1655},
+		{"^OptionParser", 1655},
+		{"^Subscription", 1655},
+		{"^[", 1655},
+		{"^attr_accessor", 1655},
+		{"^attr_reader", 1655},
+		{"^else", 1655},
+		{"^include", 1655},
+		{"^module", 1655},
+		{"^next", 1655},
+		{"^options", 1655},
+		{"^private", 1655},
+		{"^raise", 1655},
+		{"^require_relative", 1655},
+		{"^rescue", 1655},
+		{"^response", 1655},
+		{"^save", 1655},
+		{"^self", 1655},
+		{"^{", 1655},
+		{"an", 1655},
+		{"any", 1655},
+		{"attr_accessor", 1655},
+		{"attr_reader", 1655},
+		{"banner", 1655},
+		{"bin", 1655},
+		{"body", 1655},
+		{"e$", 1655},
+		{"each_with_index", 1655},
+		{"else", 1655},
+		{"else$", 1655},
+		{"empty", 1655},
+		{"entries$", 1655},
+		{"env", 1655},
+		{"exist", 1655},
+		{"expand_path", 1655},
+		{"false", 1655},
+		{"feed", 1655},
+		{"find", 1655},
+		{"frozen_string_literal", 1655},
+		{"get", 1655},
+		{"i", 1655},
+		{"include", 1655},
+		{"iso8601", 1655},
+		{"item$", 1655},
+		{"last_checked$", 1655},
+		{"module", 1655},
+		{"next", 1655},
+		{"nil$", 1655},
+		{"now", 1655},
+		{"now$", 1655},
+		{"on", 1655},
+		{"or", 1655},
+		{"path$", 1655},
+		{"pretty_generate", 1655},
+		{"private", 1655},
+		{"private$", 1655},
+		{"raise", 1655},
+		{"refresh", 1655},
+		{"reject", 1655},
+		{"require_relative", 1655},
+		{"rescue", 1655},
+		{"return$", 1655},
+		{"ruby", 1655},
+		{"ruby$", 1655},
+		{"self", 1655},
+		{"self$", 1655},
+		{"sub", 1655},
+		{"subscription", 1655},
+		{"success", 1655},
+		{"symbolize_names", 1655},
+		{"to", 1655},
+		{"to_h$", 1655},
+		{"true$", 1655},
+		{"unread", 1655},
+		{"unread$", 1655},
+		{"usr", 1655},
+		{"write", 1655},
+		{"||", 1655},
+	},
+	CodeLangRust: {
+		{"(", 77881},
+		{")", 76843},
+		{".", 44652},
+		{",", 35306},
+		{";", 29075},
+		{";$", 29075},
+		{"}", 29075},
+		{"::", 28037},
+		{"^}", 28037},
+		{"{", 28037},
+		{"}$", 28037},
+		{"{$", 26998},
+		{"self", 13499},
+		{":", 12461},
+		{"&", 11422},
+		{"\"", 10384},
+		{"<", 10384},
+		{"=", 10384},
+		{"fn", 10384},
+		{"let", 10384},
+		{"!", 9345},
+		{"^let", 9345},
+		{",$", 8307},
+		{"CountError", 8307},
+		{"^pub", 8307},
+		{"pub", 8307},
+		{">", 7268},
+		{"^use", 7268},
+		{"counts", 7268},
+		{"for", 7268},
+		{"line", 7268},
+		{"std", 7268},
+		{"use", 7268},
+		{"->", 6230},
+		{"Counts", 6230},
+		{"String", 6230},
+		{"^fn", 6230},
+		{"name", 6230},
+		{"usize", 6230},
+		{")$", 5192},
+		{"a", 5192},
+		{"args", 5192},
+		{"lines", 5192},
+		{"mut", 5192},
+		{"word", 5192},
+		{"#", 4153},
+		{"=>", 4153},
+		{"[", 4153},
+		{"]", 4153},
+		{"]$", 4153},
+		{"^#", 4153},
+		{"^for", 4153},
+		{"^impl", 4153},
+		{"err", 4153},
+		{"fmt", 4153},
+		{"impl", 4153},
+		{"in", 4153},
+		{"io", 4153},
+		{"n", 4153},
+		{"new", 4153},
+		{"path", 4153},
+		{"words", 4153},
+		{"|", 4153},
+		{"+=", 3115},
+		{"Empty", 3115},
+		{"Error", 3115},
+		{"Io", 3115},
+		{"Self", 3115},
+		{"Vec", 3115},
+		{"^CountError", 3115},
+		{"^if", 3115},
+		{"^println", 3115},
+		{"add_line", 3115},
+		{"b", 3115},
+		{"c", 3115},
+		{"collect", 3115},
+		{"f", 3115},
+		{"freq", 3115},
+		{"if", 3115},
+		{"println", 3115},
+		{"summary", 3115},
+		{"v", 3115},
+		{"?;", 2076},
+		{"?;$", 2076},
+		{"BufReader", 2076},
+		{"Debug", 2076},
+		{"Err", 2076},
+		{"File", 2076},
+		{"HashMap", 2076},
+		{"Ok", 2076},
+		{"P", 2076},
+		{"Path", 2076},
+		{"Summary", 2076},
+		{"^Ok", 2076},
+		{"^match", 2076},
+		{"^self", 2076},
+		{"^v", 2076},
+		{"as_ref", 2076},
+		{"count_file", 2076},
+		{"derive", 2076},
+		{"e", 2076},
+		{"env", 2076},
+		{"eprintln", 2076},
+		{"file", 2076},
+		{"iter", 2076},
+		{"longest", 2076},
+		{"match", 2076},
+		{"reader", 2076},
+		{"test", 2076},
+		{"top", 2076},
+		{"total", 2076},
+		{"width", 2076},
+		{"write", 2076},
+		{"'", 1038},
+		{"'$", 1038},
+		{"*", 1038},
+		{".$", 1038},
+		{"//!", 1038},
+		{"::*", 1038},
+		{"==", 1038},
+		{">>", 1038},
+		{"A", 1038},
+		{"AsRef", 1038},
+		{"BufRead", 1038},
+		{"Clone", 1038},
+		{"Default", 1038},
+		{"Display", 1038},
+		{"Formatter", 1038},
+		{"From", 1038},
+		{"Option", 1038},
+		{"Result", 1038},
+		{"Some", 1038},
+		{"^*", 1038},
+		{"^//!", 1038},
+		{"^Empty", 1038},
+		{"^Err", 1038},
+		{"^Io", 1038},
+		{"^Self", 1038},
+		{"^assert_eq", 1038},
+		{"^c", 1038},
+		{"^counts", 1038},
+		{"^eprintln", 1038},
+		{"^format", 1038},
+		{"^freq", 1038},
+		{"^mod", 1038},
+		{"^return", 1038},
+		{"^std", 1038},
+		{"^trait", 1038},
+		{"_", 1038},
+		{"assert_eq", 1038},
+		{"cfg", 1038},
+		{"checked_add", 1038},
+		{"collections", 1038},
+		{"command", 1038},
+		{"counter", 1038},
+		{"counts_words", 1038},
+		{"default", 1038},
+		{"display", 1038},
+		{"entry", 1038},
+		{"enum", 1038},
+		{"exit", 1038},
+		{"format", 1038},
+		{"from", 1038},
+		{"fs", 1038},
+		{"into_iter", 1038},
+		{"is_empty", 1038},
+		{"len", 1038},
+		{"main", 1038},
+		{"map", 1038},
+		{"max", 1038},
+		{"mod", 1038},
+		{"open", 1038},
+		{"or_insert", 1038},
+		{"process", 1038},
+		{"repeat", 1038},
+		{"return", 1038},
+		{"skip", 1038},
+		{"small", 1038},
+		{"sort_by", 1038},
+		{"split_whitespace", 1038},
+		{"str", 1038},
+		{"struct", 1038},
+		{"super", 1038},
+		{"take", 1038},
+		{"tests", 1038},
+		{"then", 1038},
+		{"to_lowercase", 1038},
+		{"to_string", 1038},
+		{"trait", 1038},
+		{"unwrap", 1038},
+	},
+	CodeLangPHP: {
+		{"$", 86038},
+		{"(", 63311},
+		{")", 63311},
+		{";", 48701},
+		{";$", 48701},
+		{"'", 38961},
+		{"->", 34090},
+		{",", 27597},
+		{"^$", 21103},
+		{"=", 17857},
+		{"[", 17857},
+		{"]", 17857},
+		{"^}", 17857},
+		{"{", 17857},
+		{"{$", 17857},
+		{"}", 17857},
+		{"}$", 17857},
+		{"=>", 16233},
+		{"post", 14610},
+		{"posts", 14610},
+		{"this", 14610},
+		{"\\", 11363},
+		{"^return", 9740},
+		{"^{", 9740},
+		{"data", 9740},
+		{"return", 9740},
+		{"function", 8116},
+		{"logger", 8116},
+		{":", 6493},
+		{"^if", 6493},
+		{"^public", 6493},
+		{"if", 6493},
+		{"public", 6493},
+		{"request", 6493},
+		{"App", 4870},
+		{"LoggerInterface", 4870},
+		{"PostRepository", 4870},
+		{"Response", 4870},
+		{"Response$", 4870},
+		{"^private", 4870},
+		{"^use", 4870},
+		{"date", 4870},
+		{"format", 4870},
+		{"items", 4870},
+		{"new", 4870},
+		{"page", 4870},
+		{"private", 4870},
+		{"slug", 4870},
+		{"string", 4870},
+		{"use", 4870},
+		{"\"", 3246},
+		{",$", 3246},
+		{"===", 3246},
+		{"JsonResponse", 3246},
+		{"PER_PAGE", 3246},
+		{"Post", 3246},
+		{"Request", 3246},
+		{"^'", 3246},
+		{"null", 3246},
+		{"render", 3246},
+		{"true", 3246},
+		{"!", 1623},
+		{")$", 1623},
+		{"*", 1623},
+		{"*/", 1623},
+		{"*/$", 1623},
+		{".", 1623},
+		{".$", 1623},
+		{"/**", 1623},
+		{"/**$", 1623},
+		{"::", 1623},
+		{"<?", 1623},
+		{"?>", 1623},
+		{"?>$", 1623},
+		{"??", 1623},
+		{"?\\", 1623},
+		{"AbstractController", 1623},
+		{"AbstractController$", 1623},
+		{"BlogController", 1623},
+		{"Controller", 1623},
+		{"DateTimeInterface", 1623},
+		{"Entity", 1623},
+		{"Handles", 1623},
+		{"Log", 1623},
+		{"Psr", 1623},
+		{"Repository", 1623},
+		{"[$", 1623},
+		{"^*", 1623},
+		{"^*/", 1623},
+		{"^/**", 1623},
+		{"^<?", 1623},
+		{"^?>", 1623},
+		{"^]", 1623},
+		{"^declare", 1623},
+		{"^echo", 1623},
+		{"^final", 1623},
+		{"^foreach", 1623},
+		{"^function", 1623},
+		{"^namespace", 1623},
+		{"^throw", 1623},
+		{"^var_dump", 1623},
+		{"_GET", 1623},
+		{"__construct", 1623},
+		{"array", 1623},
+		{"as", 1623},
+		{"blog", 1623},
+		{"class", 1623},
+		{"const", 1623},
+		{"create", 1623},
+		{"createNotFoundException", 1623},
+		{"declare", 1623},
+		{"echo", 1623},
+		{"empty", 1623},
+		{"extends", 1623},
+		{"final", 1623},
+		{"findOneBy", 1623},
+		{"findPublished", 1623},
+		{"foreach", 1623},
+		{"format_date", 1623},
+		{"get", 1623},
+		{"getContent", 1623},
+		{"getId", 1623},
+		{"index", 1623},
+		{"int", 1623},
+		{"is_array", 1623},
+		{"isset", 1623},
+		{"json_decode", 1623},
+		{"key", 1623},
+		{"max", 1623},
+		{"namespace", 1623},
+		{"pages", 1623},
+		{"php", 1623},
+		{"php$", 1623},
+		{"preg_replace", 1623},
+		{"query", 1623},
+		{"save", 1623},
+		{"self", 1623},
+		{"setBody", 1623},
+		{"setSlug", 1623},
+		{"setTitle", 1623},
+		{"show", 1623},
+		{"strict_types", 1623},
+		{"string$", 1623},
+		{"strtolower", 1623},
+		{"the", 1623},
+		{"throw", 1623},
+		{"trim", 1623},
+		{"value", 1623},
+		{"var_dump", 1623},
+		{"warning", 1623},
+		{"||", 1623},
+	},
+	CodeLangSwift: {
+		{"(", 54794},
+		{")", 54794},
+		{".", 46575},
+		{":", 46575},
+		{"{", 43835},
+		{"}", 43835},
+		{"^}", 38356},
+		{"}$", 38356},
+		{"{$", 36986},
+		{")$", 24657},
+		{"=", 20547},
+		{"items", 15068},
+		{"[", 13698},
+		{"]", 13698},
+		{"TodoItem", 12328},
+		{"func", 10958},
+		{"title", 10958},
+		{"var", 10958},
+		{"^func", 9589},
+		{"item", 9589},
+		{"let", 9589},
+		{"self", 9589},
+		{"url", 9589},
+		{"store", 8219},
+		{"try", 8219},
+		{"\"", 6849},
+		{",", 6849},
+		{"Date", 6849},
+		{"^self", 6849},
+		{"^var", 6849},
+		{"private", 6849},
+		{"?", 5479},
+		{"@", 5479},
+		{"String", 5479},
+		{"TodoStore", 5479},
+		{"]$", 5479},
+		{"^@", 5479},
+		{"^items", 5479},
+		{"^let", 5479},
+		{"_", 5479},
+		{"data", 5479},
+		{"dueDate", 5479},
+		{"return", 5479},
+		{"throws", 5479},
+		{"->", 4109},
+		{"UUID", 4109},
+		{"^init", 4109},
+		{"^private", 4109},
+		{"^return", 4109},
+		{"do", 4109},
+		{"id", 4109},
+		{"init", 4109},
+		{"isDone", 4109},
+		{"load", 4109},
+		{"model", 4109},
+		{"persist", 4109},
+		{"save", 4109},
+		{"toggle", 4109},
+		{"trimmed", 4109},
+		{"Bool", 2739},
+		{"StoreError", 2739},
+		{"TodoViewModel", 2739},
+		{"URL", 2739},
+		{"View", 2739},
+		{"^.", 2739},
+		{"^case", 2739},
+		{"^do", 2739},
+		{"^final", 2739},
+		{"^guard", 2739},
+		{"^import", 2739},
+		{"^persist", 2739},
+		{"^struct", 2739},
+		{"^try", 2739},
+		{"case", 2739},
+		{"catch", 2739},
+		{"class", 2739},
+		{"decoding", 2739},
+		{"else", 2739},
+		{"final", 2739},
+		{"guard", 2739},
+		{"import", 2739},
+		{"in", 2739},
+		{"index", 2739},
+		{"now", 2739},
+		{"struct", 2739},
+		{"to", 2739},
+		{"!", 1369},
+		{"!$", 1369},
+		{"\"$", 1369},
+		{"$", 1369},
+		{"-", 1369},
+		{".$", 1369},
+		{"///", 1369},
+		{"<", 1369},
+		{"==", 1369},
+		{"?$", 1369},
+		{"??", 1369},
+		{"A", 1369},
+		{"Codable", 1369},
+		{"Data", 1369},
+		{"Equatable", 1369},
+		{"Error", 1369},
+		{"FileManager", 1369},
+		{"FileTodoStore", 1369},
+		{"ForEach", 1369},
+		{"Foundation", 1369},
+		{"Foundation$", 1369},
+		{"HStack", 1369},
+		{"Identifiable", 1369},
+		{"Image", 1369},
+		{"Int", 1369},
+		{"JSONDecoder", 1369},
+		{"JSONEncoder", 1369},
+		{"List", 1369},
+		{"MainActor", 1369},
+		{"MainActor$", 1369},
+		{"NavigationView", 1369},
+		{"ObservableObject", 1369},
+		{"ObservedObject", 1369},
+		{"Published", 1369},
+		{"State", 1369},
+		{"String$", 1369},
+		{"SwiftUI", 1369},
+		{"SwiftUI$", 1369},
+		{"Text", 1369},
+		{"TodoListView", 1369},
+		{"TodoStore$", 1369},
+		{"TodoViewModel$", 1369},
+		{"URL$", 1369},
+		{"UUID$", 1369},
+		{"^///", 1369},
+		{"^ForEach", 1369},
+		{"^HStack", 1369},
+		{"^Image", 1369},
+		{"^List", 1369},
+		{"^NavigationView", 1369},
+		{"^Text", 1369},
+		{"^enum", 1369},
+		{"^extension", 1369},
+		{"^if", 1369},
+		{"^print", 1369},
+		{"^protocol", 1369},
+		{"^throw", 1369},
+		{"add", 1369},
+		{"append", 1369},
+		{"atPath", 1369},
+		{"atomic", 1369},
+		{"body", 1369},
+		{"contentsOf", 1369},
+		{"count", 1369},
+		{"count$", 1369},
+		{"decode", 1369},
+		{"default", 1369},
+		{"dueDate$", 1369},
+		{"encode", 1369},
+		{"enum", 1369},
+		{"error", 1369},
+		{"extension", 1369},
+		{"false", 1369},
+		{"false$", 1369},
+		{"fileExists", 1369},
+		{"filter", 1369},
+		{"firstIndex", 1369},
+		{"from", 1369},
+		{"if", 1369},
+		{"in$", 1369},
+		{"isEmpty", 1369},
+		{"isOverdue", 1369},
+		{"list", 1369},
+		{"localizedDescription", 1369},
+		{"navigationTitle", 1369},
+		{"newTitle", 1369},
+		{"nil", 1369},
+		{"notFound", 1369},
+		{"now$", 1369},
+		{"on", 1369},
+		{"onTapGesture", 1369},
+		{"options", 1369},
+		{"path", 1369},
+		{"print", 1369},
+		{"protocol", 1369},
+		{"remaining", 1369},
+		{"set", 1369},
+		{"single", 1369},
+		{"some", 1369},
+		{"store$", 1369},
+		{"systemName", 1369},
+		{"the", 1369},
+		{"throw", 1369},
+		{"throws$", 1369},
+		{"title$", 1369},
+		{"trimmingCharacters", 1369},
+		{"url$", 1369},
+		{"where", 1369},
+		{"whitespaces", 1369},
+		{"write", 1369},
+	},
+	CodeLangKotlin: {
+		{"(", 65318},
+		{")", 65318},
+		{".", 63763},
+		{":", 38880},
+		{"{", 31104},
+		{"}", 31104},
+		{"}$", 26438},
+		{")$", 24883},
+		{"val", 24883},
+		{",", 23328},
+		{"=", 23328},
+		{"^}", 21772},
+		{"{$", 20217},
+		{"^val", 17107},
+		{"\"", 15552},
+		{"WeatherResult", 15552},
+		{"String", 13996},
+		{"city", 12441},
+		{",$", 10886},
+		{"->", 10886},
+		{"<", 10886},
+		{"cities", 10886},
+		{"days", 10886},
+		{"fun", 10886},
+		{">", 9331},
+		{"Forecast", 7776},
+		{"List", 7776},
+		{"^import", 7776},
+		{"class", 7776},
+		{"import", 7776},
+		{"println", 7776},
+		{"Int", 6220},
+		{"^fun", 6220},
+		{"args", 6220},
+		{"cache", 6220},
+		{"coroutines", 6220},
+		{"it", 6220},
+		{"kotlinx", 6220},
+		{"\"$", 4665},
+		{"Failure", 4665},
+		{"Success", 4665},
+		{"WeatherRepository", 4665},
+		{"^WeatherResult", 4665},
+		{"^data", 4665},
+		{"^suspend", 4665},
+		{"data", 4665},
+		{"high", 4665},
+		{"result", 4665},
+		{"suspend", 4665},
+		{"($", 3110},
+		{"=$", 3110},
+		{"?:", 3110},
+		{"Dispatchers", 3110},
+		{"Double", 3110},
+		{"Loading", 3110},
+		{"LocalDate", 3110},
+		{"MAX_DAYS", 3110},
+		{"WeatherApi", 3110},
+		{"[", 3110},
+		{"]", 3110},
+		{"^)", 3110},
+		{"^.", 3110},
+		{"^is", 3110},
+		{"^println", 3110},
+		{"^private", 3110},
+		{"api", 3110},
+		{"async", 3110},
+		{"clear", 3110},
+		{"coroutineScope", 3110},
+		{"count", 3110},
+		{"date", 3110},
+		{"describe", 3110},
+		{"e", 3110},
+		{"else", 3110},
+		{"forecast", 3110},
+		{"forecasts", 3110},
+		{"is", 3110},
+		{"load", 3110},
+		{"low", 3110},
+		{"object", 3110},
+		{"private", 3110},
+		{"repo", 3110},
+		{"results", 3110},
+		{"summary", 3110},
+		{"validate", 3110},
+		{"when", 3110},
+		{"withContext", 3110},
+		{"!!.", 1555},
+		{"'", 1555},
+		{"'$", 1555},
+		{"+=", 1555},
+		{"->$", 1555},
+		{"/**", 1555},
+		{">$", 1555},
+		{">>", 1555},
+		{"?", 1555},
+		{"?.", 1555},
+		{"A", 1555},
+		{"Array", 1555},
+		{"Dispatchers$", 1555},
+		{"Exception", 1555},
+		{"IO", 1555},
+		{"LocalDate$", 1555},
+		{"MutableMap", 1555},
+		{"^/**", 1555},
+		{"^async", 1555},
+		{"^class", 1555},
+		{"^companion", 1555},
+		{"^const", 1555},
+		{"^count", 1555},
+		{"^else", 1555},
+		{"^for", 1555},
+		{"^high", 1555},
+		{"^interface", 1555},
+		{"^object", 1555},
+		{"^package", 1555},
+		{"^return", 1555},
+		{"^sealed", 1555},
+		{"^summary", 1555},
+		{"^try", 1555},
+		{"^var", 1555},
+		{"^when", 1555},
+		{"also", 1555},
+		{"async$", 1555},
+		{"await", 1555},
+		{"catch", 1555},
+		{"coerceIn", 1555},
+		{"com", 1555},
+		{"companion", 1555},
+		{"const", 1555},
+		{"coroutineScope$", 1555},
+		{"day", 1555},
+		{"example", 1555},
+		{"first", 1555},
+		{"firstOrNull", 1555},
+		{"flatMap", 1555},
+		{"for", 1555},
+		{"forEach", 1555},
+		{"forecasts$", 1555},
+		{"format", 1555},
+		{"if", 1555},
+		{"in", 1555},
+		{"interface", 1555},
+		{"isNotEmpty", 1555},
+		{"isNullOrBlank", 1555},
+		{"java", 1555},
+		{"length", 1555},
+		{"length$", 1555},
+		{"listOf", 1555},
+		{"main", 1555},
+		{"map", 1555},
+		{"message", 1555},
+		{"mutableMapOf", 1555},
+		{"null", 1555},
+		{"package", 1555},
+		{"printForecasts", 1555},
+		{"range", 1555},
+		{"reason", 1555},
+		{"return", 1555},
+		{"sealed", 1555},
+		{"size", 1555},
+		{"sortedBy", 1555},
+		{"time", 1555},
+		{"toList", 1555},
+		{"toString", 1555},
+		{"try", 1555},
+		{"uppercase", 1555},
+		{"var", 1555},
+		{"weather", 1555},
+		{"weather$", 1555},
+		{"withContext$", 1555},
+	},
+}
@@ -0,0 +1,378 @@
+package detect
+
+import (
+	"bytes"
+	"math"
+	"sort"
+)
+
+//go:generate go run ../../cmd/mkngram -code -o code_profiles.go ../../cmd/mkngram/corpus/code
+
+// Programming languages are classified from token statistics over the
+// windows of a file that Detect samples (the whole file if it is small).
+// Source is split into identifiers,
+// keywords and operators; string literal contents and numbers are dropped.
+// The first and last token of every line are counted again with a "^"
+// prefix or "$" suffix, which captures comment styles (^//, ^#), statement
+// terminators (;$, :$) and line-leading keywords (^def, ^package). Each
+// language has a profile of its most frequent tokens, trained by
+// cmd/mkngram and generated into code_profiles.go, and a file is scored by
+// the average log probability of its tokens under each profile. Shebangs
+// and editor modelines name the language outright and add a fixed bonus.
+const (
+	// CodeProfileSize is the number of tokens kept per language profile.
+	CodeProfileSize = 500
+
+	// maxCodeToken is the longest token counted; longer ones are usually
+	// generated identifiers or encoded data.
+	maxCodeToken = 32
+
+	// unseenTokenPPM is the frequency, in parts per million, given to a
+	// token some other language's profile has but this one lacks.
+	unseenTokenPPM = 300
+
+	// codeEvidence caps how many tokens count as independent evidence when
+	// turning log probabilities into confidences.
+	codeEvidence = 20
+
+	// codeHintBonus is the log-odds bonus a shebang or modeline gives the
+	// language it names.
+	codeHintBonus = 12
+
+	// minCodeTokens is the least number of profiled tokens worth
+	// classifying, and minCodeLangConfidence the confidence
+	// Profile.Language requires.
+	minCodeTokens         = 6
+	minCodeLangConfidence = 0.6
+)
+
+// CodeLangScore is the probability that source code is in a given
+// programming language.
+type CodeLangScore struct {
+	Lang  CodeLang
+	Score float64
+}
+
+// CodeTokens counts the tokens of source code and returns the most
+// frequent, at most top, ordered by decreasing frequency (ties by text).
+// It is what cmd/mkngram uses to build the programming language profiles.
+func CodeTokens(src []byte, top int) []NGram {
+	counts := make(map[string]int)
+	total := 0
+	for _, tok := range codeTokens(src) {
+		counts[tok]++
+		total++
+	}
+	return rankNGrams(counts, total, top, 1)
+}
+
+// codeTokens splits source code into tokens, adding the line start and
+// line end markers.
+func codeTokens(src []byte) []string {
+	var tokens []string
+	for len(src) > 0 {
+		line := src
+		if i := bytes.IndexByte(src, '\n'); i >= 0 {
+			line, src = src[:i], src[i+1:]
+		} else {
+			src = nil
+		}
+
+		n := len(tokens)
+		tokens = appendLineTokens(tokens, line)
+		if len(tokens) > n {
+			tokens = append(tokens, "^"+tokens[n], tokens[len(tokens)-1]+"$")
+		}
+	}
+	return tokens
+}
+
+// appendLineTokens appends the tokens of one line.
+func appendLineTokens(tokens []string, line []byte) []string {
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c >= 0x80:
+			i++
+
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(line) && (isIdentStart(line[j]) || isDigit(line[j])) {
+				j++
+			}
+			if j-i <= maxCodeToken {
+				tokens = append(tokens, string(line[i:j]))
+			}
+			i = j
+
+		case isDigit(c):
+			for i < len(line) && (isIdentStart(line[i]) || isDigit(line[i]) || line[i] == '.') {
+				i++
+			}
+
+		case c == '"' || c == '\'' || c == '`':
+			// The quote is a token; what it encloses is not
+			tokens = append(tokens, string(c))
+			j := i + 1
+			for j < len(line) && line[j] != c {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			i = j + 1
+
+		case isOperator(c):
+			j := i + 1
+			for j < len(line) && j-i < 3 && isOperator(line[j]) {
+				j++
+			}
+			tokens = append(tokens, string(line[i:j]))
+			i = j
+
+		default:
+			// Brackets, commas and other single characters
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isOperator(c byte) bool {
+	switch c {
+	case '!', '#', '$', '%', '&', '*', '+', '-', '.', '/', ':', ';', '<', '=', '>', '?', '@', '\\', '^', '|', '~':
+		return true
+	}
+	return false
+}
+
+// codeLangs lists the languages with a profile, in CodeLang order.
+var codeLangs = func() []CodeLang {
+	langs := make([]CodeLang, 0, len(codeLangProfiles))
+	for lang := range codeLangProfiles {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}()
+
+// codeLangTable holds the log probability of each profiled token in every
+// language.
+var codeLangTable = &logProbTable{
+	unseen: math.Log(unseenTokenPPM / 1e6),
+	profiles: func() [][]NGram {
+		profiles := make([][]NGram, len(codeLangs))
+		for i, lang := range codeLangs {
+			profiles[i] = codeLangProfiles[lang]
+		}
+		return profiles
+	},
+}
+
+// ScoreCodeLang scores source code against every language profile. It
+// returns all languages ranked by probability (summing to 1, best first),
+// or nil if the code has too few recognisable tokens to judge. Tokens no
+// profile knows, such as the file's own identifiers, are ignored. Code
+// larger than DefaultSampleSize is sampled as Detect samples it.
+func ScoreCodeLang(src []byte) []CodeLangScore {
+	return scoreCodeLang(DefaultSampling.windows(src)...)
+}
+
+// scoreCodeLang is ScoreCodeLang over windows sampled from a file, the
+// start of the file first.
+func scoreCodeLang(windows ...[]byte) []CodeLangScore {
+	table := codeLangTable.get()
+	sums := make([]float64, len(codeLangs))
+	total := 0
+	for _, window := range windows {
+		for _, tok := range codeTokens(window) {
+			probs, ok := table[tok]
+			if !ok {
+				continue
+			}
+			total++
+			for j := range sums {
+				sums[j] += probs[j]
+			}
+		}
+	}
+
+	hint := codeLangHint(windows...)
+	if total < minCodeTokens && hint == CodeLangUnknown {
+		return nil
+	}
+
+	// Average log probability per token, scaled by the evidence the file
+	// provides, plus the bonus for an explicit hint; then normalised
+	// (softmax) into probabilities
+	weighEvidence(sums, total, codeEvidence)
+	for j, lang := range codeLangs {
+		if lang == hint {
+			sums[j] += codeHintBonus
+		}
+	}
+	softmax(sums)
+
+	scores := make([]CodeLangScore, len(codeLangs))
+	for j, lang := range codeLangs {
+		scores[j] = CodeLangScore{Lang: lang, Score: sums[j]}
+	}
+	sort.SliceStable(scores, func(a, b int) bool { return scores[a].Score > scores[b].Score })
+	return scores
+}
+
+// detectLanguage identifies the programming language of source code from
+// the windows sampled from it, returning the ranked scores and the
+// probability of the best language as confidence. The language is
+// CodeLangUnknown unless the confidence is at least minCodeLangConfidence.
+func detectLanguage(windows ...[]byte) (CodeLang, []CodeLangScore, float64) {
+	scores := scoreCodeLang(windows...)
+	if len(scores) == 0 {
+		return CodeLangUnknown, nil, 0
+	}
+	confidence := scores[0].Score
+	if confidence < minCodeLangConfidence {
+		return CodeLangUnknown, scores, confidence
+	}
+	return scores[0].Lang, scores, confidence
+}
+
+// codeLangNames maps interpreter and editor mode names to languages.
+var codeLangNames = map[string]CodeLang{
+	"go":         CodeLangGo,
+	"golang":     CodeLangGo,
+	"python":     CodeLangPython,
+	"python2":    CodeLangPython,
+	"python3":    CodeLangPython,
+	"py":         CodeLangPython,
+	"ipython":    CodeLangPython,
+	"node":       CodeLangJavaScript,
+	"nodejs":     CodeLangJavaScript,
+	"deno":       CodeLangJavaScript,
+	"bun":        CodeLangJavaScript,
+	"javascript": CodeLangJavaScript,
+	"js":         CodeLangJavaScript,
+	"typescript": CodeLangJavaScript,
+	"ts":         CodeLangJavaScript,
+	"jsx":        CodeLangJavaScript,
+	"tsx":        CodeLangJavaScript,
+	"java":       CodeLangJava,
+	"c":          CodeLangC,
+	"cpp":        CodeLangCPP,
+	"c++":        CodeLangCPP,
+	"cs":         CodeLangCSharp,
+	"csharp":     CodeLangCSharp,
+	"ruby":       CodeLangRuby,
+	"rb":         CodeLangRuby,
+	"rust":       CodeLangRust,
+	"php":        CodeLangPHP,
+	"swift":      CodeLangSwift,
+	"kotlin":     CodeLangKotlin,
+	"kts":        CodeLangKotlin,
+}
+
+// codeLangHint returns the language named by a shebang on the first line,
+// or by a vim or Emacs modeline in the first or last five lines, of the
+// windows sampled from a file.
+func codeLangHint(windows ...[]byte) CodeLang {
+	if len(windows) == 0 {
+		return CodeLangUnknown
+	}
+	if lang := shebangLang(windows[0]); lang != CodeLangUnknown {
+		return lang
+	}
+	head := bytes.SplitN(windows[0], []byte("\n"), 6)
+	if len(head) > 5 {
+		head = head[:5]
+	}
+	lines := bytes.Split(windows[len(windows)-1], []byte("\n"))
+	for _, line := range append(head, lines[max(len(lines)-5, 0):]...) {
+		if lang := modelineLang(line); lang != CodeLangUnknown {
+			return lang
+		}
+	}
+	return CodeLangUnknown
+}
+
+// shebangLang reads "#!/usr/bin/python3" and "#!/usr/bin/env -S node".
+func shebangLang(src []byte) CodeLang {
+	if !bytes.HasPrefix(src, []byte("#!")) {
+		return CodeLangUnknown
+	}
+	line := src[2:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	fields := bytes.Fields(line)
+	for _, f := range fields {
+		name := f[bytes.LastIndexByte(f, '/')+1:]
+		if len(name) == 0 {
+			break
+		}
+		if string(name) == "env" || name[0] == '-' {
+			continue
+		}
+		return codeLangName(name)
+	}
+	return CodeLangUnknown
+}
+
+// modelineLang reads "vim: ft=python", "vim: set filetype=ruby:" and
+// "-*- mode: rust -*-".
+func modelineLang(line []byte) CodeLang {
+	if i := bytes.Index(line, []byte("-*-")); i >= 0 {
+		rest := line[i+3:]
+		if j := bytes.Index(rest, []byte("-*-")); j >= 0 {
+			for _, field := range bytes.Split(rest[:j], []byte(";")) {
+				key, value, ok := bytes.Cut(field, []byte(":"))
+				if !ok {
+					// "-*- python -*-" names the mode alone
+					value = key
+				} else if string(bytes.TrimSpace(key)) != "mode" {
+					continue
+				}
+				return codeLangName(bytes.TrimSpace(value))
+			}
+		}
+	}
+
+	for _, marker := range []string{"vim:", "vi:", "ex:"} {
+		i := bytes.Index(line, []byte(marker))
+		if i < 0 {
+			continue
+		}
+		for _, f := range bytes.FieldsFunc(line[i+len(marker):], func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ':'
+		}) {
+			key, value, ok := bytes.Cut(f, []byte("="))
+			if !ok {
+				continue
+			}
+			switch string(key) {
+			case "ft", "filetype", "syntax", "syn":
+				return codeLangName(value)
+			}
+		}
+	}
+	return CodeLangUnknown
+}
+
+// codeLangName looks up an interpreter or mode name, ignoring case and a
+// version suffix ("python3.11", "ruby2.7").
+func codeLangName(name []byte) CodeLang {
+	s := string(bytes.ToLower(name))
+	if lang, ok := codeLangNames[s]; ok {
+		return lang
+	}
+	s = string(bytes.TrimRight([]byte(s), "0123456789."))
+	return codeLangNames[s]
+}
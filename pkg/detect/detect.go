@@ -17,6 +17,9 @@ const (
 	TypeRepetitive             // Highly repetitive data
 	TypeLowEntropy             // Low entropy (restricted byte range)
 	TypeRandom                 // High entropy, incompressible
+	TypeCompressed             // Compressed data or archive (gzip, zstd, ZIP, ...)
+	TypeMedia                  // Image, audio, video or font (PNG, JPEG, MP4, ...)
+	TypeExecutable             // Executable or object file (ELF, PE, Mach-O)
 )
 
 func (t Type) String() string {
@@ -33,6 +36,12 @@ func (t Type) String() string {
 		return "low-entropy"
 	case TypeRandom:
 		return "random"
+	case TypeCompressed:
+		return "compressed"
+	case TypeMedia:
+		return "media"
+	case TypeExecutable:
+		return "executable"
 	default:
 		return "unknown"
 	}
@@ -146,31 +155,46 @@ func (n NatLang) String() string {
 
 // Profile contains statistics about input data.
 type Profile struct {
-	Type           Type
-	Language       CodeLang   // Programming language (if TypeCode)
-	DataFmt        DataFormat // Structured data format
-	Markup         MarkupLang // Markup language
-	NatLang        NatLang    // Natural/human language
-	Entropy        float64    // bits per byte (0-8)
-	ASCIIRatio     float64    // fraction of printable ASCII
-	UniqueBytes    int        // number of distinct byte values
-	RepetitionRate float64    // estimated repetition (0-1)
-	CodeScore      float64    // likelihood of being source code (0-1)
+	Type               Type
+	Language           CodeLang        // Programming language (if TypeCode)
+	LanguageScores     []CodeLangScore // every programming language ranked by probability (if TypeCode)
+	LanguageConfidence float64         // probability of the best programming language (0-1)
+	DataFmt            DataFormat      // Structured data format
+	Binary             BinaryFormat    // Binary format recognised by its magic number
+	Markup             MarkupLang      // Markup language
+	NatLang            NatLang         // Natural/human language
+	NatLangScores      []LangScore     // every language ranked by probability (nil if too little text)
+	NatLangConfidence  float64         // probability of the best language (0-1)
+	Segments           []Segment       // regions of mixed content (nil if only one)
+	Entropy            float64         // bits per byte (0-8)
+	ASCIIRatio         float64         // fraction of printable ASCII
+	UniqueBytes        int             // number of distinct byte values
+	RepetitionRate     float64         // estimated repetition (0-1)
+	CodeScore          float64         // likelihood of being source code (0-1)
 }
 
-// Detect analyzes data and returns its profile.
-// Uses first 8KB for analysis if data is larger.
+// Detect analyzes data and returns its profile. Files larger than
+// DefaultSampleSize are sampled with DefaultSampling.
 func Detect(data []byte) Profile {
-	if len(data) == 0 {
-		return Profile{Type: TypeRandom}
-	}
+	return DetectWith(data, DefaultSampling)
+}
 
-	// Sample size (use first 8KB or all if smaller)
-	sampleSize := len(data)
-	if sampleSize > 8192 {
-		sampleSize = 8192
+// DetectWith analyzes the parts of data that s selects.
+func DetectWith(data []byte, s Sampling) Profile {
+	return analyze(s.windows(data), data)
+}
+
+// analyze profiles a file from the windows sampled from it, the first of
+// which is the start of the file. full is the whole file, or nil if only
+// the windows are available; mixed content is then not segmented.
+func analyze(windows [][]byte, full []byte) Profile {
+	sample := windows[0]
+	if len(windows) > 1 {
+		sample = bytes.Join(windows, nil)
+	}
+	if len(sample) == 0 {
+		return Profile{Type: TypeRandom}
 	}
-	sample := data[:sampleSize]
 
 	// Compute byte frequency histogram
 	var freq [256]int
@@ -219,14 +243,30 @@ func Detect(data []byte) Profile {
 		CodeScore:      codeScore,
 	}
 
+	// Known binary formats are recognised by their magic numbers, and
+	// need no further analysis
+	if profile.Binary = detectBinaryFormat(windows[0]); profile.Binary != BinaryNone {
+		profile.Type = profile.Binary.Type()
+		return profile
+	}
+
 	// First check for structured data formats (JSON, XML, etc.)
 	profile.DataFmt = detectDataFormat(sample)
+	if profile.DataFmt == DataFormatNone && isCSVBody(windows) {
+		// A table after a preamble
+		profile.DataFmt = DataFormatCSV
+	}
 
 	// Check for markup languages
 	profile.Markup = detectMarkup(sample)
 
+	// Markdown, HTML and notebooks can embed code in other languages
+	if full != nil && (profile.Markup == MarkupMarkdown || profile.Markup == MarkupHTML || profile.DataFmt == DataFormatJSON) {
+		profile.Segments = Segments(full)
+	}
+
 	// Detect natural language (for text content)
-	profile.NatLang = detectNatLang(sample)
+	profile.NatLang, profile.NatLangScores, profile.NatLangConfidence = detectNatLang(sample)
 
 	// Classify data type
 	switch {
@@ -234,16 +274,12 @@ func Detect(data []byte) Profile {
 		// Structured data is a type of code
 		profile.Type = TypeCode
 	case profile.Markup != MarkupNone:
-		// Markup is a type of text (unless it's HTML with scripts)
-		if profile.Markup == MarkupHTML && codeScore >= 0.4 {
-			profile.Type = TypeCode
-			profile.Language = CodeLangJavaScript // likely has embedded JS
-		} else {
-			profile.Type = TypeText
-		}
+		// Markup is a type of text, whatever it embeds: the languages of
+		// scripts and code blocks are for the segments to tell
+		profile.Type = TypeText
 	case asciiRatio > 0.85 && codeScore >= 0.4:
 		profile.Type = TypeCode
-		profile.Language = detectLanguage(sample)
+		profile.Language, profile.LanguageScores, profile.LanguageConfidence = detectLanguage(windows...)
 	case asciiRatio > 0.85:
 		profile.Type = TypeText
 	case repetitionRate > 0.3:
@@ -346,428 +382,6 @@ func computeCodeScore(data []byte, freq []int) float64 {
 	return score
 }
 
-// detectLanguage identifies the programming language of source code.
-func detectLanguage(data []byte) CodeLang {
-	goScore := 0
-	pyScore := 0
-	jsScore := 0
-
-	// Go indicators
-	if bytes.Contains(data, []byte("package ")) {
-		goScore += 3
-	}
-	if bytes.Contains(data, []byte("func ")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte(":= ")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("if err != nil")) {
-		goScore += 3
-	}
-	if bytes.Contains(data, []byte("import (")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("defer ")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("go func")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("chan ")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("interface{")) {
-		goScore += 2
-	}
-	if bytes.Contains(data, []byte("struct {")) {
-		goScore += 2
-	}
-
-	// Python indicators
-	if bytes.Contains(data, []byte("def ")) {
-		pyScore += 2
-	}
-	if bytes.Contains(data, []byte("class ")) {
-		pyScore += 1 // Also in other languages
-	}
-	if bytes.Contains(data, []byte("import ")) {
-		pyScore += 1 // Also in JS/Go
-	}
-	if bytes.Contains(data, []byte("from ")) && bytes.Contains(data, []byte(" import ")) {
-		pyScore += 3
-	}
-	if bytes.Contains(data, []byte("self.")) {
-		pyScore += 3
-	}
-	if bytes.Contains(data, []byte("__init__")) {
-		pyScore += 3
-	}
-	if bytes.Contains(data, []byte("__name__")) {
-		pyScore += 3
-	}
-	if bytes.Contains(data, []byte("elif ")) {
-		pyScore += 3
-	}
-	if bytes.Contains(data, []byte("True")) || bytes.Contains(data, []byte("False")) {
-		pyScore += 1
-	}
-	if bytes.Contains(data, []byte("None")) {
-		pyScore += 1
-	}
-	if bytes.Contains(data, []byte("async def")) {
-		pyScore += 2
-	}
-	if bytes.Contains(data, []byte("await ")) {
-		pyScore += 1 // Also in JS
-	}
-	// Python uses significant indentation - check for 4-space indents
-	if bytes.Count(data, []byte("    ")) > bytes.Count(data, []byte("\t")) {
-		pyScore += 1
-	}
-
-	// JavaScript/TypeScript indicators
-	if bytes.Contains(data, []byte("const ")) {
-		jsScore += 2
-	}
-	if bytes.Contains(data, []byte("let ")) {
-		jsScore += 2
-	}
-	if bytes.Contains(data, []byte("var ")) {
-		jsScore += 1
-	}
-	if bytes.Contains(data, []byte("function ")) {
-		jsScore += 1 // Could be PHP too
-	}
-	if bytes.Contains(data, []byte("=> ")) {
-		jsScore += 3
-	}
-	if bytes.Contains(data, []byte("async ")) {
-		jsScore += 1 // Also in Python
-	}
-	if bytes.Contains(data, []byte("export ")) {
-		jsScore += 2
-	}
-	if bytes.Contains(data, []byte("require(")) {
-		jsScore += 3
-	}
-	if bytes.Contains(data, []byte("module.exports")) {
-		jsScore += 3
-	}
-	if bytes.Contains(data, []byte("console.log")) {
-		jsScore += 2
-	}
-	if bytes.Contains(data, []byte("null")) {
-		jsScore += 1
-	}
-	if bytes.Contains(data, []byte("undefined")) {
-		jsScore += 2
-	}
-	if bytes.Contains(data, []byte("interface ")) {
-		jsScore += 1 // TypeScript
-	}
-	if bytes.Contains(data, []byte(": string")) || bytes.Contains(data, []byte(": number")) {
-		jsScore += 2 // TypeScript
-	}
-	if bytes.Contains(data, []byte("useState")) || bytes.Contains(data, []byte("useEffect")) {
-		jsScore += 3 // React
-	}
-
-	// Java indicators
-	javaScore := 0
-	if bytes.Contains(data, []byte("public class ")) {
-		javaScore += 4
-	}
-	if bytes.Contains(data, []byte("private ")) {
-		javaScore += 1
-	}
-	if bytes.Contains(data, []byte("public static void main")) {
-		javaScore += 5
-	}
-	if bytes.Contains(data, []byte("System.out.println")) {
-		javaScore += 4
-	}
-	if bytes.Contains(data, []byte("import java.")) {
-		javaScore += 4
-	}
-	if bytes.Contains(data, []byte("@Override")) {
-		javaScore += 3
-	}
-	if bytes.Contains(data, []byte("throws ")) {
-		javaScore += 2
-	}
-	if bytes.Contains(data, []byte("extends ")) {
-		javaScore += 1
-	}
-	if bytes.Contains(data, []byte("implements ")) {
-		javaScore += 2
-	}
-
-	// C indicators
-	cScore := 0
-	if bytes.Contains(data, []byte("#include <")) {
-		cScore += 4
-	}
-	if bytes.Contains(data, []byte("#include \"")) {
-		cScore += 3
-	}
-	if bytes.Contains(data, []byte("#define ")) {
-		cScore += 3
-	}
-	if bytes.Contains(data, []byte("int main(")) {
-		cScore += 4
-	}
-	if bytes.Contains(data, []byte("printf(")) {
-		cScore += 3
-	}
-	if bytes.Contains(data, []byte("malloc(")) || bytes.Contains(data, []byte("free(")) {
-		cScore += 3
-	}
-	if bytes.Contains(data, []byte("sizeof(")) {
-		cScore += 2
-	}
-	if bytes.Contains(data, []byte("typedef ")) {
-		cScore += 2
-	}
-	if bytes.Contains(data, []byte("->")) {
-		cScore += 1
-	}
-
-	// C++ indicators
-	cppScore := cScore // Inherits from C
-	if bytes.Contains(data, []byte("std::")) {
-		cppScore += 4
-	}
-	if bytes.Contains(data, []byte("cout <<")) || bytes.Contains(data, []byte("cin >>")) {
-		cppScore += 4
-	}
-	if bytes.Contains(data, []byte("namespace ")) {
-		cppScore += 3
-	}
-	if bytes.Contains(data, []byte("template<")) || bytes.Contains(data, []byte("template <")) {
-		cppScore += 4
-	}
-	if bytes.Contains(data, []byte("class ")) && bytes.Contains(data, []byte("public:")) {
-		cppScore += 3
-	}
-	if bytes.Contains(data, []byte("virtual ")) {
-		cppScore += 2
-	}
-	if bytes.Contains(data, []byte("nullptr")) {
-		cppScore += 3
-	}
-	if bytes.Contains(data, []byte("new ")) && bytes.Contains(data, []byte("delete ")) {
-		cppScore += 2
-	}
-
-	// C# indicators
-	csScore := 0
-	if bytes.Contains(data, []byte("using System")) {
-		csScore += 4
-	}
-	if bytes.Contains(data, []byte("namespace ")) && bytes.Contains(data, []byte("class ")) {
-		csScore += 2
-	}
-	if bytes.Contains(data, []byte("Console.WriteLine")) {
-		csScore += 4
-	}
-	if bytes.Contains(data, []byte("public async Task")) {
-		csScore += 4
-	}
-	if bytes.Contains(data, []byte("[Attribute]")) || bytes.Contains(data, []byte("[SerializeField]")) {
-		csScore += 3
-	}
-	if bytes.Contains(data, []byte("get;")) || bytes.Contains(data, []byte("set;")) {
-		csScore += 3
-	}
-	if bytes.Contains(data, []byte("var ")) && bytes.Contains(data, []byte("new ")) {
-		csScore += 1
-	}
-	if bytes.Contains(data, []byte("=> ")) && bytes.Contains(data, []byte("public ")) {
-		csScore += 2
-	}
-
-	// Ruby indicators
-	rubyScore := 0
-	if bytes.Contains(data, []byte("def ")) && bytes.Contains(data, []byte("end")) {
-		rubyScore += 3
-	}
-	if bytes.Contains(data, []byte("require '")) || bytes.Contains(data, []byte("require \"")) {
-		rubyScore += 3
-	}
-	if bytes.Contains(data, []byte("puts ")) {
-		rubyScore += 3
-	}
-	if bytes.Contains(data, []byte("attr_accessor")) || bytes.Contains(data, []byte("attr_reader")) {
-		rubyScore += 4
-	}
-	if bytes.Contains(data, []byte("do |")) {
-		rubyScore += 3
-	}
-	if bytes.Contains(data, []byte(".each ")) || bytes.Contains(data, []byte(".map ")) {
-		rubyScore += 2
-	}
-	if bytes.Contains(data, []byte("@")) && bytes.Contains(data, []byte("def ")) {
-		rubyScore += 2 // Instance variables
-	}
-	if bytes.Contains(data, []byte("unless ")) || bytes.Contains(data, []byte("elsif ")) {
-		rubyScore += 2
-	}
-	if bytes.Contains(data, []byte("module ")) {
-		rubyScore += 2
-	}
-
-	// Rust indicators
-	rustScore := 0
-	if bytes.Contains(data, []byte("fn ")) && bytes.Contains(data, []byte("let ")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("fn main()")) {
-		rustScore += 4
-	}
-	if bytes.Contains(data, []byte("println!(")) || bytes.Contains(data, []byte("print!(")) {
-		rustScore += 4
-	}
-	if bytes.Contains(data, []byte("use std::")) {
-		rustScore += 4
-	}
-	if bytes.Contains(data, []byte("mut ")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("impl ")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("pub fn ")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("match ")) && bytes.Contains(data, []byte("=>")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("Option<")) || bytes.Contains(data, []byte("Result<")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("&self")) || bytes.Contains(data, []byte("&mut self")) {
-		rustScore += 3
-	}
-	if bytes.Contains(data, []byte("unwrap()")) {
-		rustScore += 2
-	}
-
-	// PHP indicators
-	phpScore := 0
-	if bytes.Contains(data, []byte("<?php")) {
-		phpScore += 5
-	}
-	if bytes.Contains(data, []byte("echo ")) {
-		phpScore += 2
-	}
-	if bytes.Contains(data, []byte("$")) && bytes.Contains(data, []byte("function ")) {
-		phpScore += 3
-	}
-	if bytes.Contains(data, []byte("->")) && bytes.Contains(data, []byte("$this")) {
-		phpScore += 4
-	}
-	if bytes.Contains(data, []byte("namespace ")) && bytes.Contains(data, []byte("use ")) {
-		phpScore += 2
-	}
-	if bytes.Contains(data, []byte("array(")) || bytes.Contains(data, []byte("=>")) {
-		phpScore += 1
-	}
-
-	// Swift indicators
-	swiftScore := 0
-	if bytes.Contains(data, []byte("import Foundation")) || bytes.Contains(data, []byte("import UIKit")) {
-		swiftScore += 5
-	}
-	if bytes.Contains(data, []byte("func ")) && bytes.Contains(data, []byte("->")) {
-		swiftScore += 3
-	}
-	if bytes.Contains(data, []byte("var ")) && bytes.Contains(data, []byte(": ")) {
-		swiftScore += 2
-	}
-	if bytes.Contains(data, []byte("let ")) && bytes.Contains(data, []byte(": ")) {
-		swiftScore += 2
-	}
-	if bytes.Contains(data, []byte("guard ")) || bytes.Contains(data, []byte("if let ")) {
-		swiftScore += 4
-	}
-	if bytes.Contains(data, []byte("print(")) && !bytes.Contains(data, []byte("println")) {
-		swiftScore += 2
-	}
-	if bytes.Contains(data, []byte("@IBOutlet")) || bytes.Contains(data, []byte("@IBAction")) {
-		swiftScore += 4
-	}
-	if bytes.Contains(data, []byte("struct ")) && bytes.Contains(data, []byte(": ")) {
-		swiftScore += 2
-	}
-
-	// Kotlin indicators
-	kotlinScore := 0
-	if bytes.Contains(data, []byte("fun ")) && bytes.Contains(data, []byte(": ")) {
-		kotlinScore += 3
-	}
-	if bytes.Contains(data, []byte("fun main(")) {
-		kotlinScore += 4
-	}
-	if bytes.Contains(data, []byte("println(")) {
-		kotlinScore += 2
-	}
-	if bytes.Contains(data, []byte("val ")) || bytes.Contains(data, []byte("var ")) {
-		kotlinScore += 1
-	}
-	if bytes.Contains(data, []byte("?.")) || bytes.Contains(data, []byte("!!")) {
-		kotlinScore += 3
-	}
-	if bytes.Contains(data, []byte("data class ")) {
-		kotlinScore += 4
-	}
-	if bytes.Contains(data, []byte("suspend fun ")) {
-		kotlinScore += 4
-	}
-	if bytes.Contains(data, []byte("companion object")) {
-		kotlinScore += 4
-	}
-	if bytes.Contains(data, []byte("import kotlin")) || bytes.Contains(data, []byte("import android")) {
-		kotlinScore += 4
-	}
-
-	// Determine winner
-	scores := []struct {
-		score int
-		lang  CodeLang
-	}{
-		{goScore, CodeLangGo},
-		{pyScore, CodeLangPython},
-		{jsScore, CodeLangJavaScript},
-		{javaScore, CodeLangJava},
-		{cScore, CodeLangC},
-		{cppScore, CodeLangCPP},
-		{csScore, CodeLangCSharp},
-		{rubyScore, CodeLangRuby},
-		{rustScore, CodeLangRust},
-		{phpScore, CodeLangPHP},
-		{swiftScore, CodeLangSwift},
-		{kotlinScore, CodeLangKotlin},
-	}
-
-	maxScore := 0
-	lang := CodeLangUnknown
-
-	for _, s := range scores {
-		if s.score > maxScore {
-			maxScore = s.score
-			lang = s.lang
-		}
-	}
-
-	// Minimum threshold to claim a language
-	if maxScore < 3 {
-		return CodeLangUnknown
-	}
-
-	return lang
-}
-
 // detectDataFormat identifies structured data formats.
 func detectDataFormat(data []byte) DataFormat {
 	if len(data) == 0 {
@@ -933,199 +547,6 @@ func detectMarkup(data []byte) MarkupLang {
 	return MarkupNone
 }
 
-// detectNatLang identifies the natural/human language of text.
-func detectNatLang(data []byte) NatLang {
-	if len(data) == 0 {
-		return NatLangUnknown
-	}
-
-	// Check for non-Latin scripts first (by Unicode range)
-	var hasArabic, hasChinese, hasJapanese, hasHindi, hasBengali, hasRussian bool
-
-	for i := 0; i < len(data); {
-		r, size := decodeRune(data[i:])
-		if size == 0 {
-			i++
-			continue
-		}
-
-		switch {
-		case r >= 0x0600 && r <= 0x06FF: // Arabic
-			hasArabic = true
-		case r >= 0x4E00 && r <= 0x9FFF: // CJK Unified Ideographs (Chinese)
-			hasChinese = true
-		case r >= 0x3040 && r <= 0x30FF: // Hiragana + Katakana (Japanese)
-			hasJapanese = true
-		case r >= 0x0900 && r <= 0x097F: // Devanagari (Hindi)
-			hasHindi = true
-		case r >= 0x0980 && r <= 0x09FF: // Bengali
-			hasBengali = true
-		case r >= 0x0400 && r <= 0x04FF: // Cyrillic (Russian)
-			hasRussian = true
-		}
-		i += size
-	}
-
-	// Non-Latin scripts take priority
-	if hasArabic {
-		return NatLangArabic
-	}
-	if hasJapanese {
-		return NatLangJapanese
-	}
-	if hasChinese {
-		return NatLangChinese
-	}
-	if hasHindi {
-		return NatLangHindi
-	}
-	if hasBengali {
-		return NatLangBengali
-	}
-	if hasRussian {
-		return NatLangRussian
-	}
-
-	// For Latin scripts, use common word detection
-	lower := bytes.ToLower(data)
-
-	// Spanish indicators
-	esScore := 0
-	for _, word := range [][]byte{
-		[]byte(" el "), []byte(" la "), []byte(" los "), []byte(" las "),
-		[]byte(" de "), []byte(" en "), []byte(" que "), []byte(" es "),
-		[]byte(" un "), []byte(" una "), []byte(" para "), []byte(" con "),
-		[]byte(" por "), []byte("ción"), []byte("ñ"),
-	} {
-		if bytes.Contains(lower, word) {
-			esScore++
-		}
-	}
-
-	// French indicators
-	frScore := 0
-	for _, word := range [][]byte{
-		[]byte(" le "), []byte(" la "), []byte(" les "), []byte(" de "),
-		[]byte(" et "), []byte(" est "), []byte(" un "), []byte(" une "),
-		[]byte(" que "), []byte(" pour "), []byte(" avec "), []byte(" dans "),
-		[]byte("ç"), []byte("œ"), []byte("ê"), []byte("è"),
-	} {
-		if bytes.Contains(lower, word) {
-			frScore++
-		}
-	}
-
-	// Portuguese indicators
-	ptScore := 0
-	for _, word := range [][]byte{
-		[]byte(" o "), []byte(" a "), []byte(" os "), []byte(" as "),
-		[]byte(" de "), []byte(" em "), []byte(" que "), []byte(" é "),
-		[]byte(" um "), []byte(" uma "), []byte(" para "), []byte(" com "),
-		[]byte(" não "), []byte("ção"), []byte("ã"),
-	} {
-		if bytes.Contains(lower, word) {
-			ptScore++
-		}
-	}
-
-	// German indicators
-	deScore := 0
-	for _, word := range [][]byte{
-		[]byte(" der "), []byte(" die "), []byte(" das "), []byte(" und "),
-		[]byte(" ist "), []byte(" ein "), []byte(" eine "), []byte(" für "),
-		[]byte(" mit "), []byte(" auf "), []byte(" nicht "), []byte(" sich "),
-		[]byte("ß"), []byte("ü"), []byte("ö"), []byte("ä"),
-	} {
-		if bytes.Contains(lower, word) {
-			deScore++
-		}
-	}
-
-	// Italian indicators
-	itScore := 0
-	for _, word := range [][]byte{
-		[]byte(" il "), []byte(" la "), []byte(" i "), []byte(" le "),
-		[]byte(" di "), []byte(" che "), []byte(" è "), []byte(" un "),
-		[]byte(" una "), []byte(" per "), []byte(" con "), []byte(" non "),
-		[]byte(" sono "), []byte(" della "),
-	} {
-		if bytes.Contains(lower, word) {
-			itScore++
-		}
-	}
-
-	// Dutch indicators
-	nlScore := 0
-	for _, word := range [][]byte{
-		[]byte(" de "), []byte(" het "), []byte(" een "), []byte(" van "),
-		[]byte(" en "), []byte(" is "), []byte(" op "), []byte(" te "),
-		[]byte(" dat "), []byte(" niet "), []byte(" met "), []byte(" voor "),
-		[]byte("ij"), []byte("oe"),
-	} {
-		if bytes.Contains(lower, word) {
-			nlScore++
-		}
-	}
-
-	// Indonesian/Malay indicators
-	idScore := 0
-	for _, word := range [][]byte{
-		[]byte(" yang "), []byte(" dan "), []byte(" di "), []byte(" ini "),
-		[]byte(" itu "), []byte(" dengan "), []byte(" untuk "), []byte(" dari "),
-		[]byte(" pada "), []byte(" adalah "), []byte(" tidak "), []byte(" ke "),
-		[]byte("kan "), []byte("nya "),
-	} {
-		if bytes.Contains(lower, word) {
-			idScore++
-		}
-	}
-
-	// English indicators (baseline)
-	enScore := 0
-	for _, word := range [][]byte{
-		[]byte(" the "), []byte(" and "), []byte(" is "), []byte(" to "),
-		[]byte(" of "), []byte(" a "), []byte(" in "), []byte(" that "),
-		[]byte(" it "), []byte(" for "), []byte(" with "), []byte(" as "),
-		[]byte(" was "), []byte(" are "), []byte(" be "), []byte(" have "),
-	} {
-		if bytes.Contains(lower, word) {
-			enScore++
-		}
-	}
-
-	// Find the highest scoring language
-	scores := []struct {
-		score int
-		lang  NatLang
-	}{
-		{enScore, NatLangEnglish},
-		{esScore, NatLangSpanish},
-		{frScore, NatLangFrench},
-		{ptScore, NatLangPortuguese},
-		{deScore, NatLangGerman},
-		{itScore, NatLangItalian},
-		{nlScore, NatLangDutch},
-		{idScore, NatLangIndonesian},
-	}
-
-	maxScore := 0
-	lang := NatLangUnknown
-
-	for _, s := range scores {
-		if s.score > maxScore {
-			maxScore = s.score
-			lang = s.lang
-		}
-	}
-
-	// Require minimum confidence
-	if maxScore < 3 {
-		return NatLangUnknown
-	}
-
-	return lang
-}
-
 // decodeRune decodes the first UTF-8 rune from data.
 // Returns the rune and its byte length, or (0, 0) for invalid encoding.
 func decodeRune(data []byte) (rune, int) {
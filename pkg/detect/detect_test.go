@@ -143,6 +143,9 @@ func TestTypeString(t *testing.T) {
 		{TypeRepetitive, "repetitive"},
 		{TypeLowEntropy, "low-entropy"},
 		{TypeRandom, "random"},
+		{TypeCompressed, "compressed"},
+		{TypeMedia, "media"},
+		{TypeExecutable, "executable"},
 		{Type(99), "unknown"},
 	}
 
@@ -632,6 +635,33 @@ fun main(args: Array<String>) {
 }`,
 			want: CodeLangKotlin,
 		},
+		{
+			name: "JavaScript module with import and class",
+			code: `import React from 'react';
+import { useState } from 'react';
+
+export default class Counter extends React.Component {
+  render() {
+    return null;
+  }
+}`,
+			want: CodeLangJavaScript,
+		},
+		{
+			name: "short script with shebang",
+			code: `#!/usr/bin/env python3
+import sys
+print(sys.argv)`,
+			want: CodeLangPython,
+		},
+		{
+			name: "C with a C++ modeline",
+			code: `int add(int a, int b) {
+    return a + b;
+}
+// vim: set ft=cpp:`,
+			want: CodeLangCPP,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -644,6 +674,77 @@ fun main(args: Array<String>) {
 	}
 }
 
+func TestScoreCodeLang(t *testing.T) {
+	src := []byte(`fn main() {
+    let mut total = 0;
+    for i in 0..10 {
+        total += i;
+    }
+    println!("{}", total);
+}`)
+	scores := ScoreCodeLang(src)
+	if len(scores) != int(CodeLangKotlin) {
+		t.Fatalf("got %d scores, want one per language (%d)", len(scores), CodeLangKotlin)
+	}
+
+	seen := make(map[CodeLang]bool)
+	var sum float64
+	for i, s := range scores {
+		if i > 0 && s.Score > scores[i-1].Score {
+			t.Errorf("scores not ranked: %v before %v", scores[i-1], s)
+		}
+		seen[s.Lang] = true
+		sum += s.Score
+	}
+	for lang := CodeLangGo; lang <= CodeLangKotlin; lang++ {
+		if !seen[lang] {
+			t.Errorf("no score for %v", lang)
+		}
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("scores sum to %f, want 1", sum)
+	}
+	if scores[0].Lang != CodeLangRust {
+		t.Errorf("best: got %v, want Rust", scores[0].Lang)
+	}
+
+	// Code in no supported language is not claimed by one
+	lang, _, confidence := detectLanguage([]byte("SELECT name, count(*) FROM users WHERE id > 10 GROUP BY name;\n"))
+	if lang != CodeLangUnknown {
+		t.Errorf("SQL: got %v (confidence %.2f), want Unknown", lang, confidence)
+	}
+	if got := ScoreCodeLang([]byte("x = 1\n")); got != nil {
+		t.Errorf("too few tokens: got %v, want nil", got)
+	}
+}
+
+func TestCodeLangHint(t *testing.T) {
+	testCases := []struct {
+		src  string
+		want CodeLang
+	}{
+		{"#!/usr/bin/python3\n", CodeLangPython},
+		{"#!/usr/bin/env ruby\n", CodeLangRuby},
+		{"#!/usr/bin/env -S node --harmony\n", CodeLangJavaScript},
+		{"#!/usr/local/bin/python3.11 -u\n", CodeLangPython},
+		{"#!/bin/sh\n", CodeLangUnknown},
+		{"#!/\n", CodeLangUnknown},
+		{"// -*- mode: rust -*-\nfn f() {}\n", CodeLangRust},
+		{"# -*- coding: utf-8; mode: python -*-\n", CodeLangPython},
+		{"/* -*- C++ -*- */\n", CodeLangCPP},
+		{"x\n# vim: set filetype=ruby:\n", CodeLangRuby},
+		{"x\n// vim: ts=4 ft=kotlin\n", CodeLangKotlin},
+		{"# vim: ts=4 sw=4\n", CodeLangUnknown},
+		{"no hints here\n", CodeLangUnknown},
+	}
+
+	for _, tc := range testCases {
+		if got := codeLangHint([]byte(tc.src)); got != tc.want {
+			t.Errorf("codeLangHint(%q): got %v, want %v", tc.src, got, tc.want)
+		}
+	}
+}
+
 // Tests for data format detection
 func TestDetectDataFormats(t *testing.T) {
 	testCases := []struct {
@@ -846,6 +947,29 @@ print("hello")
 	}
 }
 
+// HTML is markup text however much script it carries; the script's
+// language belongs to its segment
+func TestDetectHTMLWithScripts(t *testing.T) {
+	data := "<!DOCTYPE html>\n<html><head><title>Demo</title>\n<script>\n" +
+		strings.Repeat("function update(items) {\n  for (const item of items) {\n    document.querySelectorAll('.row').forEach((el) => { el.textContent = item.name; });\n  }\n}\n", 20) +
+		"</script>\n</head>\n<body>\n" +
+		strings.Repeat("<p class=\"row\">An item of the list, filled in when the page loads.</p>\n", 4) +
+		"</body></html>\n"
+
+	profile := Detect([]byte(data))
+	if profile.Markup != MarkupHTML || profile.Type != TypeText || profile.Language != CodeLangUnknown {
+		t.Errorf("got markup %v, type %v, language %v; want HTML text with no language",
+			profile.Markup, profile.Type, profile.Language)
+	}
+	var script bool
+	for _, seg := range profile.Segments {
+		script = script || seg.Language == CodeLangJavaScript
+	}
+	if !script {
+		t.Errorf("no JavaScript segment in %+v", profile.Segments)
+	}
+}
+
 // Tests for natural language detection
 func TestDetectNatLang(t *testing.T) {
 	testCases := []struct {
@@ -913,6 +1037,26 @@ func TestDetectNatLang(t *testing.T) {
 			text: "Rubah coklat yang cepat melompati anjing yang malas. Ini adalah tes dari sistem deteksi bahasa Indonesia. Ini seharusnya bekerja dengan benar untuk teks yang lebih panjang.",
 			want: NatLangIndonesian,
 		},
+		{
+			name: "Hindi",
+			text: "तेज़ भूरी लोमड़ी आलसी कुत्ते के ऊपर कूदती है। यह हिंदी भाषा पहचान प्रणाली का परीक्षण है। इसे लंबे पाठों के लिए सही ढंग से काम करना चाहिए।",
+			want: NatLangHindi,
+		},
+		{
+			name: "Bengali",
+			text: "দ্রুত বাদামী শিয়াল অলস কুকুরের উপর দিয়ে লাফ দেয়। এটি বাংলা ভাষা সনাক্তকরণ ব্যবস্থার একটি পরীক্ষা। এটি দীর্ঘ লেখার জন্য সঠিকভাবে কাজ করা উচিত।",
+			want: NatLangBengali,
+		},
+		{
+			name: "English with a Cyrillic word",
+			text: "The report was written in English, but the author quoted one word, спасибо, from a letter. The rest of the document is ordinary English prose about the project.",
+			want: NatLangEnglish,
+		},
+		{
+			name: "English with a Chinese name",
+			text: "Our new colleague 王 joined the team last week and will be working on the storage layer with the rest of us until the end of the year.",
+			want: NatLangEnglish,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -925,6 +1069,70 @@ func TestDetectNatLang(t *testing.T) {
 	}
 }
 
+func TestScoreNatLang(t *testing.T) {
+	text := []byte("Der schnelle braune Fuchs springt über den faulen Hund, und die Kinder sehen ihm vom Fenster aus zu.")
+	scores := ScoreNatLang(text)
+	if len(scores) != int(NatLangJapanese) {
+		t.Fatalf("got %d scores, want one per language (%d)", len(scores), NatLangJapanese)
+	}
+
+	seen := make(map[NatLang]bool)
+	var sum float64
+	for i, s := range scores {
+		if i > 0 && s.Score > scores[i-1].Score {
+			t.Errorf("scores not ranked: %v before %v", scores[i-1], s)
+		}
+		seen[s.Lang] = true
+		sum += s.Score
+	}
+	for lang := NatLangEnglish; lang <= NatLangJapanese; lang++ {
+		if !seen[lang] {
+			t.Errorf("no score for %v", lang)
+		}
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("scores sum to %f, want 1", sum)
+	}
+	if scores[0].Lang != NatLangGerman {
+		t.Errorf("best: got %v, want German", scores[0].Lang)
+	}
+
+	profile := Detect(text)
+	if profile.NatLangConfidence != scores[0].Score || len(profile.NatLangScores) != len(scores) {
+		t.Errorf("profile: confidence %f with %d scores, want %f with %d",
+			profile.NatLangConfidence, len(profile.NatLangScores), scores[0].Score, len(scores))
+	}
+
+	if got := ScoreNatLang([]byte("ok")); got != nil {
+		t.Errorf("short text: got %v, want nil", got)
+	}
+}
+
+func TestNGrams(t *testing.T) {
+	grams := NGrams([]byte("Abba!"), 3)
+	// " abba ": every n-gram occurs fewer than ProfileMinCount times
+	if len(grams) != 0 {
+		t.Errorf("got %v, want no n-grams seen %d times", grams, ProfileMinCount)
+	}
+
+	grams = NGrams([]byte("Abba, ABBA! abba"), 3)
+	// " abba abba abba ": 12 letters, 15 bigrams and 14 trigrams
+	want := []NGram{{"a", 146341}, {"b", 146341}, {" a", 73170}}
+	if len(grams) != len(want) {
+		t.Fatalf("got %v, want %v", grams, want)
+	}
+	for i := range want {
+		if grams[i] != want[i] {
+			t.Errorf("gram %d: got %v, want %v", i, grams[i], want[i])
+		}
+	}
+	for _, g := range NGrams([]byte("Abba, ABBA! abba"), 100) {
+		if g.Text == "a a" {
+			t.Errorf("%q occurs twice but is in the profile", g.Text)
+		}
+	}
+}
+
 // Test decodeRune helper
 func TestDecodeRune(t *testing.T) {
 	testCases := []struct {
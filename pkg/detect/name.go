@@ -0,0 +1,351 @@
+package detect
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// nameHintBonus is the log-odds bonus a file name gives the programming
+// language it implies: enough to settle a close call between languages,
+// but far less than a shebang, so content that is plainly another
+// language still wins.
+const nameHintBonus = 4
+
+// nameOnlyConfidence is the language confidence given when only the name
+// says what the language is, the content being too short to tell.
+const nameOnlyConfidence = 0.9
+
+// Hint is what a file name says about its content. A zero field says
+// nothing, except that code of an unknown language is code in none of the
+// languages detect classifies (a Makefile, a shell script).
+type Hint struct {
+	Type     Type // TypeText or TypeCode, if the name implies one
+	Language CodeLang
+	DataFmt  DataFormat
+	Markup   MarkupLang
+}
+
+// extensionHints maps lowercase file extensions to hints.
+var extensionHints = map[string]Hint{
+	".go":    {Type: TypeCode, Language: CodeLangGo},
+	".py":    {Type: TypeCode, Language: CodeLangPython},
+	".pyi":   {Type: TypeCode, Language: CodeLangPython},
+	".pyw":   {Type: TypeCode, Language: CodeLangPython},
+	".js":    {Type: TypeCode, Language: CodeLangJavaScript},
+	".mjs":   {Type: TypeCode, Language: CodeLangJavaScript},
+	".cjs":   {Type: TypeCode, Language: CodeLangJavaScript},
+	".jsx":   {Type: TypeCode, Language: CodeLangJavaScript},
+	".ts":    {Type: TypeCode, Language: CodeLangJavaScript},
+	".mts":   {Type: TypeCode, Language: CodeLangJavaScript},
+	".tsx":   {Type: TypeCode, Language: CodeLangJavaScript},
+	".java":  {Type: TypeCode, Language: CodeLangJava},
+	".c":     {Type: TypeCode, Language: CodeLangC},
+	".h":     {Type: TypeCode, Language: CodeLangC},
+	".cc":    {Type: TypeCode, Language: CodeLangCPP},
+	".cpp":   {Type: TypeCode, Language: CodeLangCPP},
+	".cxx":   {Type: TypeCode, Language: CodeLangCPP},
+	".hh":    {Type: TypeCode, Language: CodeLangCPP},
+	".hpp":   {Type: TypeCode, Language: CodeLangCPP},
+	".hxx":   {Type: TypeCode, Language: CodeLangCPP},
+	".cs":    {Type: TypeCode, Language: CodeLangCSharp},
+	".rb":    {Type: TypeCode, Language: CodeLangRuby},
+	".rake":  {Type: TypeCode, Language: CodeLangRuby},
+	".rs":    {Type: TypeCode, Language: CodeLangRust},
+	".php":   {Type: TypeCode, Language: CodeLangPHP},
+	".swift": {Type: TypeCode, Language: CodeLangSwift},
+	".kt":    {Type: TypeCode, Language: CodeLangKotlin},
+	".kts":   {Type: TypeCode, Language: CodeLangKotlin},
+	".sh":    {Type: TypeCode},
+	".bash":  {Type: TypeCode},
+	".sql":   {Type: TypeCode},
+	".mk":    {Type: TypeCode},
+
+	".json": {Type: TypeCode, DataFmt: DataFormatJSON},
+	".xml":  {Type: TypeCode, DataFmt: DataFormatXML},
+	".xsd":  {Type: TypeCode, DataFmt: DataFormatXML},
+	".xsl":  {Type: TypeCode, DataFmt: DataFormatXML},
+	".svg":  {Type: TypeCode, DataFmt: DataFormatXML},
+	".yaml": {Type: TypeCode, DataFmt: DataFormatYAML},
+	".yml":  {Type: TypeCode, DataFmt: DataFormatYAML},
+	".csv":  {Type: TypeCode, DataFmt: DataFormatCSV},
+	".toml": {Type: TypeCode, DataFmt: DataFormatTOML},
+	".ini":  {Type: TypeCode, DataFmt: DataFormatINI},
+	".cfg":  {Type: TypeCode, DataFmt: DataFormatINI},
+
+	".html":     {Markup: MarkupHTML},
+	".htm":      {Markup: MarkupHTML},
+	".xhtml":    {Markup: MarkupHTML},
+	".md":       {Type: TypeText, Markup: MarkupMarkdown},
+	".markdown": {Type: TypeText, Markup: MarkupMarkdown},
+	".tex":      {Type: TypeText, Markup: MarkupLaTeX},
+	".sty":      {Type: TypeText, Markup: MarkupLaTeX},
+	".rtf":      {Type: TypeText, Markup: MarkupRTF},
+	".rst":      {Type: TypeText, Markup: MarkupReST},
+	".adoc":     {Type: TypeText, Markup: MarkupAsciiDoc},
+	".asciidoc": {Type: TypeText, Markup: MarkupAsciiDoc},
+	".org":      {Type: TypeText, Markup: MarkupOrg},
+	".txt":      {Type: TypeText},
+}
+
+// fileNameHints maps well-known file names to hints. They take precedence
+// over extensions (package.json, CMakeLists.txt).
+var fileNameHints = map[string]Hint{
+	"Makefile":       {Type: TypeCode},
+	"makefile":       {Type: TypeCode},
+	"GNUmakefile":    {Type: TypeCode},
+	"Dockerfile":     {Type: TypeCode},
+	"Containerfile":  {Type: TypeCode},
+	"CMakeLists.txt": {Type: TypeCode},
+	"Jenkinsfile":    {Type: TypeCode},
+
+	"go.mod":  {Type: TypeCode, Language: CodeLangGo},
+	"go.work": {Type: TypeCode, Language: CodeLangGo},
+	"go.sum":  {Type: TypeCode},
+
+	"Gemfile":     {Type: TypeCode, Language: CodeLangRuby},
+	"Rakefile":    {Type: TypeCode, Language: CodeLangRuby},
+	"Podfile":     {Type: TypeCode, Language: CodeLangRuby},
+	"Vagrantfile": {Type: TypeCode, Language: CodeLangRuby},
+	"SConstruct":  {Type: TypeCode, Language: CodeLangPython},
+	"BUILD":       {Type: TypeCode, Language: CodeLangPython},
+	"WORKSPACE":   {Type: TypeCode, Language: CodeLangPython},
+
+	"package.json":      {Type: TypeCode, DataFmt: DataFormatJSON},
+	"package-lock.json": {Type: TypeCode, DataFmt: DataFormatJSON},
+	"tsconfig.json":     {Type: TypeCode, DataFmt: DataFormatJSON},
+	"composer.json":     {Type: TypeCode, DataFmt: DataFormatJSON},
+	"Cargo.toml":        {Type: TypeCode, DataFmt: DataFormatTOML},
+	"Cargo.lock":        {Type: TypeCode, DataFmt: DataFormatTOML},
+	"pyproject.toml":    {Type: TypeCode, DataFmt: DataFormatTOML},
+	"Pipfile":           {Type: TypeCode, DataFmt: DataFormatTOML},
+	".editorconfig":     {Type: TypeCode, DataFmt: DataFormatINI},
+	".gitconfig":        {Type: TypeCode, DataFmt: DataFormatINI},
+
+	"README":    {Type: TypeText},
+	"LICENSE":   {Type: TypeText},
+	"COPYING":   {Type: TypeText},
+	"AUTHORS":   {Type: TypeText},
+	"CHANGELOG": {Type: TypeText},
+	"NEWS":      {Type: TypeText},
+}
+
+// typeNames maps the type names of a mapping file to hints, besides the
+// programming language names of codeLangNames.
+var typeNames = map[string]Hint{
+	"text": {Type: TypeText},
+	"code": {Type: TypeCode},
+
+	"json": {Type: TypeCode, DataFmt: DataFormatJSON},
+	"xml":  {Type: TypeCode, DataFmt: DataFormatXML},
+	"yaml": {Type: TypeCode, DataFmt: DataFormatYAML},
+	"csv":  {Type: TypeCode, DataFmt: DataFormatCSV},
+	"toml": {Type: TypeCode, DataFmt: DataFormatTOML},
+	"ini":  {Type: TypeCode, DataFmt: DataFormatINI},
+
+	"html":     {Markup: MarkupHTML},
+	"markdown": {Type: TypeText, Markup: MarkupMarkdown},
+	"latex":    {Type: TypeText, Markup: MarkupLaTeX},
+	"rtf":      {Type: TypeText, Markup: MarkupRTF},
+	"rst":      {Type: TypeText, Markup: MarkupReST},
+	"asciidoc": {Type: TypeText, Markup: MarkupAsciiDoc},
+	"org":      {Type: TypeText, Markup: MarkupOrg},
+}
+
+// NameMap holds user mappings from file name patterns to types, which
+// override the built-in extension and file name hints. The nil *NameMap
+// has no mappings.
+type NameMap struct {
+	rules []nameRule
+}
+
+type nameRule struct {
+	pattern string
+	hint    Hint
+}
+
+// ParseNameMap reads a mapping file: one "pattern type" pair per line,
+// blank lines and lines starting with # ignored. Patterns use path.Match
+// syntax and match the base name, or the whole slash-separated name if
+// they contain a slash. Types are programming languages (go, python,
+// javascript, java, c, cpp, csharp, ruby, rust, php, swift, kotlin), data
+// formats (json, xml, yaml, csv, toml, ini), markup (html, markdown,
+// latex, rtf, rst, asciidoc, org), or just text or code. When several
+// lines match a name, the last one wins.
+func ParseNameMap(r io.Reader) (*NameMap, error) {
+	m := &NameMap{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("detect: line %d: want \"pattern type\", got %q", n, line)
+		}
+		if _, err := path.Match(fields[0], ""); err != nil {
+			return nil, fmt.Errorf("detect: line %d: bad pattern %q", n, fields[0])
+		}
+		hint, ok := typeHint(fields[1])
+		if !ok {
+			return nil, fmt.Errorf("detect: line %d: unknown type %q", n, fields[1])
+		}
+		m.rules = append(m.rules, nameRule{pattern: fields[0], hint: hint})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadNameMap reads a mapping file (see ParseNameMap).
+func LoadNameMap(file string) (*NameMap, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	m, err := ParseNameMap(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return m, nil
+}
+
+// typeHint looks up a type name of a mapping file.
+func typeHint(name string) (Hint, bool) {
+	name = strings.ToLower(name)
+	if hint, ok := typeNames[name]; ok {
+		return hint, true
+	}
+	if lang, ok := codeLangNames[name]; ok {
+		return Hint{Type: TypeCode, Language: lang}, true
+	}
+	return Hint{}, false
+}
+
+// Hint returns what a file name says about its content: the last matching
+// user mapping, else a well-known file name, else the extension. Names may
+// carry a directory; a trailing slash means a directory, which says
+// nothing.
+func (m *NameMap) Hint(name string) (Hint, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasSuffix(name, "/") {
+		return Hint{}, false
+	}
+	base := path.Base(name)
+
+	if m != nil {
+		for i := len(m.rules) - 1; i >= 0; i-- {
+			rule := m.rules[i]
+			target := base
+			if strings.Contains(rule.pattern, "/") {
+				target = strings.TrimPrefix(name, "/")
+			}
+			if ok, _ := path.Match(strings.TrimPrefix(rule.pattern, "/"), target); ok {
+				return rule.hint, true
+			}
+		}
+	}
+
+	if hint, ok := fileNameHints[base]; ok {
+		return hint, true
+	}
+	if hint, ok := extensionHints[strings.ToLower(path.Ext(base))]; ok {
+		return hint, true
+	}
+	return Hint{}, false
+}
+
+// Detect is DetectWithName using the mappings of m.
+func (m *NameMap) Detect(name string, data []byte) Profile {
+	return m.DetectWith(name, data, DefaultSampling)
+}
+
+// DetectWith is Detect sampling data as s says.
+func (m *NameMap) DetectWith(name string, data []byte, s Sampling) Profile {
+	profile := DetectWith(data, s)
+	if hint, ok := m.Hint(name); ok {
+		applyHint(&profile, hint, s.windows(data))
+	}
+	return profile
+}
+
+// DetectWithName analyzes data like Detect, also using what its file name
+// says: the extension (.go, .json, .md) or a well-known name (Makefile,
+// go.mod, Dockerfile, package.json). The name settles what the content
+// leaves open, most usefully for short files, but does not turn binary
+// data into text, and a shebang or clearly different code still beats a
+// misleading extension.
+func DetectWithName(name string, data []byte) Profile {
+	return (*NameMap)(nil).Detect(name, data)
+}
+
+// applyHint combines a file name hint with a content profile, detected
+// from windows.
+func applyHint(profile *Profile, hint Hint, windows [][]byte) {
+	if profile.Type != TypeText && profile.Type != TypeCode {
+		return
+	}
+
+	if hint.Markup != MarkupNone {
+		profile.Markup = hint.Markup
+	}
+	if hint.DataFmt != DataFormatNone {
+		profile.DataFmt = hint.DataFmt
+	}
+	if hint.Type != TypeText && hint.Type != TypeCode {
+		return
+	}
+	profile.Type = hint.Type
+	if hint.Type != TypeCode || profile.DataFmt != DataFormatNone {
+		return
+	}
+
+	// Content detection only classifies what it took for code
+	if profile.LanguageScores == nil {
+		profile.Language, profile.LanguageScores, profile.LanguageConfidence = detectLanguage(windows...)
+	}
+	if hint.Language == CodeLangUnknown {
+		// Makefiles, shell scripts and the like: none of the classified
+		// languages, whatever their tokens resemble, unless a shebang says
+		// otherwise
+		if codeLangHint(windows...) == CodeLangUnknown {
+			profile.Language = CodeLangUnknown
+			profile.LanguageConfidence = 0
+		}
+		return
+	}
+	if profile.LanguageScores == nil {
+		profile.Language = hint.Language
+		profile.LanguageConfidence = nameOnlyConfidence
+		return
+	}
+
+	// Weigh the named language's probability by the bonus and renormalise
+	scores := append([]CodeLangScore(nil), profile.LanguageScores...)
+	var norm float64
+	for i := range scores {
+		if scores[i].Lang == hint.Language {
+			scores[i].Score *= math.Exp(nameHintBonus)
+		}
+		norm += scores[i].Score
+	}
+	for i := range scores {
+		scores[i].Score /= norm
+	}
+	sort.SliceStable(scores, func(a, b int) bool { return scores[a].Score > scores[b].Score })
+
+	profile.LanguageScores = scores
+	profile.LanguageConfidence = scores[0].Score
+	profile.Language = CodeLangUnknown
+	if scores[0].Score >= minCodeLangConfidence {
+		profile.Language = scores[0].Lang
+	}
+}
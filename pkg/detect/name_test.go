@@ -0,0 +1,126 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectWithName(t *testing.T) {
+	binary := make([]byte, 1000)
+	for i := range binary {
+		binary[i] = byte(i * 37 % 256)
+	}
+
+	testCases := []struct {
+		name     string
+		data     string
+		wantType Type
+		wantLang CodeLang
+		wantFmt  DataFormat
+		wantMark MarkupLang
+	}{
+		{"cmd/main.go", "x := f()\n", TypeCode, CodeLangGo, DataFormatNone, MarkupNone},
+		{"lib/util.py", "x = f()\n", TypeCode, CodeLangPython, DataFormatNone, MarkupNone},
+		{"Gemfile", "source 'https://rubygems.org'\ngem 'rails'\n", TypeCode, CodeLangRuby, DataFormatNone, MarkupNone},
+		{"go.mod", "module example.com/m\n\ngo 1.21\n", TypeCode, CodeLangGo, DataFormatNone, MarkupNone},
+		{"web/package.json", `{"name": "x"}`, TypeCode, CodeLangUnknown, DataFormatJSON, MarkupNone},
+		{"Makefile", "all:\n\tcc -o app main.c\n", TypeCode, CodeLangUnknown, DataFormatNone, MarkupNone},
+		{"docs/GUIDE.md", "Read this first.\n", TypeText, CodeLangUnknown, DataFormatNone, MarkupMarkdown},
+		{"notes.TXT", "if (x) { y(); }\n", TypeText, CodeLangUnknown, DataFormatNone, MarkupNone},
+		// Binary content is not text whatever its name
+		{"data.go", string(binary), Detect(binary).Type, CodeLangUnknown, DataFormatNone, MarkupNone},
+		// A shebang beats the extension
+		{"tool.rb", "#!/usr/bin/env python3\nimport os\nprint(os.getcwd())\n", TypeCode, CodeLangPython, DataFormatNone, MarkupNone},
+		{"run.sh", "#!/usr/bin/env python3\nimport sys\nprint(sys.argv[1:])\n", TypeCode, CodeLangPython, DataFormatNone, MarkupNone},
+		// No hint: same as Detect
+		{"LICENSE.unknownext", "x := f()\n", Detect([]byte("x := f()\n")).Type, CodeLangUnknown, DataFormatNone, MarkupNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := DetectWithName(tc.name, []byte(tc.data))
+			if profile.Type != tc.wantType {
+				t.Errorf("Type: got %v, want %v", profile.Type, tc.wantType)
+			}
+			if profile.Language != tc.wantLang {
+				t.Errorf("Language: got %v, want %v", profile.Language, tc.wantLang)
+			}
+			if profile.DataFmt != tc.wantFmt {
+				t.Errorf("DataFmt: got %v, want %v", profile.DataFmt, tc.wantFmt)
+			}
+			if profile.Markup != tc.wantMark {
+				t.Errorf("Markup: got %v, want %v", profile.Markup, tc.wantMark)
+			}
+		})
+	}
+}
+
+func TestDetectWithNameWeighsContent(t *testing.T) {
+	// Plainly Python content keeps its language under a misleading name
+	src := []byte(`import os
+
+class Walker:
+    def __init__(self, root):
+        self.root = root
+
+    def files(self):
+        for dirpath, _, names in os.walk(self.root):
+            for name in names:
+                yield os.path.join(dirpath, name)
+`)
+	profile := DetectWithName("walker.js", src)
+	if profile.Language != CodeLangPython {
+		t.Errorf("got %v, want Python", profile.Language)
+	}
+	var sum float64
+	for _, s := range profile.LanguageScores {
+		sum += s.Score
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("scores sum to %f, want 1", sum)
+	}
+}
+
+func TestParseNameMap(t *testing.T) {
+	m, err := ParseNameMap(strings.NewReader(`
+# templates are Go
+*.tmpl      go
+*.jsonl     JSON
+build/*.in  python
+*.tmpl      text
+README      markdown
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		want Hint
+		ok   bool
+	}{
+		{"page.tmpl", Hint{Type: TypeText}, true}, // last match wins
+		{"logs/a.jsonl", Hint{Type: TypeCode, DataFmt: DataFormatJSON}, true},
+		{"build/setup.in", Hint{Type: TypeCode, Language: CodeLangPython}, true},
+		{"src/build/setup.in", Hint{}, false},
+		{"README", Hint{Type: TypeText, Markup: MarkupMarkdown}, true},
+		{"main.go", Hint{Type: TypeCode, Language: CodeLangGo}, true}, // built-in
+		{"src/", Hint{}, false},
+	}
+	for _, tc := range testCases {
+		got, ok := m.Hint(tc.name)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Hint(%q): got %+v, %v; want %+v, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+
+	if got := m.Detect("short.tmpl", []byte("{{ .Name }}\n")); got.Type != TypeText {
+		t.Errorf("Detect: got %v, want text", got.Type)
+	}
+
+	for _, bad := range []string{"*.x", "*.x cobol", "[ go", "*.x go extra"} {
+		if _, err := ParseNameMap(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseNameMap(%q): expected an error", bad)
+		}
+	}
+}
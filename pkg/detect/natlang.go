@@ -0,0 +1,189 @@
+package detect
+
+import (
+	"math"
+	"sort"
+	"unicode"
+)
+
+//go:generate go run ../../cmd/mkngram -o ngram_profiles.go ../../cmd/mkngram/corpus
+
+// Natural language identification uses character n-grams of one to three
+// runes, counted on normalised text (letters lowercased, everything else
+// collapsed to a single space, so " th" marks a word start). Each language
+// has a profile of its most frequent n-grams, trained by cmd/mkngram and
+// generated into ngram_profiles.go. A sample is scored by the average log
+// probability of its n-grams under each profile, which does not care about
+// the odd foreign word or character the way keyword and script checks do.
+const (
+	maxNGram = 3
+
+	// ProfileSize is the most n-grams kept per language profile.
+	ProfileSize = 500
+
+	// ProfileMinCount is the least number of times an n-gram must occur in
+	// the training text to enter a profile. Rarer ones are noise in a
+	// corpus of a few kilobytes per language, not evidence of the language.
+	ProfileMinCount = 3
+
+	// unseenPPM is the frequency, in parts per million, given to n-grams
+	// missing from a profile.
+	unseenPPM = 0.5
+
+	// natLangEvidence caps how many n-grams count as independent evidence
+	// when turning log probabilities into confidences, as neighbouring
+	// n-grams overlap. It keeps short samples from looking certain.
+	natLangEvidence = 40
+
+	// minNatLangLetters is the least text worth identifying, and
+	// minNatLangConfidence the confidence Profile.NatLang requires.
+	minNatLangLetters    = 12
+	minNatLangConfidence = 0.5
+)
+
+// NGram is a character n-gram with its frequency in parts per million of
+// all n-grams in the text it was counted on.
+type NGram struct {
+	Text string
+	PPM  int
+}
+
+// LangScore is the probability that text is in a given natural language.
+type LangScore struct {
+	Lang  NatLang
+	Score float64
+}
+
+// NGrams counts the character n-grams of text and returns the most
+// frequent, at most top, ordered by decreasing frequency (ties by text).
+// N-grams occurring fewer than ProfileMinCount times are left out. It is
+// what cmd/mkngram uses to build the language profiles.
+func NGrams(text []byte, top int) []NGram {
+	counts, total := countNGrams(text)
+	return rankNGrams(counts, total, top, ProfileMinCount)
+}
+
+// normaliseText lowercases letters (keeping combining marks, which
+// Devanagari and Bengali need) and turns every run of other characters
+// into one space. The result starts and ends with a space. It also returns
+// the number of letters seen.
+func normaliseText(text []byte) ([]rune, int) {
+	runes := []rune{' '}
+	letters := 0
+	for i := 0; i < len(text); {
+		r, size := decodeRune(text[i:])
+		i += size
+		switch {
+		case unicode.IsLetter(r):
+			runes = append(runes, unicode.ToLower(r))
+			letters++
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Mc, r):
+			runes = append(runes, r)
+		case runes[len(runes)-1] != ' ':
+			runes = append(runes, ' ')
+		}
+	}
+	if runes[len(runes)-1] != ' ' {
+		runes = append(runes, ' ')
+	}
+	return runes, letters
+}
+
+// countNGrams counts the n-grams of normalised text. A lone space is not
+// an n-gram.
+func countNGrams(text []byte) (map[string]int, int) {
+	runes, _ := normaliseText(text)
+	counts := make(map[string]int)
+	total := 0
+	for i := range runes {
+		for n := 1; n <= maxNGram && i+n <= len(runes); n++ {
+			if n == 1 && runes[i] == ' ' {
+				continue
+			}
+			counts[string(runes[i:i+n])]++
+			total++
+		}
+	}
+	return counts, total
+}
+
+// natLangs lists the languages with a profile, in NatLang order.
+var natLangs = func() []NatLang {
+	langs := make([]NatLang, 0, len(natLangProfiles))
+	for lang := range natLangProfiles {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}()
+
+// natLangTable holds the log probability of each profiled n-gram in every
+// language.
+var natLangTable = &logProbTable{
+	unseen: math.Log(unseenPPM / 1e6),
+	profiles: func() [][]NGram {
+		profiles := make([][]NGram, len(natLangs))
+		for i, lang := range natLangs {
+			profiles[i] = natLangProfiles[lang]
+		}
+		return profiles
+	},
+}
+
+// ScoreNatLang scores text against every language profile. It returns all
+// languages ranked by probability (summing to 1, best first), or nil if
+// the text has too few letters to judge.
+func ScoreNatLang(text []byte) []LangScore {
+	runes, letters := normaliseText(text)
+	if letters < minNatLangLetters {
+		return nil
+	}
+
+	table := natLangTable.get()
+	sums := make([]float64, len(natLangs))
+	total := 0
+	for i := range runes {
+		for n := 1; n <= maxNGram && i+n <= len(runes); n++ {
+			if n == 1 && runes[i] == ' ' {
+				continue
+			}
+			total++
+			probs, ok := table[string(runes[i:i+n])]
+			for j := range sums {
+				if ok {
+					sums[j] += probs[j]
+				} else {
+					sums[j] += natLangTable.unseen
+				}
+			}
+		}
+	}
+
+	// Average log probability per n-gram, scaled by the evidence the
+	// sample provides, then normalised (softmax) into probabilities
+	weighEvidence(sums, total, natLangEvidence)
+	softmax(sums)
+
+	scores := make([]LangScore, len(natLangs))
+	for j, lang := range natLangs {
+		scores[j] = LangScore{Lang: lang, Score: sums[j]}
+	}
+	sort.SliceStable(scores, func(a, b int) bool { return scores[a].Score > scores[b].Score })
+	return scores
+}
+
+// detectNatLang identifies the natural language of text, returning the
+// ranked scores and the probability of the best language as confidence.
+// The language is NatLangUnknown unless the confidence is at least
+// minNatLangConfidence.
+func detectNatLang(text []byte) (NatLang, []LangScore, float64) {
+	scores := ScoreNatLang(text)
+	if len(scores) == 0 {
+		return NatLangUnknown, nil, 0
+	}
+	confidence := scores[0].Score
+	if confidence < minNatLangConfidence {
+		return NatLangUnknown, scores, confidence
+	}
+	return scores[0].Lang, scores, confidence
+}
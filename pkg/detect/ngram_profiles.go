@@ -0,0 +1,5750 @@
+// Code generated by mkngram. DO NOT EDIT.
+
+package detect
+
+// natLangProfiles holds up to 500 of the most frequent character n-grams of each
+// language's training text seen at least 3 times, with their frequency in parts per million.
+var natLangProfiles = map[NatLang][]NGram{
+	NatLangEnglish: {
+		{"e", 38534},
+		{"t", 28427},
+		{"o", 24162},
+		{"a", 21320},
+		{"n", 19740},
+		{"h", 19267},
+		{"r", 19267},
+		{"s", 17530},
+		{"i", 17056},
+		{"e ", 15161},
+		{"d", 14845},
+		{" t", 12160},
+		{"th", 12002},
+		{"l", 11212},
+		{"he", 10423},
+		{" th", 9159},
+		{"d ", 9001},
+		{"the", 8686},
+		{"u", 8370},
+		{"c", 7580},
+		{"s ", 7580},
+		{"w", 6790},
+		{"he ", 6475},
+		{"er", 6317},
+		{" a", 6159},
+		{"t ", 6001},
+		{"y", 5843},
+		{" s", 5527},
+		{" w", 5527},
+		{"f", 5527},
+		{"n ", 5527},
+		{" o", 5369},
+		{"m", 5369},
+		{"r ", 5211},
+		{"an", 5053},
+		{"nd", 4895},
+		{"b", 4579},
+		{"g", 4579},
+		{"in", 4579},
+		{"p", 4421},
+		{"re", 4264},
+		{"on", 3948},
+		{"nd ", 3790},
+		{"y ", 3790},
+		{" b", 3474},
+		{"and", 3474},
+		{"ou", 3474},
+		{"ha", 3316},
+		{" c", 3158},
+		{"en", 3158},
+		{" an", 3000},
+		{"ar", 3000},
+		{"ed", 3000},
+		{"er ", 3000},
+		{"or", 3000},
+		{"at", 2842},
+		{"l ", 2842},
+		{" r", 2684},
+		{"it", 2684},
+		{"ti", 2684},
+		{"v", 2684},
+		{" f", 2526},
+		{" h", 2526},
+		{" p", 2526},
+		{"ed ", 2526},
+		{"o ", 2526},
+		{"ho", 2368},
+		{"k", 2368},
+		{"ne", 2368},
+		{"ve", 2368},
+		{" i", 2210},
+		{"her", 2210},
+		{"is", 2210},
+		{"of", 2210},
+		{"t t", 2210},
+		{" m", 2053},
+		{"d t", 2053},
+		{"f ", 2053},
+		{"of ", 2053},
+		{"on ", 2053},
+		{"re ", 2053},
+		{"ri", 2053},
+		{"se", 2053},
+		{" of", 1895},
+		{"al", 1895},
+		{"ng", 1895},
+		{"st", 1895},
+		{" d", 1737},
+		{" e", 1737},
+		{" to", 1737},
+		{"as", 1737},
+		{"ea", 1737},
+		{"ld", 1737},
+		{"me", 1737},
+		{"to", 1737},
+		{" l", 1579},
+		{"at ", 1579},
+		{"be", 1579},
+		{"co", 1579},
+		{"de", 1579},
+		{"e c", 1579},
+		{"e t", 1579},
+		{"e w", 1579},
+		{"il", 1579},
+		{"li", 1579},
+		{"nt", 1579},
+		{"s a", 1579},
+		{"to ", 1579},
+		{" co", 1421},
+		{"e s", 1421},
+		{"hat", 1421},
+		{"io", 1421},
+		{"ion", 1421},
+		{"ld ", 1421},
+		{"ll", 1421},
+		{"ot", 1421},
+		{"oth", 1421},
+		{"ro", 1421},
+		{"sh", 1421},
+		{"tha", 1421},
+		{"ut", 1421},
+		{"ver", 1421},
+		{"we", 1421},
+		{" ha", 1263},
+		{" we", 1263},
+		{" wh", 1263},
+		{"are", 1263},
+		{"ca", 1263},
+		{"d b", 1263},
+		{"e r", 1263},
+		{"ee", 1263},
+		{"en ", 1263},
+		{"g ", 1263},
+		{"in ", 1263},
+		{"ing", 1263},
+		{"la", 1263},
+		{"n t", 1263},
+		{"ng ", 1263},
+		{"pe", 1263},
+		{"ra", 1263},
+		{"te", 1263},
+		{"ul", 1263},
+		{"wa", 1263},
+		{"wh", 1263},
+		{" be", 1105},
+		{" n", 1105},
+		{" re", 1105},
+		{" se", 1105},
+		{" sh", 1105},
+		{" y", 1105},
+		{"as ", 1105},
+		{"ch", 1105},
+		{"d a", 1105},
+		{"di", 1105},
+		{"es", 1105},
+		{"et", 1105},
+		{"ev", 1105},
+		{"fo", 1105},
+		{"h ", 1105},
+		{"ig", 1105},
+		{"is ", 1105},
+		{"ll ", 1105},
+		{"mo", 1105},
+		{"oo", 1105},
+		{"or ", 1105},
+		{"r t", 1105},
+		{"so", 1105},
+		{"ta", 1105},
+		{"tio", 1105},
+		{"ts", 1105},
+		{"us", 1105},
+		{"ut ", 1105},
+		{"yo", 1105},
+		{" fo", 947},
+		{" in", 947},
+		{" on", 947},
+		{" wa", 947},
+		{" wi", 947},
+		{"ay", 947},
+		{"ds", 947},
+		{"ds ", 947},
+		{"e a", 947},
+		{"e f", 947},
+		{"e o", 947},
+		{"ere", 947},
+		{"eve", 947},
+		{"f t", 947},
+		{"hi", 947},
+		{"hou", 947},
+		{"ke", 947},
+		{"le", 947},
+		{"ma", 947},
+		{"me ", 947},
+		{"ne ", 947},
+		{"ol", 947},
+		{"one", 947},
+		{"op", 947},
+		{"oul", 947},
+		{"out", 947},
+		{"r w", 947},
+		{"rs", 947},
+		{"ry", 947},
+		{"sc", 947},
+		{"ts ", 947},
+		{"uld", 947},
+		{"un", 947},
+		{"wi", 947},
+		{" bu", 789},
+		{" ca", 789},
+		{" di", 789},
+		{" mo", 789},
+		{" ne", 789},
+		{" or", 789},
+		{" ot", 789},
+		{" ri", 789},
+		{" so", 789},
+		{" st", 789},
+		{" wo", 789},
+		{"ac", 789},
+		{"ad", 789},
+		{"al ", 789},
+		{"av", 789},
+		{"ave", 789},
+		{"bo", 789},
+		{"bu", 789},
+		{"ci", 789},
+		{"do", 789},
+		{"e h", 789},
+		{"e p", 789},
+		{"ear", 789},
+		{"ec", 789},
+		{"ent", 789},
+		{"ery", 789},
+		{"et ", 789},
+		{"for", 789},
+		{"gh", 789},
+		{"ght", 789},
+		{"ht", 789},
+		{"lo", 789},
+		{"ni", 789},
+		{"o t", 789},
+		{"od", 789},
+		{"om", 789},
+		{"ow", 789},
+		{"pl", 789},
+		{"r s", 789},
+		{"rig", 789},
+		{"rt", 789},
+		{"s s", 789},
+		{"s t", 789},
+		{"t w", 789},
+		{"ty", 789},
+		{"ty ", 789},
+		{"ur", 789},
+		{"w ", 789},
+		{"whe", 789},
+		{"wo", 789},
+		{"ye", 789},
+		{" ar", 631},
+		{" by", 631},
+		{" en", 631},
+		{" ev", 631},
+		{" fa", 631},
+		{" ho", 631},
+		{" is", 631},
+		{" it", 631},
+		{" li", 631},
+		{" ma", 631},
+		{" pr", 631},
+		{" sp", 631},
+		{" u", 631},
+		{" ye", 631},
+		{"ai", 631},
+		{"all", 631},
+		{"am", 631},
+		{"ar ", 631},
+		{"ati", 631},
+		{"by", 631},
+		{"by ", 631},
+		{"ce", 631},
+		{"ct", 631},
+		{"d o", 631},
+		{"d s", 631},
+		{"de ", 631},
+		{"e d", 631},
+		{"el", 631},
+		{"ers", 631},
+		{"es ", 631},
+		{"ey", 631},
+		{"ey ", 631},
+		{"fa", 631},
+		{"ge", 631},
+		{"id", 631},
+		{"ie", 631},
+		{"igh", 631},
+		{"ill", 631},
+		{"ind", 631},
+		{"ir", 631},
+		{"it ", 631},
+		{"nc", 631},
+		{"oc", 631},
+		{"os", 631},
+		{"pa", 631},
+		{"pr", 631},
+		{"pro", 631},
+		{"rd", 631},
+		{"rit", 631},
+		{"rn", 631},
+		{"rs ", 631},
+		{"s w", 631},
+		{"sho", 631},
+		{"sp", 631},
+		{"sti", 631},
+		{"t o", 631},
+		{"ter", 631},
+		{"th ", 631},
+		{"tho", 631},
+		{"til", 631},
+		{"tu", 631},
+		{"use", 631},
+		{"was", 631},
+		{"we ", 631},
+		{" a ", 473},
+		{" ab", 473},
+		{" al", 473},
+		{" ch", 473},
+		{" fr", 473},
+		{" g", 473},
+		{" k", 473},
+		{" la", 473},
+		{" ou", 473},
+		{" pa", 473},
+		{" pe", 473},
+		{" pl", 473},
+		{" ra", 473},
+		{" su", 473},
+		{" yo", 473},
+		{"a ", 473},
+		{"ab", 473},
+		{"abo", 473},
+		{"ace", 473},
+		{"ad ", 473},
+		{"ain", 473},
+		{"an ", 473},
+		{"ay ", 473},
+		{"be ", 473},
+		{"bou", 473},
+		{"ce ", 473},
+		{"ch ", 473},
+		{"ck", 473},
+		{"cl", 473},
+		{"cu", 473},
+		{"d i", 473},
+		{"d r", 473},
+		{"d w", 473},
+		{"da", 473},
+		{"day", 473},
+		{"din", 473},
+		{"dis", 473},
+		{"e b", 473},
+		{"e e", 473},
+		{"e l", 473},
+		{"e m", 473},
+		{"eed", 473},
+		{"ef", 473},
+		{"em", 473},
+		{"end", 473},
+		{"fr", 473},
+		{"fu", 473},
+		{"g t", 473},
+		{"gi", 473},
+		{"go", 473},
+		{"h o", 473},
+		{"han", 473},
+		{"hav", 473},
+		{"hen", 473},
+		{"hey", 473},
+		{"his", 473},
+		{"ht ", 473},
+		{"ith", 473},
+		{"ity", 473},
+		{"k ", 473},
+		{"ki", 473},
+		{"kin", 473},
+		{"ks", 473},
+		{"ks ", 473},
+		{"l o", 473},
+		{"lan", 473},
+		{"le ", 473},
+		{"m ", 473},
+		{"mor", 473},
+		{"n a", 473},
+		{"n b", 473},
+		{"n n", 473},
+		{"n s", 473},
+		{"no", 473},
+		{"ns", 473},
+		{"nti", 473},
+		{"o w", 473},
+		{"od ", 473},
+		{"ood", 473},
+		{"ou ", 473},
+		{"ow ", 473},
+		{"pen", 473},
+		{"per", 473},
+		{"pla", 473},
+		{"r a", 473},
+		{"r o", 473},
+		{"r p", 473},
+		{"rds", 473},
+		{"rea", 473},
+		{"ree", 473},
+		{"ry ", 473},
+		{"ryo", 473},
+		{"s e", 473},
+		{"s h", 473},
+		{"s m", 473},
+		{"s o", 473},
+		{"se ", 473},
+		{"she", 473},
+		{"st ", 473},
+		{"sta", 473},
+		{"su", 473},
+		{"thi", 473},
+		{"tt", 473},
+		{"tte", 473},
+		{"u ", 473},
+		{"uc", 473},
+		{"um", 473},
+		{"ve ", 473},
+		{"wer", 473},
+		{"wit", 473},
+		{"wou", 473},
+		{"y a", 473},
+		{"yea", 473},
+		{"yon", 473},
+		{"you", 473},
+	},
+	NatLangSpanish: {
+		{"a", 37149},
+		{"e", 34547},
+		{"o", 25585},
+		{"s", 23272},
+		{"n", 20526},
+		{"r", 19369},
+		{"i", 17924},
+		{"l", 16912},
+		{"d", 15177},
+		{"a ", 13732},
+		{"c", 13298},
+		{"s ", 13009},
+		{"t", 11853},
+		{"u", 11708},
+		{"e ", 9540},
+		{" l", 7516},
+		{"o ", 7516},
+		{"os", 7372},
+		{"m", 6649},
+		{"n ", 6649},
+		{" e", 6504},
+		{"os ", 6504},
+		{"p", 6504},
+		{"es", 6360},
+		{" d", 6215},
+		{"la", 5926},
+		{"en", 5782},
+		{"de", 5492},
+		{" c", 5348},
+		{"er", 5203},
+		{" p", 4914},
+		{" de", 4625},
+		{"b", 4336},
+		{"ra", 4336},
+		{" la", 4047},
+		{"an", 4047},
+		{"ue", 4047},
+		{"la ", 3902},
+		{" s", 3758},
+		{"ar", 3758},
+		{"as", 3758},
+		{"de ", 3758},
+		{" a", 3469},
+		{"do", 3469},
+		{"v", 3469},
+		{"l ", 3324},
+		{"ta", 3324},
+		{"y", 3324},
+		{"h", 3180},
+		{"re", 3180},
+		{"el", 3035},
+		{"es ", 3035},
+		{"g", 3035},
+		{"ie", 3035},
+		{"í", 3035},
+		{"ó", 3035},
+		{"ci", 2891},
+		{"or", 2891},
+		{"q", 2891},
+		{"qu", 2891},
+		{"as ", 2746},
+		{"co", 2746},
+		{"lo", 2746},
+		{"na", 2746},
+		{" q", 2601},
+		{" qu", 2601},
+		{" t", 2601},
+		{"r ", 2601},
+		{"y ", 2601},
+		{" es", 2457},
+		{"nt", 2457},
+		{"que", 2457},
+		{"st", 2457},
+		{"to", 2457},
+		{" h", 2312},
+		{" y", 2312},
+		{" y ", 2312},
+		{"ad", 2312},
+		{"el ", 2312},
+		{"nd", 2312},
+		{"ro", 2312},
+		{" lo", 2168},
+		{" m", 2168},
+		{"ca", 2168},
+		{"e l", 2168},
+		{"on", 2168},
+		{"te", 2168},
+		{"ue ", 2168},
+		{" co", 2023},
+		{" el", 2023},
+		{" n", 2023},
+		{" o", 2023},
+		{"al", 2023},
+		{"da", 2023},
+		{"di", 2023},
+		{"do ", 2023},
+		{"f", 2023},
+		{"ió", 2023},
+		{"los", 2023},
+		{"se", 2023},
+		{" ha", 1879},
+		{"an ", 1879},
+		{"ha", 1879},
+		{"id", 1879},
+		{"s p", 1879},
+		{"un", 1879},
+		{"ía", 1879},
+		{"ón", 1879},
+		{" se", 1734},
+		{"a l", 1734},
+		{"ab", 1734},
+		{"cu", 1734},
+		{"en ", 1734},
+		{"in", 1734},
+		{"pa", 1734},
+		{"po", 1734},
+		{"ti", 1734},
+		{"vi", 1734},
+		{"ía ", 1734},
+		{" v", 1590},
+		{"ac", 1590},
+		{"est", 1590},
+		{"ma", 1590},
+		{"mi", 1590},
+		{"no", 1590},
+		{"s d", 1590},
+		{"sta", 1590},
+		{"ón ", 1590},
+		{"a e", 1445},
+		{"am", 1445},
+		{"ien", 1445},
+		{"ión", 1445},
+		{"li", 1445},
+		{"ri", 1445},
+		{"s e", 1445},
+		{"so", 1445},
+		{"tr", 1445},
+		{" a ", 1300},
+		{" en", 1300},
+		{" f", 1300},
+		{" pa", 1300},
+		{" po", 1300},
+		{"a c", 1300},
+		{"a d", 1300},
+		{"e e", 1300},
+		{"ent", 1300},
+		{"il", 1300},
+		{"nc", 1300},
+		{"ne", 1300},
+		{"ra ", 1300},
+		{" ca", 1156},
+		{" r", 1156},
+		{"ado", 1156},
+		{"ce", 1156},
+		{"ec", 1156},
+		{"ia", 1156},
+		{"ic", 1156},
+		{"na ", 1156},
+		{"ndo", 1156},
+		{"om", 1156},
+		{"res", 1156},
+		{"rt", 1156},
+		{" cu", 1011},
+		{" i", 1011},
+		{" so", 1011},
+		{" to", 1011},
+		{"ba", 1011},
+		{"br", 1011},
+		{"ció", 1011},
+		{"con", 1011},
+		{"dos", 1011},
+		{"esc", 1011},
+		{"gu", 1011},
+		{"ida", 1011},
+		{"ig", 1011},
+		{"me", 1011},
+		{"mo", 1011},
+		{"n c", 1011},
+		{"o s", 1011},
+		{"od", 1011},
+		{"or ", 1011},
+		{"ot", 1011},
+		{"pe", 1011},
+		{"por", 1011},
+		{"pr", 1011},
+		{"ran", 1011},
+		{"ros", 1011},
+		{"rí", 1011},
+		{"s c", 1011},
+		{"sa", 1011},
+		{"sc", 1011},
+		{"se ", 1011},
+		{"si", 1011},
+		{"ta ", 1011},
+		{"to ", 1011},
+		{"tod", 1011},
+		{"ua", 1011},
+		{" ot", 867},
+		{" pe", 867},
+		{" pr", 867},
+		{" ti", 867},
+		{" u", 867},
+		{"a h", 867},
+		{"a v", 867},
+		{"aci", 867},
+		{"ar ", 867},
+		{"ara", 867},
+		{"ch", 867},
+		{"end", 867},
+		{"ho", 867},
+		{"ia ", 867},
+		{"ier", 867},
+		{"io", 867},
+		{"j", 867},
+		{"las", 867},
+		{"le", 867},
+		{"n d", 867},
+		{"nci", 867},
+		{"ni", 867},
+		{"nos", 867},
+		{"nte", 867},
+		{"o l", 867},
+		{"ol", 867},
+		{"otr", 867},
+		{"s l", 867},
+		{"su", 867},
+		{"tra", 867},
+		{"ve", 867},
+		{"á", 867},
+		{" di", 722},
+		{" g", 722},
+		{" ma", 722},
+		{" re", 722},
+		{" un", 722},
+		{" vi", 722},
+		{"a t", 722},
+		{"aba", 722},
+		{"cho", 722},
+		{"com", 722},
+		{"cua", 722},
+		{"d ", 722},
+		{"e s", 722},
+		{"ene", 722},
+		{"ere", 722},
+		{"ero", 722},
+		{"ib", 722},
+		{"it", 722},
+		{"ll", 722},
+		{"n l", 722},
+		{"n p", 722},
+		{"o e", 722},
+		{"o q", 722},
+		{"oc", 722},
+		{"on ", 722},
+		{"ona", 722},
+		{"par", 722},
+		{"per", 722},
+		{"ras", 722},
+		{"rec", 722},
+		{"ría", 722},
+		{"s a", 722},
+		{"s s", 722},
+		{"son", 722},
+		{"tab", 722},
+		{"tar", 722},
+		{"te ", 722},
+		{"ui", 722},
+		{"ñ", 722},
+		{" al", 578},
+		{" in", 578},
+		{" li", 578},
+		{" na", 578},
+		{" no", 578},
+		{" su", 578},
+		{" te", 578},
+		{" ve", 578},
+		{"a a", 578},
+		{"a m", 578},
+		{"a o", 578},
+		{"a s", 578},
+		{"ace", 578},
+		{"ad ", 578},
+		{"and", 578},
+		{"ano", 578},
+		{"av", 578},
+		{"ay", 578},
+		{"añ", 578},
+		{"be", 578},
+		{"bl", 578},
+		{"ca ", 578},
+		{"cia", 578},
+		{"cio", 578},
+		{"cl", 578},
+		{"cla", 578},
+		{"dad", 578},
+		{"der", 578},
+		{"des", 578},
+		{"e h", 578},
+		{"e p", 578},
+		{"e r", 578},
+		{"em", 578},
+		{"enc", 578},
+		{"er ", 578},
+		{"era", 578},
+		{"fi", 578},
+		{"ga", 578},
+		{"gr", 578},
+		{"gra", 578},
+		{"hab", 578},
+		{"han", 578},
+		{"ici", 578},
+		{"is", 578},
+		{"ió ", 578},
+		{"lib", 578},
+		{"man", 578},
+		{"n e", 578},
+		{"no ", 578},
+		{"nta", 578},
+		{"o a", 578},
+		{"o c", 578},
+		{"o d", 578},
+		{"o p", 578},
+		{"o y", 578},
+		{"odo", 578},
+		{"pro", 578},
+		{"pu", 578},
+		{"r l", 578},
+		{"rr", 578},
+		{"rs", 578},
+		{"rta", 578},
+		{"s t", 578},
+		{"s u", 578},
+		{"s y", 578},
+		{"tie", 578},
+		{"tro", 578},
+		{"ual", 578},
+		{"una", 578},
+		{"vid", 578},
+		{"y d", 578},
+		{"ño", 578},
+		{"ó ", 578},
+		{" añ", 433},
+		{" ce", 433},
+		{" có", 433},
+		{" fu", 433},
+		{" gr", 433},
+		{" me", 433},
+		{" mi", 433},
+		{" mu", 433},
+		{" nu", 433},
+		{" o ", 433},
+		{"a f", 433},
+		{"a n", 433},
+		{"a p", 433},
+		{"a y", 433},
+		{"al ", 433},
+		{"ama", 433},
+		{"ami", 433},
+		{"amo", 433},
+		{"ant", 433},
+		{"are", 433},
+		{"art", 433},
+		{"arí", 433},
+		{"asa", 433},
+		{"año", 433},
+		{"ban", 433},
+		{"bre", 433},
+		{"cas", 433},
+		{"cor", 433},
+		{"cos", 433},
+		{"cr", 433},
+		{"cri", 433},
+		{"có", 433},
+		{"da ", 433},
+		{"dig", 433},
+		{"dr", 433},
+		{"du", 433},
+		{"e c", 433},
+		{"e d", 433},
+		{"e m", 433},
+		{"e n", 433},
+		{"e t", 433},
+		{"ech", 433},
+		{"ed", 433},
+		{"ern", 433},
+		{"ers", 433},
+		{"ert", 433},
+		{"et", 433},
+		{"fu", 433},
+		{"gar", 433},
+		{"hac", 433},
+		{"hos", 433},
+		{"ibe", 433},
+		{"ica", 433},
+		{"il ", 433},
+		{"ill", 433},
+		{"ja", 433},
+		{"l a", 433},
+		{"l d", 433},
+		{"l p", 433},
+		{"lar", 433},
+		{"les", 433},
+		{"lla", 433},
+		{"lo ", 433},
+		{"lu", 433},
+		{"mb", 433},
+		{"mie", 433},
+		{"mil", 433},
+		{"mo ", 433},
+		{"mos", 433},
+		{"mp", 433},
+		{"mu", 433},
+		{"n q", 433},
+		{"nac", 433},
+		{"nde", 433},
+		{"ndi", 433},
+		{"ne ", 433},
+		{"nto", 433},
+		{"ntr", 433},
+		{"nu", 433},
+		{"ob", 433},
+		{"oda", 433},
+		{"ori", 433},
+		{"qui", 433},
+		{"r c", 433},
+		{"re ", 433},
+		{"rn", 433},
+		{"ro ", 433},
+		{"rso", 433},
+		{"s h", 433},
+		{"s m", 433},
+		{"s n", 433},
+		{"s q", 433},
+		{"sa ", 433},
+		{"scr", 433},
+		{"su ", 433},
+		{"tad", 433},
+		{"ten", 433},
+		{"ter", 433},
+		{"u ", 433},
+		{"uan", 433},
+		{"uel", 433},
+		{"uen", 433},
+		{"ug", 433},
+		{"uga", 433},
+		{"uie", 433},
+		{"um", 433},
+		{"unc", 433},
+		{"ven", 433},
+		{"vie", 433},
+		{"ví", 433},
+		{"vía", 433},
+		{"y l", 433},
+		{"y q", 433},
+		{"ye", 433},
+		{"yen", 433},
+		{"z", 433},
+	},
+	NatLangFrench: {
+		{"e", 43054},
+		{"s", 24955},
+		{"t", 22213},
+		{"n", 22075},
+		{"i", 21527},
+		{"r", 21390},
+		{"a", 20430},
+		{"o", 17002},
+		{"u", 17002},
+		{"e ", 16865},
+		{"l", 15905},
+		{"s ", 15494},
+		{"d", 12340},
+		{"t ", 9872},
+		{" d", 9461},
+		{" l", 8775},
+		{"c", 8227},
+		{"es", 7404},
+		{"p", 7267},
+		{"m", 6993},
+		{"en", 6581},
+		{"é", 6170},
+		{"de", 6033},
+		{" e", 5758},
+		{"es ", 5758},
+		{"re", 5621},
+		{" de", 5484},
+		{" p", 5484},
+		{"le", 5210},
+		{"v", 4936},
+		{"ou", 4799},
+		{"nt", 4662},
+		{"on", 4662},
+		{"de ", 4524},
+		{"ai", 4387},
+		{"n ", 4387},
+		{" a", 4250},
+		{"er", 4250},
+		{"it", 3702},
+		{" s", 3565},
+		{"la", 3565},
+		{"nt ", 3565},
+		{" le", 3427},
+		{"a ", 3427},
+		{"r ", 3427},
+		{" c", 3153},
+		{"et", 3016},
+		{"ent", 2879},
+		{"g", 2879},
+		{"in", 2879},
+		{" m", 2742},
+		{"e l", 2742},
+		{"ie", 2742},
+		{"re ", 2742},
+		{"ur", 2742},
+		{" la", 2605},
+		{" t", 2605},
+		{"f", 2605},
+		{"et ", 2468},
+		{"la ", 2468},
+		{"le ", 2468},
+		{"t d", 2468},
+		{" et", 2331},
+		{" n", 2331},
+		{"an", 2331},
+		{"is", 2331},
+		{"l ", 2331},
+		{"q", 2331},
+		{"qu", 2331},
+		{"rs", 2331},
+		{"se", 2331},
+		{"te", 2331},
+		{"au", 2193},
+		{"co", 2193},
+		{"ns", 2193},
+		{"oi", 2193},
+		{"on ", 2193},
+		{"so", 2193},
+		{"ti", 2193},
+		{"ut", 2193},
+		{" f", 2056},
+		{" o", 2056},
+		{"ar", 2056},
+		{"it ", 2056},
+		{"ne", 2056},
+		{"ro", 2056},
+		{"b", 1919},
+		{"les", 1919},
+		{"li", 1919},
+		{"pr", 1919},
+		{"rs ", 1919},
+		{"tr", 1919},
+		{"u ", 1919},
+		{"ue", 1919},
+		{"un", 1919},
+		{"us", 1919},
+		{"ve", 1919},
+		{" q", 1782},
+		{" qu", 1782},
+		{"e d", 1782},
+		{"h", 1782},
+		{"il", 1782},
+		{"ma", 1782},
+		{"ra", 1782},
+		{"s e", 1782},
+		{"s p", 1782},
+		{"ue ", 1782},
+		{" en", 1645},
+		{" r", 1645},
+		{" à", 1645},
+		{" à ", 1645},
+		{"d ", 1645},
+		{"eu", 1645},
+		{"me", 1645},
+		{"ns ", 1645},
+		{"pa", 1645},
+		{"que", 1645},
+		{"s l", 1645},
+		{"à", 1645},
+		{"à ", 1645},
+		{"é ", 1645},
+		{" co", 1508},
+		{" ma", 1508},
+		{" pr", 1508},
+		{"ce", 1508},
+		{"io", 1508},
+		{"ion", 1508},
+		{"ir", 1508},
+		{"ni", 1508},
+		{"s d", 1508},
+		{"t l", 1508},
+		{" l ", 1371},
+		{" no", 1371},
+		{" so", 1371},
+		{"e p", 1371},
+		{"n d", 1371},
+		{"nd", 1371},
+		{"ne ", 1371},
+		{"no", 1371},
+		{"res", 1371},
+		{"ri", 1371},
+		{"rt", 1371},
+		{"tre", 1371},
+		{"ts", 1371},
+		{"ts ", 1371},
+		{"us ", 1371},
+		{" au", 1234},
+		{" es", 1234},
+		{" pa", 1234},
+		{" to", 1234},
+		{" u", 1234},
+		{" v", 1234},
+		{" é", 1234},
+		{"ais", 1234},
+		{"ait", 1234},
+		{"des", 1234},
+		{"di", 1234},
+		{"e a", 1234},
+		{"er ", 1234},
+		{"eur", 1234},
+		{"mai", 1234},
+		{"s a", 1234},
+		{"st", 1234},
+		{"ta", 1234},
+		{"to", 1234},
+		{"è", 1234},
+		{" i", 1096},
+		{" se", 1096},
+		{"aut", 1096},
+		{"av", 1096},
+		{"ce ", 1096},
+		{"ch", 1096},
+		{"e c", 1096},
+		{"e e", 1096},
+		{"est", 1096},
+		{"j", 1096},
+		{"om", 1096},
+		{"ont", 1096},
+		{"our", 1096},
+		{"ous", 1096},
+		{"son", 1096},
+		{"té", 1096},
+		{"urs", 1096},
+		{"va", 1096},
+		{"à l", 1096},
+		{"és", 1096},
+		{" li", 959},
+		{" un", 959},
+		{"ac", 959},
+		{"at", 959},
+		{"e m", 959},
+		{"i ", 959},
+		{"ien", 959},
+		{"iv", 959},
+		{"nc", 959},
+		{"nou", 959},
+		{"or", 959},
+		{"par", 959},
+		{"pe", 959},
+		{"pl", 959},
+		{"tio", 959},
+		{"tou", 959},
+		{"ur ", 959},
+		{"uv", 959},
+		{"uve", 959},
+		{"éc", 959},
+		{"és ", 959},
+		{" av", 822},
+		{" b", 822},
+		{" d ", 822},
+		{" j", 822},
+		{" pe", 822},
+		{" pl", 822},
+		{" re", 822},
+		{"a p", 822},
+		{"ain", 822},
+		{"al", 822},
+		{"ava", 822},
+		{"dr", 822},
+		{"du", 822},
+		{"du ", 822},
+		{"e n", 822},
+		{"e s", 822},
+		{"e t", 822},
+		{"en ", 822},
+		{"ert", 822},
+		{"fa", 822},
+		{"ir ", 822},
+		{"is ", 822},
+		{"ll", 822},
+		{"lu", 822},
+		{"men", 822},
+		{"mm", 822},
+		{"mme", 822},
+		{"oit", 822},
+		{"ouv", 822},
+		{"plu", 822},
+		{"pro", 822},
+		{"r d", 822},
+		{"roi", 822},
+		{"rè", 822},
+		{"s s", 822},
+		{"s u", 822},
+		{"sa", 822},
+		{"st ", 822},
+		{"té ", 822},
+		{"utr", 822},
+		{"ven", 822},
+		{"vi", 822},
+		{"ét", 822},
+		{" ce", 685},
+		{" du", 685},
+		{" dé", 685},
+		{" fa", 685},
+		{" g", 685},
+		{" il", 685},
+		{" ou", 685},
+		{" po", 685},
+		{" ét", 685},
+		{"aie", 685},
+		{"am", 685},
+		{"and", 685},
+		{"ati", 685},
+		{"ci", 685},
+		{"cu", 685},
+		{"dro", 685},
+		{"dé", 685},
+		{"e r", 685},
+		{"el", 685},
+		{"ers", 685},
+		{"ge", 685},
+		{"gr", 685},
+		{"il ", 685},
+		{"in ", 685},
+		{"lle", 685},
+		{"lo", 685},
+		{"mi", 685},
+		{"mo", 685},
+		{"n p", 685},
+		{"nn", 685},
+		{"né", 685},
+		{"oc", 685},
+		{"ons", 685},
+		{"po", 685},
+		{"r l", 685},
+		{"rit", 685},
+		{"rès", 685},
+		{"s m", 685},
+		{"s o", 685},
+		{"sen", 685},
+		{"si", 685},
+		{"ss", 685},
+		{"t q", 685},
+		{"tai", 685},
+		{"te ", 685},
+		{"tu", 685},
+		{"une", 685},
+		{"ute", 685},
+		{"ver", 685},
+		{"vo", 685},
+		{"x", 685},
+		{"ès", 685},
+		{"ès ", 685},
+		{"é e", 685},
+		{" a ", 548},
+		{" di", 548},
+		{" gr", 548},
+		{" h", 548},
+		{" mo", 548},
+		{" on", 548},
+		{" te", 548},
+		{" tr", 548},
+		{" vo", 548},
+		{"a f", 548},
+		{"ag", 548},
+		{"ant", 548},
+		{"as", 548},
+		{"be", 548},
+		{"cl", 548},
+		{"cla", 548},
+		{"cou", 548},
+		{"cr", 548},
+		{"d o", 548},
+		{"déc", 548},
+		{"e f", 548},
+		{"e o", 548},
+		{"e à", 548},
+		{"em", 548},
+		{"enc", 548},
+		{"end", 548},
+		{"fi", 548},
+		{"gi", 548},
+		{"gra", 548},
+		{"ha", 548},
+		{"he", 548},
+		{"ie ", 548},
+		{"ig", 548},
+		{"ins", 548},
+		{"ire", 548},
+		{"its", 548},
+		{"jo", 548},
+		{"jou", 548},
+		{"l a", 548},
+		{"lus", 548},
+		{"na", 548},
+		{"nu", 548},
+		{"oir", 548},
+		{"omm", 548},
+		{"out", 548},
+		{"rai", 548},
+		{"s i", 548},
+		{"s t", 548},
+		{"se ", 548},
+		{"ser", 548},
+		{"sse", 548},
+		{"t e", 548},
+		{"t p", 548},
+		{"t r", 548},
+		{"t s", 548},
+		{"t t", 548},
+		{"tes", 548},
+		{"ua", 548},
+		{"ui", 548},
+		{"un ", 548},
+		{"ux", 548},
+		{"ux ", 548},
+		{"vai", 548},
+		{"x ", 548},
+		{"èr", 548},
+		{"ère", 548},
+		{"ée", 548},
+		{"éta", 548},
+		{" an", 411},
+		{" ch", 411},
+		{" do", 411},
+		{" dr", 411},
+		{" fe", 411},
+		{" fr", 411},
+		{" in", 411},
+		{" jo", 411},
+		{" lo", 411},
+		{" na", 411},
+		{" ra", 411},
+		{" sa", 411},
+		{" vi", 411},
+		{" éc", 411},
+		{"ace", 411},
+		{"ann", 411},
+		{"ans", 411},
+		{"ar ", 411},
+		{"ard", 411},
+		{"aux", 411},
+		{"ber", 411},
+		{"bl", 411},
+		{"cha", 411},
+		{"che", 411},
+		{"com", 411},
+		{"con", 411},
+		{"cri", 411},
+		{"ct", 411},
+		{"cun", 411},
+		{"da", 411},
+		{"dan", 411},
+		{"dis", 411},
+		{"do", 411},
+		{"e g", 411},
+		{"e v", 411},
+		{"ei", 411},
+		{"eil", 411},
+		{"era", 411},
+		{"erm", 411},
+		{"ez", 411},
+		{"ez ", 411},
+		{"fe", 411},
+		{"fr", 411},
+		{"ib", 411},
+		{"id", 411},
+		{"ier", 411},
+		{"ieu", 411},
+		{"ill", 411},
+		{"ine", 411},
+		{"iso", 411},
+		{"iss", 411},
+		{"itu", 411},
+		{"ive", 411},
+		{"ié", 411},
+		{"l e", 411},
+		{"lib", 411},
+		{"ls", 411},
+		{"ls ", 411},
+		{"me ", 411},
+		{"mp", 411},
+		{"mé", 411},
+		{"n e", 411},
+		{"nd ", 411},
+		{"ndr", 411},
+		{"ng", 411},
+		{"ni ", 411},
+		{"nné", 411},
+		{"nte", 411},
+		{"née", 411},
+		{"od", 411},
+		{"ol", 411},
+		{"ors", 411},
+		{"ou ", 411},
+		{"pen", 411},
+		{"pi", 411},
+		{"pou", 411},
+		{"prè", 411},
+		{"rd", 411},
+		{"ren", 411},
+		{"rm", 411},
+		{"roc", 411},
+		{"rt ", 411},
+		{"ré", 411},
+		{"s c", 411},
+		{"s n", 411},
+		{"s q", 411},
+		{"s à", 411},
+		{"sc", 411},
+		{"soi", 411},
+		{"t n", 411},
+		{"ten", 411},
+		{"ter", 411},
+		{"tin", 411},
+		{"u d", 411},
+		{"ul", 411},
+		{"uni", 411},
+		{"ure", 411},
+		{"ut ", 411},
+		{"z", 411},
+		{"z ", 411},
+		{"à c", 411},
+		{"â", 411},
+		{"éco", 411},
+		{"écr", 411},
+	},
+	NatLangPortuguese: {
+		{"a", 37691},
+		{"e", 32894},
+		{"o", 30701},
+		{"s", 25493},
+		{"r", 20833},
+		{"i", 17955},
+		{"d", 16173},
+		{"n", 14802},
+		{"t", 13294},
+		{"a ", 13020},
+		{"m", 13020},
+		{"o ", 12335},
+		{"s ", 12335},
+		{"u", 11376},
+		{"c", 10964},
+		{"e ", 10827},
+		{" d", 8497},
+		{"p", 6990},
+		{"l", 6853},
+		{" e", 6578},
+		{"os", 6578},
+		{" a", 6167},
+		{"de", 6167},
+		{"os ", 6030},
+		{" p", 5619},
+		{"es", 5619},
+		{"ra", 5619},
+		{"m ", 5482},
+		{" o", 5345},
+		{"v", 5208},
+		{" c", 4797},
+		{"er", 4660},
+		{" de", 4523},
+		{"ar", 4523},
+		{"as", 4385},
+		{"de ", 4248},
+		{" s", 4111},
+		{"do", 4111},
+		{"re", 3974},
+		{"r ", 3563},
+		{"nt", 3426},
+		{"to", 3426},
+		{"as ", 3289},
+		{"se", 3289},
+		{"da", 3152},
+		{"te", 3152},
+		{"co", 3015},
+		{"em", 3015},
+		{"g", 3015},
+		{" m", 2878},
+		{" n", 2878},
+		{"am", 2878},
+		{"ã", 2878},
+		{"an", 2741},
+		{"f", 2741},
+		{"in", 2741},
+		{"ma", 2741},
+		{"ão", 2741},
+		{"do ", 2604},
+		{"q", 2604},
+		{"qu", 2604},
+		{"ão ", 2604},
+		{" se", 2467},
+		{"en", 2467},
+		{"h", 2467},
+		{"ri", 2467},
+		{"ta", 2467},
+		{" a ", 2330},
+		{" e ", 2330},
+		{" t", 2330},
+		{"ad", 2330},
+		{"em ", 2330},
+		{"pa", 2330},
+		{"ra ", 2330},
+		{" co", 2192},
+		{" q", 2192},
+		{" qu", 2192},
+		{"or", 2192},
+		{"s e", 2192},
+		{" f", 2055},
+		{" l", 2055},
+		{"b", 2055},
+		{"ci", 2055},
+		{"di", 2055},
+		{"s p", 2055},
+		{"ç", 2055},
+		{" es", 1918},
+		{"a d", 1918},
+		{"ca", 1918},
+		{"e o", 1918},
+		{"es ", 1918},
+		{"ia", 1918},
+		{"no", 1918},
+		{"s d", 1918},
+		{"ue", 1918},
+		{" o ", 1781},
+		{" pa", 1781},
+		{"am ", 1781},
+		{"da ", 1781},
+		{"it", 1781},
+		{"me", 1781},
+		{"on", 1781},
+		{"que", 1781},
+		{"so", 1781},
+		{"to ", 1781},
+		{" v", 1644},
+		{"av", 1644},
+		{"id", 1644},
+		{"ir", 1644},
+		{"is", 1644},
+		{"na", 1644},
+		{"nd", 1644},
+		{"om", 1644},
+		{"sc", 1644},
+		{"st", 1644},
+		{"ti", 1644},
+		{" do", 1507},
+		{" os", 1507},
+		{"a c", 1507},
+		{"al", 1507},
+		{"ara", 1507},
+		{"la", 1507},
+		{"o d", 1507},
+		{"po", 1507},
+		{"ss", 1507},
+		{" di", 1370},
+		{" ou", 1370},
+		{"a a", 1370},
+		{"ado", 1370},
+		{"ai", 1370},
+		{"ar ", 1370},
+		{"con", 1370},
+		{"e a", 1370},
+		{"ent", 1370},
+		{"ito", 1370},
+		{"l ", 1370},
+		{"o e", 1370},
+		{"o p", 1370},
+		{"ou", 1370},
+		{"res", 1370},
+		{"ro", 1370},
+		{"sa", 1370},
+		{"tr", 1370},
+		{"u ", 1370},
+		{"ua", 1370},
+		{"ue ", 1370},
+		{"va", 1370},
+		{"á", 1370},
+		{" ma", 1233},
+		{" na", 1233},
+		{" pr", 1233},
+		{"dos", 1233},
+		{"el", 1233},
+		{"ia ", 1233},
+		{"li", 1233},
+		{"nte", 1233},
+		{"pe", 1233},
+		{"pr", 1233},
+		{"te ", 1233},
+		{"ve", 1233},
+		{"é", 1233},
+		{"í", 1233},
+		{" i", 1096},
+		{" pe", 1096},
+		{" po", 1096},
+		{"a p", 1096},
+		{"ava", 1096},
+		{"br", 1096},
+		{"com", 1096},
+		{"e d", 1096},
+		{"esc", 1096},
+		{"m d", 1096},
+		{"mo", 1096},
+		{"nc", 1096},
+		{"par", 1096},
+		{"rt", 1096},
+		{"s c", 1096},
+		{"um", 1096},
+		{"un", 1096},
+		{"ut", 1096},
+		{" no", 959},
+		{" r", 959},
+		{" so", 959},
+		{" à", 959},
+		{" à ", 959},
+		{"cu", 959},
+		{"des", 959},
+		{"ei", 959},
+		{"er ", 959},
+		{"est", 959},
+		{"gu", 959},
+		{"ida", 959},
+		{"o a", 959},
+		{"oc", 959},
+		{"ria", 959},
+		{"s a", 959},
+		{"ui", 959},
+		{"à", 959},
+		{"à ", 959},
+		{"çã", 959},
+		{"ção", 959},
+		{" da", 822},
+		{" em", 822},
+		{" en", 822},
+		{" h", 822},
+		{" te", 822},
+		{" u", 822},
+		{"a m", 822},
+		{"a o", 822},
+		{"ano", 822},
+		{"ant", 822},
+		{"are", 822},
+		{"aç", 822},
+		{"e s", 822},
+		{"ec", 822},
+		{"eu", 822},
+		{"ha", 822},
+		{"ic", 822},
+		{"ig", 822},
+		{"io", 822},
+		{"j", 822},
+		{"m a", 822},
+		{"nh", 822},
+		{"ni", 822},
+		{"nos", 822},
+		{"ns", 822},
+		{"o s", 822},
+		{"ob", 822},
+		{"out", 822},
+		{"r c", 822},
+		{"ram", 822},
+		{"ros", 822},
+		{"se ", 822},
+		{"ser", 822},
+		{"si", 822},
+		{"sta", 822},
+		{"tin", 822},
+		{"tos", 822},
+		{"tra", 822},
+		{"uma", 822},
+		{"utr", 822},
+		{"vi", 822},
+		{"ça", 822},
+		{"é ", 822},
+		{"ó", 822},
+		{" as", 685},
+		{" ca", 685},
+		{" li", 685},
+		{" mu", 685},
+		{" é", 685},
+		{" é ", 685},
+		{"a e", 685},
+		{"a t", 685},
+		{"al ", 685},
+		{"at", 685},
+		{"car", 685},
+		{"cr", 685},
+		{"dad", 685},
+		{"e e", 685},
+		{"e n", 685},
+		{"ela", 685},
+		{"era", 685},
+		{"ess", 685},
+		{"ind", 685},
+		{"la ", 685},
+		{"man", 685},
+		{"men", 685},
+		{"mu", 685},
+		{"na ", 685},
+		{"no ", 685},
+		{"nto", 685},
+		{"o c", 685},
+		{"o f", 685},
+		{"o m", 685},
+		{"o o", 685},
+		{"o q", 685},
+		{"obr", 685},
+		{"od", 685},
+		{"om ", 685},
+		{"ont", 685},
+		{"or ", 685},
+		{"pre", 685},
+		{"qua", 685},
+		{"s s", 685},
+		{"sa ", 685},
+		{"sso", 685},
+		{"ta ", 685},
+		{"ur", 685},
+		{"va ", 685},
+		{"ver", 685},
+		{"vo", 685},
+		{"á ", 685},
+		{"ê", 685},
+		{" ai", 548},
+		{" an", 548},
+		{" cu", 548},
+		{" g", 548},
+		{" in", 548},
+		{" me", 548},
+		{" re", 548},
+		{" ti", 548},
+		{" to", 548},
+		{" ve", 548},
+		{"a f", 548},
+		{"a n", 548},
+		{"ade", 548},
+		{"ain", 548},
+		{"and", 548},
+		{"açã", 548},
+		{"ce", 548},
+		{"cia", 548},
+		{"cl", 548},
+		{"das", 548},
+		{"e m", 548},
+		{"e r", 548},
+		{"eri", 548},
+		{"eu ", 548},
+		{"fe", 548},
+		{"fi", 548},
+		{"ge", 548},
+		{"ica", 548},
+		{"il", 548},
+		{"im", 548},
+		{"ir ", 548},
+		{"is ", 548},
+		{"ja", 548},
+		{"ma ", 548},
+		{"mar", 548},
+		{"mas", 548},
+		{"mos", 548},
+		{"mui", 548},
+		{"nda", 548},
+		{"nta", 548},
+		{"nti", 548},
+		{"nç", 548},
+		{"odo", 548},
+		{"ol", 548},
+		{"ora", 548},
+		{"ou ", 548},
+		{"por", 548},
+		{"r e", 548},
+		{"r o", 548},
+		{"r s", 548},
+		{"ras", 548},
+		{"re ", 548},
+		{"s l", 548},
+		{"s n", 548},
+		{"s o", 548},
+		{"sco", 548},
+		{"scr", 548},
+		{"tav", 548},
+		{"ter", 548},
+		{"tes", 548},
+		{"tod", 548},
+		{"tu", 548},
+		{"uan", 548},
+		{"uit", 548},
+		{"us", 548},
+		{"z", 548},
+		{" al", 411},
+		{" er", 411},
+		{" fe", 411},
+		{" fi", 411},
+		{" fo", 411},
+		{" j", 411},
+		{" le", 411},
+		{" lo", 411},
+		{" nã", 411},
+		{" si", 411},
+		{" um", 411},
+		{" vi", 411},
+		{"a l", 411},
+		{"a s", 411},
+		{"ada", 411},
+		{"ais", 411},
+		{"ama", 411},
+		{"ass", 411},
+		{"be", 411},
+		{"ber", 411},
+		{"bre", 411},
+		{"bri", 411},
+		{"ch", 411},
+		{"cla", 411},
+		{"cri", 411},
+		{"dig", 411},
+		{"dir", 411},
+		{"dis", 411},
+		{"e c", 411},
+		{"e f", 411},
+		{"e p", 411},
+		{"e q", 411},
+		{"e t", 411},
+		{"e v", 411},
+		{"eir", 411},
+		{"eit", 411},
+		{"ern", 411},
+		{"ert", 411},
+		{"ev", 411},
+		{"fic", 411},
+		{"fo", 411},
+		{"ga", 411},
+		{"go", 411},
+		{"ha ", 411},
+		{"he", 411},
+		{"ho", 411},
+		{"hu", 411},
+		{"if", 411},
+		{"il ", 411},
+		{"inh", 411},
+		{"int", 411},
+		{"io ", 411},
+		{"ire", 411},
+		{"iv", 411},
+		{"l d", 411},
+		{"le", 411},
+		{"lh", 411},
+		{"lha", 411},
+		{"lo", 411},
+		{"lu", 411},
+		{"m l", 411},
+		{"m o", 411},
+		{"mo ", 411},
+		{"nas", 411},
+		{"nci", 411},
+		{"nde", 411},
+		{"ndo", 411},
+		{"ng", 411},
+		{"nhe", 411},
+		{"nu", 411},
+		{"nv", 411},
+		{"nã", 411},
+		{"não", 411},
+		{"o t", 411},
+		{"o v", 411},
+		{"o à", 411},
+		{"oa", 411},
+		{"ome", 411},
+		{"omo", 411},
+		{"pas", 411},
+		{"pel", 411},
+		{"pes", 411},
+		{"pro", 411},
+		{"ran", 411},
+		{"rav", 411},
+		{"rd", 411},
+		{"rec", 411},
+		{"rei", 411},
+		{"rio", 411},
+		{"rit", 411},
+		{"rn", 411},
+		{"rto", 411},
+		{"s q", 411},
+		{"sem", 411},
+		{"soa", 411},
+		{"sob", 411},
+		{"ssa", 411},
+		{"sse", 411},
+		{"tar", 411},
+		{"tem", 411},
+		{"tro", 411},
+		{"ura", 411},
+		{"vam", 411},
+		{"vid", 411},
+		{"vr", 411},
+		{"ça ", 411},
+		{"ên", 411},
+		{"ênc", 411},
+	},
+	NatLangGerman: {
+		{"e", 52239},
+		{"n", 30323},
+		{"r", 24121},
+		{"s", 19572},
+		{"i", 18607},
+		{"d", 17505},
+		{"a", 17367},
+		{"t", 16953},
+		{"n ", 14748},
+		{"en", 14059},
+		{"h", 13507},
+		{"er", 12129},
+		{"en ", 11302},
+		{"u", 11026},
+		{"g", 9235},
+		{"r ", 9097},
+		{"e ", 8683},
+		{"l", 8683},
+		{" d", 8270},
+		{"c", 8132},
+		{"o", 7718},
+		{"ch", 7443},
+		{"de", 7305},
+		{"er ", 6616},
+		{"f", 6340},
+		{"m", 6340},
+		{"b", 6202},
+		{"ge", 6202},
+		{"nd", 5926},
+		{"t ", 5926},
+		{" s", 5651},
+		{"te", 5651},
+		{"ie", 5237},
+		{"w", 4962},
+		{" w", 4272},
+		{"ei", 4272},
+		{"d ", 4135},
+		{"s ", 4135},
+		{"un", 4135},
+		{" a", 3997},
+		{"re", 3997},
+		{"nd ", 3721},
+		{"es", 3445},
+		{" g", 3308},
+		{"be", 3308},
+		{"der", 3308},
+		{"in", 3308},
+		{"k", 3170},
+		{"st", 3032},
+		{" u", 2894},
+		{"he", 2894},
+		{"ie ", 2894},
+		{"p", 2894},
+		{"se", 2894},
+		{" ge", 2756},
+		{" un", 2756},
+		{"le", 2756},
+		{"und", 2756},
+		{"v", 2756},
+		{" de", 2618},
+		{" di", 2618},
+		{" v", 2618},
+		{"au", 2618},
+		{"di", 2618},
+		{"die", 2618},
+		{" h", 2481},
+		{"z", 2481},
+		{" m", 2343},
+		{"an", 2343},
+		{"da", 2343},
+		{"ha", 2343},
+		{"ü", 2343},
+		{" da", 2205},
+		{"hr", 2205},
+		{"nde", 2205},
+		{" f", 2067},
+		{"as", 2067},
+		{"ch ", 2067},
+		{"h ", 2067},
+		{" e", 1929},
+		{"ic", 1929},
+		{"it", 1929},
+		{"n d", 1929},
+		{"ne", 1929},
+		{"sc", 1929},
+		{"sch", 1929},
+		{"ss", 1929},
+		{" i", 1791},
+		{" n", 1791},
+		{"ben", 1791},
+		{"cht", 1791},
+		{"den", 1791},
+		{"ht", 1791},
+		{"ich", 1791},
+		{"m ", 1791},
+		{"on", 1791},
+		{"si", 1791},
+		{"te ", 1791},
+		{"ä", 1791},
+		{" b", 1654},
+		{" ha", 1654},
+		{" si", 1654},
+		{"ar", 1654},
+		{"gen", 1654},
+		{"is", 1654},
+		{"n w", 1654},
+		{"ve", 1654},
+		{"ver", 1654},
+		{" ve", 1516},
+		{" z", 1516},
+		{"al", 1516},
+		{"das", 1516},
+		{"me", 1516},
+		{"so", 1516},
+		{"ter", 1516},
+		{"ti", 1516},
+		{" k", 1378},
+		{" p", 1378},
+		{" so", 1378},
+		{"ab", 1378},
+		{"am", 1378},
+		{"che", 1378},
+		{"eb", 1378},
+		{"eh", 1378},
+		{"el", 1378},
+		{"es ", 1378},
+		{"fe", 1378},
+		{"n s", 1378},
+		{"ns", 1378},
+		{"or", 1378},
+		{"sen", 1378},
+		{"sp", 1378},
+		{"ten", 1378},
+		{"we", 1378},
+		{" au", 1240},
+		{" we", 1240},
+		{"abe", 1240},
+		{"eg", 1240},
+		{"ein", 1240},
+		{"f ", 1240},
+		{"it ", 1240},
+		{"nt", 1240},
+		{"ra", 1240},
+		{"ste", 1240},
+		{"t d", 1240},
+		{"uf", 1240},
+		{"ut", 1240},
+		{"wa", 1240},
+		{"wi", 1240},
+		{" an", 1102},
+		{" o", 1102},
+		{" r", 1102},
+		{" zu", 1102},
+		{"as ", 1102},
+		{"at", 1102},
+		{"auf", 1102},
+		{"hen", 1102},
+		{"hre", 1102},
+		{"li", 1102},
+		{"lt", 1102},
+		{"n m", 1102},
+		{"n u", 1102},
+		{"na", 1102},
+		{"on ", 1102},
+		{"pr", 1102},
+		{"ren", 1102},
+		{"ta", 1102},
+		{"us", 1102},
+		{"zu", 1102},
+		{" l", 964},
+		{" wa", 964},
+		{" wi", 964},
+		{"ac", 964},
+		{"ach", 964},
+		{"an ", 964},
+		{"ass", 964},
+		{"aus", 964},
+		{"de ", 964},
+		{"eit", 964},
+		{"ges", 964},
+		{"her", 964},
+		{"ht ", 964},
+		{"ig", 964},
+		{"ind", 964},
+		{"j", 964},
+		{"n a", 964},
+		{"n g", 964},
+		{"ng", 964},
+		{"r s", 964},
+		{"rd", 964},
+		{"rg", 964},
+		{"ri", 964},
+		{"st ", 964},
+		{"ur", 964},
+		{"war", 964},
+		{"ö", 964},
+		{" j", 827},
+		{" le", 827},
+		{" me", 827},
+		{" re", 827},
+		{" sp", 827},
+		{" t", 827},
+		{" vo", 827},
+		{" wo", 827},
+		{"ag", 827},
+		{"and", 827},
+		{"ber", 827},
+		{"e d", 827},
+		{"ec", 827},
+		{"ed", 827},
+		{"ege", 827},
+		{"end", 827},
+		{"ens", 827},
+		{"ere", 827},
+		{"esc", 827},
+		{"ft", 827},
+		{"g ", 827},
+		{"in ", 827},
+		{"ir", 827},
+		{"ist", 827},
+		{"la", 827},
+		{"ll", 827},
+		{"ma", 827},
+		{"mi", 827},
+		{"n b", 827},
+		{"n e", 827},
+		{"n h", 827},
+		{"ne ", 827},
+		{"nn", 827},
+		{"od", 827},
+		{"ode", 827},
+		{"r d", 827},
+		{"r h", 827},
+		{"r w", 827},
+		{"rde", 827},
+		{"rei", 827},
+		{"ro", 827},
+		{"rt", 827},
+		{"s d", 827},
+		{"sse", 827},
+		{"uf ", 827},
+		{"vo", 827},
+		{"wo", 827},
+		{" fe", 689},
+		{" ma", 689},
+		{" se", 689},
+		{"am ", 689},
+		{"br", 689},
+		{"d d", 689},
+		{"des", 689},
+		{"e m", 689},
+		{"e s", 689},
+		{"e v", 689},
+		{"e w", 689},
+		{"ebe", 689},
+		{"ech", 689},
+		{"ehr", 689},
+		{"ent", 689},
+		{"ern", 689},
+		{"ers", 689},
+		{"et", 689},
+		{"eu", 689},
+		{"fen", 689},
+		{"geb", 689},
+		{"hab", 689},
+		{"hr ", 689},
+		{"ige", 689},
+		{"ih", 689},
+		{"ler", 689},
+		{"lte", 689},
+		{"men", 689},
+		{"mit", 689},
+		{"r v", 689},
+		{"rge", 689},
+		{"rn", 689},
+		{"rs", 689},
+		{"ru", 689},
+		{"sic", 689},
+		{"sin", 689},
+		{"spr", 689},
+		{"ss ", 689},
+		{"t u", 689},
+		{"t v", 689},
+		{"tig", 689},
+		{"u ", 689},
+		{"wer", 689},
+		{"wir", 689},
+		{"zu ", 689},
+		{"ür", 689},
+		{" al", 551},
+		{" am", 551},
+		{" be", 551},
+		{" br", 551},
+		{" du", 551},
+		{" ei", 551},
+		{" he", 551},
+		{" na", 551},
+		{" od", 551},
+		{"ah", 551},
+		{"ahr", 551},
+		{"alt", 551},
+		{"ar ", 551},
+		{"at ", 551},
+		{"bt", 551},
+		{"d s", 551},
+		{"du", 551},
+		{"dur", 551},
+		{"e g", 551},
+		{"e t", 551},
+		{"e u", 551},
+		{"eis", 551},
+		{"erd", 551},
+		{"ese", 551},
+		{"f d", 551},
+		{"h a", 551},
+		{"hau", 551},
+		{"hl", 551},
+		{"hte", 551},
+		{"ier", 551},
+		{"ine", 551},
+		{"io", 551},
+		{"ion", 551},
+		{"ir ", 551},
+		{"je", 551},
+		{"kt", 551},
+		{"l ", 551},
+		{"len", 551},
+		{"lic", 551},
+		{"man", 551},
+		{"nen", 551},
+		{"ng ", 551},
+		{"ni", 551},
+		{"nne", 551},
+		{"nsc", 551},
+		{"nst", 551},
+		{"nte", 551},
+		{"oc", 551},
+		{"och", 551},
+		{"of", 551},
+		{"ol", 551},
+		{"r g", 551},
+		{"r k", 551},
+		{"rau", 551},
+		{"rc", 551},
+		{"rch", 551},
+		{"re ", 551},
+		{"rec", 551},
+		{"rie", 551},
+		{"rk", 551},
+		{"rü", 551},
+		{"son", 551},
+		{"t a", 551},
+		{"tag", 551},
+		{"tl", 551},
+		{"um", 551},
+		{"ung", 551},
+		{"urc", 551},
+		{"von", 551},
+		{"ze", 551},
+		{"üb", 551},
+		{"übe", 551},
+		{" ab", 413},
+		{" er", 413},
+		{" es", 413},
+		{" fr", 413},
+		{" ih", 413},
+		{" im", 413},
+		{" in", 413},
+		{" is", 413},
+		{" ja", 413},
+		{" je", 413},
+		{" ni", 413},
+		{" no", 413},
+		{" pr", 413},
+		{" st", 413},
+		{" ü", 413},
+		{" üb", 413},
+		{"ami", 413},
+		{"are", 413},
+		{"b ", 413},
+		{"bt ", 413},
+		{"chl", 413},
+		{"chr", 413},
+		{"ck", 413},
+		{"d a", 413},
+		{"d f", 413},
+		{"d g", 413},
+		{"e a", 413},
+		{"e f", 413},
+		{"e l", 413},
+		{"ede", 413},
+		{"ef", 413},
+		{"ehe", 413},
+		{"ele", 413},
+		{"em", 413},
+		{"erk", 413},
+		{"ert", 413},
+		{"eut", 413},
+		{"fa", 413},
+		{"ff", 413},
+		{"fr", 413},
+		{"fre", 413},
+		{"ft ", 413},
+		{"fu", 413},
+		{"fun", 413},
+		{"fü", 413},
+		{"für", 413},
+		{"g s", 413},
+		{"ga", 413},
+		{"ge ", 413},
+		{"geg", 413},
+		{"gel", 413},
+		{"gr", 413},
+		{"h d", 413},
+		{"hal", 413},
+		{"hat", 413},
+		{"hei", 413},
+		{"ieb", 413},
+		{"iel", 413},
+		{"ihr", 413},
+		{"il", 413},
+		{"im", 413},
+		{"im ", 413},
+		{"int", 413},
+		{"ja", 413},
+		{"jah", 413},
+		{"jed", 413},
+		{"ke", 413},
+		{"kl", 413},
+		{"ku", 413},
+		{"lei", 413},
+		{"lle", 413},
+		{"ls", 413},
+		{"mei", 413},
+		{"mm", 413},
+		{"n f", 413},
+		{"n n", 413},
+		{"no", 413},
+		{"noc", 413},
+		{"oh", 413},
+		{"oll", 413},
+		{"org", 413},
+		{"pa", 413},
+		{"pro", 413},
+		{"r a", 413},
+		{"r b", 413},
+		{"r e", 413},
+		{"r p", 413},
+		{"r z", 413},
+		{"rb", 413},
+		{"rer", 413},
+		{"rt ", 413},
+		{"run", 413},
+		{"s p", 413},
+		{"s s", 413},
+		{"se ", 413},
+		{"seh", 413},
+		{"sie", 413},
+		{"sst", 413},
+		{"sta", 413},
+		{"t g", 413},
+		{"t s", 413},
+		{"tes", 413},
+		{"tio", 413},
+		{"tt", 413},
+		{"tw", 413},
+		{"tz", 413},
+		{"use", 413},
+		{"ute", 413},
+		{"zi", 413},
+		{"ß", 413},
+		{"än", 413},
+		{"ür ", 413},
+	},
+	NatLangItalian: {
+		{"i", 37956},
+		{"a", 31652},
+		{"e", 31520},
+		{"o", 27318},
+		{"n", 22064},
+		{"r", 18912},
+		{"t", 18518},
+		{"l", 16942},
+		{"i ", 13921},
+		{"s", 13527},
+		{"a ", 13133},
+		{"c", 13002},
+		{"e ", 12871},
+		{"d", 12083},
+		{"o ", 12083},
+		{" d", 8274},
+		{"u", 7880},
+		{"g", 6698},
+		{"p", 6698},
+		{"m", 6041},
+		{" a", 5778},
+		{"di", 5778},
+		{" c", 5647},
+		{"er", 5647},
+		{"v", 5384},
+		{" p", 4990},
+		{" s", 4990},
+		{"ri", 4596},
+		{" di", 4465},
+		{"on", 4465},
+		{"no", 4334},
+		{" l", 4202},
+		{"an", 4202},
+		{"re", 4202},
+		{"b", 4071},
+		{" e", 3808},
+		{"in", 3808},
+		{" i", 3677},
+		{"co", 3677},
+		{"la", 3546},
+		{"io", 3414},
+		{"ne", 3414},
+		{"no ", 3414},
+		{"ti", 3414},
+		{"to", 3414},
+		{"h", 3283},
+		{"ra", 3283},
+		{"te", 3283},
+		{"di ", 3152},
+		{"f", 3152},
+		{"ia", 3020},
+		{"li", 3020},
+		{"at", 2889},
+		{"la ", 2889},
+		{"to ", 2889},
+		{"z", 2889},
+		{"al", 2758},
+		{"or", 2758},
+		{"re ", 2758},
+		{" m", 2626},
+		{"ch", 2626},
+		{"e d", 2626},
+		{"en", 2626},
+		{"it", 2626},
+		{"l ", 2626},
+		{"ll", 2626},
+		{"na", 2626},
+		{" n", 2495},
+		{"ar", 2495},
+		{"de", 2495},
+		{" co", 2364},
+		{" f", 2364},
+		{"le", 2364},
+		{"ni", 2364},
+		{"st", 2364},
+		{"ti ", 2364},
+		{" de", 2232},
+		{"el", 2232},
+		{"es", 2232},
+		{"o d", 2232},
+		{"si", 2232},
+		{"ta", 2232},
+		{"tt", 2232},
+		{" al", 2101},
+		{"ro", 2101},
+		{"un", 2101},
+		{" e ", 1970},
+		{"ci", 1970},
+		{"ma", 1970},
+		{"ne ", 1970},
+		{"tr", 1970},
+		{" ch", 1838},
+		{" t", 1838},
+		{"ic", 1838},
+		{"ion", 1838},
+		{"n ", 1838},
+		{"ri ", 1838},
+		{"sc", 1838},
+		{"so", 1838},
+		{" g", 1707},
+		{" in", 1707},
+		{" v", 1707},
+		{"che", 1707},
+		{"gi", 1707},
+		{"he", 1707},
+		{"le ", 1707},
+		{"lla", 1707},
+		{"nt", 1707},
+		{"pe", 1707},
+		{"vi", 1707},
+		{"zi", 1707},
+		{" r", 1576},
+		{"a c", 1576},
+		{"a d", 1576},
+		{"ell", 1576},
+		{"gl", 1576},
+		{"gli", 1576},
+		{"he ", 1576},
+		{"me", 1576},
+		{"ol", 1576},
+		{"one", 1576},
+		{"os", 1576},
+		{"ra ", 1576},
+		{"te ", 1576},
+		{"ve", 1576},
+		{" la", 1444},
+		{"a a", 1444},
+		{"are", 1444},
+		{"ca", 1444},
+		{"do", 1444},
+		{"e l", 1444},
+		{"i p", 1444},
+		{"il", 1444},
+		{"li ", 1444},
+		{"mo", 1444},
+		{"ni ", 1444},
+		{"o a", 1444},
+		{"o c", 1444},
+		{"po", 1444},
+		{"pr", 1444},
+		{"se", 1444},
+		{"va", 1444},
+		{" pe", 1313},
+		{" u", 1313},
+		{"ano", 1313},
+		{"bi", 1313},
+		{"eg", 1313},
+		{"i a", 1313},
+		{"i s", 1313},
+		{"lt", 1313},
+		{"per", 1313},
+		{"ss", 1313},
+		{"zio", 1313},
+		{" an", 1182},
+		{" o", 1182},
+		{" pr", 1182},
+		{"a p", 1182},
+		{"bb", 1182},
+		{"con", 1182},
+		{"del", 1182},
+		{"i d", 1182},
+		{"i e", 1182},
+		{"na ", 1182},
+		{"nd", 1182},
+		{"o s", 1182},
+		{"rt", 1182},
+		{"ta ", 1182},
+		{" a ", 1050},
+		{" i ", 1050},
+		{" le", 1050},
+		{" ma", 1050},
+		{" po", 1050},
+		{"a f", 1050},
+		{"as", 1050},
+		{"cu", 1050},
+		{"da", 1050},
+		{"e a", 1050},
+		{"e c", 1050},
+		{"ere", 1050},
+		{"fi", 1050},
+		{"i i", 1050},
+		{"ia ", 1050},
+		{"iv", 1050},
+		{"nc", 1050},
+		{"o p", 1050},
+		{"ori", 1050},
+		{"pa", 1050},
+		{"r ", 1050},
+		{"si ", 1050},
+		{"uo", 1050},
+		{"ut", 1050},
+		{" il", 919},
+		{" no", 919},
+		{" so", 919},
+		{" vi", 919},
+		{"all", 919},
+		{"am", 919},
+		{"ate", 919},
+		{"ato", 919},
+		{"be", 919},
+		{"ce", 919},
+		{"chi", 919},
+		{"e e", 919},
+		{"e t", 919},
+		{"ed", 919},
+		{"er ", 919},
+		{"ess", 919},
+		{"ev", 919},
+		{"gio", 919},
+		{"gn", 919},
+		{"hi", 919},
+		{"i c", 919},
+		{"ig", 919},
+		{"il ", 919},
+		{"io ", 919},
+		{"nn", 919},
+		{"nz", 919},
+		{"og", 919},
+		{"on ", 919},
+		{"ost", 919},
+		{"sa", 919},
+		{"sti", 919},
+		{"tti", 919},
+		{"tu", 919},
+		{"ua", 919},
+		{" gl", 788},
+		{" mo", 788},
+		{" ne", 788},
+		{" pa", 788},
+		{" ri", 788},
+		{" sc", 788},
+		{" è", 788},
+		{" è ", 788},
+		{"a l", 788},
+		{"a m", 788},
+		{"alt", 788},
+		{"anc", 788},
+		{"ann", 788},
+		{"av", 788},
+		{"az", 788},
+		{"cor", 788},
+		{"do ", 788},
+		{"e s", 788},
+		{"ent", 788},
+		{"era", 788},
+		{"eri", 788},
+		{"et", 788},
+		{"ge", 788},
+		{"gg", 788},
+		{"i n", 788},
+		{"i r", 788},
+		{"ie", 788},
+		{"im", 788},
+		{"ir", 788},
+		{"is", 788},
+		{"lo", 788},
+		{"ltr", 788},
+		{"ma ", 788},
+		{"pi", 788},
+		{"rit", 788},
+		{"sta", 788},
+		{"va ", 788},
+		{"za", 788},
+		{"za ", 788},
+		{"è", 788},
+		{"è ", 788},
+		{" b", 656},
+		{" ca", 656},
+		{" da", 656},
+		{" do", 656},
+		{" er", 656},
+		{" fa", 656},
+		{" fi", 656},
+		{" h", 656},
+		{" ha", 656},
+		{" l ", 656},
+		{" li", 656},
+		{" na", 656},
+		{" q", 656},
+		{" qu", 656},
+		{" se", 656},
+		{" si", 656},
+		{" tu", 656},
+		{" un", 656},
+		{" ve", 656},
+		{"a i", 656},
+		{"a n", 656},
+		{"azi", 656},
+		{"bbi", 656},
+		{"ber", 656},
+		{"bia", 656},
+		{"cc", 656},
+		{"cos", 656},
+		{"da ", 656},
+		{"div", 656},
+		{"du", 656},
+		{"e i", 656},
+		{"e n", 656},
+		{"e p", 656},
+		{"ers", 656},
+		{"est", 656},
+		{"ett", 656},
+		{"eva", 656},
+		{"fa", 656},
+		{"ha", 656},
+		{"i u", 656},
+		{"ib", 656},
+		{"ito", 656},
+		{"itt", 656},
+		{"nit", 656},
+		{"nno", 656},
+		{"non", 656},
+		{"nu", 656},
+		{"o l", 656},
+		{"oc", 656},
+		{"om", 656},
+		{"ono", 656},
+		{"ora", 656},
+		{"ov", 656},
+		{"pro", 656},
+		{"q", 656},
+		{"qu", 656},
+		{"ran", 656},
+		{"ro ", 656},
+		{"rs", 656},
+		{"ser", 656},
+		{"su", 656},
+		{"tro", 656},
+		{"tto", 656},
+		{"una", 656},
+		{"à", 656},
+		{"à ", 656},
+		{" ab", 525},
+		{" es", 525},
+		{" me", 525},
+		{" o ", 525},
+		{" sp", 525},
+		{" su", 525},
+		{" te", 525},
+		{"a e", 525},
+		{"a s", 525},
+		{"a v", 525},
+		{"ab", 525},
+		{"abb", 525},
+		{"ac", 525},
+		{"ag", 525},
+		{"and", 525},
+		{"art", 525},
+		{"cr", 525},
+		{"ei", 525},
+		{"ei ", 525},
+		{"el ", 525},
+		{"em", 525},
+		{"eni", 525},
+		{"fin", 525},
+		{"ggi", 525},
+		{"hia", 525},
+		{"i l", 525},
+		{"i m", 525},
+		{"iat", 525},
+		{"ica", 525},
+		{"ici", 525},
+		{"ien", 525},
+		{"if", 525},
+		{"in ", 525},
+		{"ind", 525},
+		{"ino", 525},
+		{"iri", 525},
+		{"ita", 525},
+		{"iu", 525},
+		{"lib", 525},
+		{"lo ", 525},
+		{"men", 525},
+		{"mi", 525},
+		{"mo ", 525},
+		{"mol", 525},
+		{"nco", 525},
+		{"ndi", 525},
+		{"ng", 525},
+		{"nte", 525},
+		{"nti", 525},
+		{"nzi", 525},
+		{"o i", 525},
+		{"od", 525},
+		{"ogn", 525},
+		{"olt", 525},
+		{"ome", 525},
+		{"op", 525},
+		{"par", 525},
+		{"pos", 525},
+		{"rim", 525},
+		{"rn", 525},
+		{"rte", 525},
+		{"sci", 525},
+		{"sco", 525},
+		{"so ", 525},
+		{"son", 525},
+		{"sp", 525},
+		{"sse", 525},
+		{"tat", 525},
+		{"tin", 525},
+		{"tor", 525},
+		{"tri", 525},
+		{"tut", 525},
+		{"uni", 525},
+		{"ur", 525},
+		{"us", 525},
+		{"utt", 525},
+		{"ver", 525},
+		{"vo", 525},
+		{"à e", 525},
+		{"ì", 525},
+		{"ì ", 525},
+		{" bi", 394},
+		{" ed", 394},
+		{" fr", 394},
+		{" fu", 394},
+		{" gi", 394},
+		{" pi", 394},
+		{" ra", 394},
+		{" st", 394},
+		{" tr", 394},
+		{"a g", 394},
+		{"ad", 394},
+		{"agi", 394},
+		{"ale", 394},
+		{"amo", 394},
+		{"asc", 394},
+		{"ati", 394},
+		{"att", 394},
+		{"bbe", 394},
+		{"cat", 394},
+		{"ce ", 394},
+		{"com", 394},
+		{"cri", 394},
+		{"d ", 394},
+		{"deg", 394},
+		{"dic", 394},
+		{"dif", 394},
+		{"dir", 394},
+		{"duo", 394},
+		{"e f", 394},
+		{"e m", 394},
+		{"e v", 394},
+		{"eb", 394},
+		{"ebb", 394},
+		{"egg", 394},
+		{"egl", 394},
+		{"ene", 394},
+		{"enz", 394},
+		{"ert", 394},
+		{"fic", 394},
+		{"fr", 394},
+		{"fu", 394},
+		{"gia", 394},
+		{"gni", 394},
+		{"gr", 394},
+		{"gra", 394},
+		{"han", 394},
+		{"i g", 394},
+		{"i h", 394},
+		{"i v", 394},
+		{"iam", 394},
+		{"ibe", 394},
+		{"id", 394},
+		{"idu", 394},
+		{"igl", 394},
+		{"ile", 394},
+		{"ima", 394},
+		{"ine", 394},
+		{"ing", 394},
+		{"ini", 394},
+		{"ive", 394},
+		{"ivi", 394},
+		{"iz", 394},
+		{"izi", 394},
+		{"l a", 394},
+		{"l c", 394},
+		{"l s", 394},
+		{"lto", 394},
+		{"man", 394},
+		{"me ", 394},
+		{"n p", 394},
+		{"nas", 394},
+		{"ndo", 394},
+		{"nel", 394},
+		{"ner", 394},
+		{"ns", 394},
+		{"nza", 394},
+		{"o e", 394},
+		{"o f", 394},
+		{"o g", 394},
+		{"o m", 394},
+		{"o t", 394},
+		{"odi", 394},
+		{"ole", 394},
+		{"ona", 394},
+		{"ont", 394},
+		{"ot", 394},
+		{"po ", 394},
+	},
+	NatLangDutch: {
+		{"e", 57918},
+		{"n", 30096},
+		{"a", 20331},
+		{"d", 20197},
+		{"o", 19261},
+		{"r", 18325},
+		{"n ", 17924},
+		{"t", 17121},
+		{"en", 16318},
+		{"i", 16051},
+		{"en ", 13777},
+		{"de", 11369},
+		{"e ", 11369},
+		{"g", 11235},
+		{"s", 10032},
+		{"l", 9497},
+		{"h", 8293},
+		{" d", 7891},
+		{"t ", 7758},
+		{"er", 7624},
+		{"k", 7089},
+		{"v", 6688},
+		{"m", 6019},
+		{"de ", 5617},
+		{"ge", 5617},
+		{"w", 5484},
+		{"j", 5350},
+		{"u", 5350},
+		{"r ", 5216},
+		{"b", 5082},
+		{" de", 4681},
+		{"te", 4681},
+		{" o", 4547},
+		{" v", 4414},
+		{" e", 4280},
+		{"aa", 4280},
+		{"an", 4280},
+		{"he", 4280},
+		{" h", 4146},
+		{" w", 4146},
+		{" g", 4012},
+		{"ij", 4012},
+		{"p", 4012},
+		{"s ", 4012},
+		{"z", 4012},
+		{"et", 3879},
+		{"nd", 3879},
+		{"c", 3611},
+		{"ee", 3611},
+		{"et ", 3344},
+		{" he", 3210},
+		{" z", 3210},
+		{"d ", 3210},
+		{"el", 3210},
+		{" ge", 2942},
+		{" m", 2942},
+		{"ar", 2942},
+		{"or", 2942},
+		{"ve", 2942},
+		{"nde", 2808},
+		{"re", 2808},
+		{" b", 2675},
+		{"ch", 2675},
+		{"den", 2675},
+		{"g ", 2675},
+		{"le", 2675},
+		{"oo", 2675},
+		{" en", 2541},
+		{"er ", 2541},
+		{"f", 2541},
+		{"ie", 2541},
+		{"in", 2541},
+		{"n d", 2541},
+		{" i", 2407},
+		{" n", 2407},
+		{"at", 2407},
+		{" t", 2273},
+		{"der", 2273},
+		{"on", 2273},
+		{"aar", 2140},
+		{"het", 2140},
+		{"wa", 2140},
+		{"st", 2006},
+		{"ver", 2006},
+		{" k", 1872},
+		{"an ", 1872},
+		{"da", 1872},
+		{"ed", 1872},
+		{"ke", 1872},
+		{"n e", 1872},
+		{"oe", 1872},
+		{" va", 1738},
+		{"be", 1738},
+		{"k ", 1738},
+		{"me", 1738},
+		{"ten", 1738},
+		{"va", 1738},
+		{" a", 1605},
+		{" wa", 1605},
+		{"and", 1605},
+		{"n g", 1605},
+		{"van", 1605},
+		{"we", 1605},
+		{"ar ", 1471},
+		{"es", 1471},
+		{"ig", 1471},
+		{"la", 1471},
+		{"ma", 1471},
+		{"n o", 1471},
+		{"oor", 1471},
+		{"ra", 1471},
+		{"ri", 1471},
+		{"rs", 1471},
+		{"sc", 1471},
+		{"sch", 1471},
+		{"te ", 1471},
+		{"ui", 1471},
+		{"zo", 1471},
+		{" p", 1337},
+		{" te", 1337},
+		{" ve", 1337},
+		{" zo", 1337},
+		{"di", 1337},
+		{"eb", 1337},
+		{"li", 1337},
+		{"n w", 1337},
+		{"ns", 1337},
+		{"om", 1337},
+		{"ou", 1337},
+		{"rd", 1337},
+		{"ren", 1337},
+		{" j", 1203},
+		{" ma", 1203},
+		{" r", 1203},
+		{" s", 1203},
+		{" we", 1203},
+		{" zi", 1203},
+		{"al", 1203},
+		{"at ", 1203},
+		{"e d", 1203},
+		{"een", 1203},
+		{"ei", 1203},
+		{"ere", 1203},
+		{"ers", 1203},
+		{"gen", 1203},
+		{"id", 1203},
+		{"n h", 1203},
+		{"n v", 1203},
+		{"nt", 1203},
+		{"ro", 1203},
+		{"t d", 1203},
+		{"ti", 1203},
+		{"vo", 1203},
+		{"zi", 1203},
+		{" be", 1070},
+		{" da", 1070},
+		{" in", 1070},
+		{" me", 1070},
+		{" on", 1070},
+		{"ag", 1070},
+		{"ede", 1070},
+		{"end", 1070},
+		{"f ", 1070},
+		{"ij ", 1070},
+		{"is", 1070},
+		{"j ", 1070},
+		{"n b", 1070},
+		{"n z", 1070},
+		{"no", 1070},
+		{"of", 1070},
+		{"op", 1070},
+		{"r d", 1070},
+		{"rg", 1070},
+		{"ze", 1070},
+		{" no", 936},
+		{" op", 936},
+		{"cht", 936},
+		{"dat", 936},
+		{"do", 936},
+		{"e m", 936},
+		{"e o", 936},
+		{"e v", 936},
+		{"eg", 936},
+		{"ev", 936},
+		{"ht", 936},
+		{"ijk", 936},
+		{"ijn", 936},
+		{"is ", 936},
+		{"jk", 936},
+		{"jn", 936},
+		{"jn ", 936},
+		{"l ", 936},
+		{"m ", 936},
+		{"na", 936},
+		{"ni", 936},
+		{"og", 936},
+		{"or ", 936},
+		{"ter", 936},
+		{" di", 802},
+		{" do", 802},
+		{" ee", 802},
+		{" l", 802},
+		{" na", 802},
+		{" of", 802},
+		{" ov", 802},
+		{" vo", 802},
+		{" wo", 802},
+		{"aan", 802},
+		{"ak", 802},
+		{"as", 802},
+		{"as ", 802},
+		{"bo", 802},
+		{"eid", 802},
+		{"ek", 802},
+		{"ens", 802},
+		{"est", 802},
+		{"gel", 802},
+		{"gr", 802},
+		{"ha", 802},
+		{"ho", 802},
+		{"ie ", 802},
+		{"il", 802},
+		{"in ", 802},
+		{"je", 802},
+		{"ken", 802},
+		{"ko", 802},
+		{"lij", 802},
+		{"ll", 802},
+		{"lle", 802},
+		{"maa", 802},
+		{"n i", 802},
+		{"ng", 802},
+		{"of ", 802},
+		{"ond", 802},
+		{"oud", 802},
+		{"ov", 802},
+		{"ove", 802},
+		{"p ", 802},
+		{"pe", 802},
+		{"raa", 802},
+		{"rde", 802},
+		{"rij", 802},
+		{"se", 802},
+		{"ste", 802},
+		{"t w", 802},
+		{"ud", 802},
+		{"wi", 802},
+		{"wo", 802},
+		{"zij", 802},
+		{" is", 668},
+		{" ko", 668},
+		{"am", 668},
+		{"bb", 668},
+		{"bbe", 668},
+		{"ben", 668},
+		{"e b", 668},
+		{"e h", 668},
+		{"e n", 668},
+		{"e p", 668},
+		{"ebb", 668},
+		{"eli", 668},
+		{"eve", 668},
+		{"ft", 668},
+		{"g n", 668},
+		{"geb", 668},
+		{"heb", 668},
+		{"hei", 668},
+		{"id ", 668},
+		{"ing", 668},
+		{"it", 668},
+		{"jk ", 668},
+		{"kt", 668},
+		{"ld", 668},
+		{"len", 668},
+		{"ls", 668},
+		{"ls ", 668},
+		{"mee", 668},
+		{"men", 668},
+		{"n k", 668},
+		{"n m", 668},
+		{"nd ", 668},
+		{"nog", 668},
+		{"od", 668},
+		{"oed", 668},
+		{"og ", 668},
+		{"op ", 668},
+		{"ord", 668},
+		{"ot", 668},
+		{"pr", 668},
+		{"r g", 668},
+		{"r h", 668},
+		{"rt", 668},
+		{"s v", 668},
+		{"sen", 668},
+		{"so", 668},
+		{"ts", 668},
+		{"ude", 668},
+		{"ven", 668},
+		{"was", 668},
+		{"we ", 668},
+		{" al", 535},
+		{" an", 535},
+		{" du", 535},
+		{" f", 535},
+		{" je", 535},
+		{" ni", 535},
+		{" pl", 535},
+		{" re", 535},
+		{" wi", 535},
+		{"aat", 535},
+		{"age", 535},
+		{"ap", 535},
+		{"are", 535},
+		{"bl", 535},
+		{"br", 535},
+		{"d e", 535},
+		{"dag", 535},
+		{"die", 535},
+		{"doo", 535},
+		{"du", 535},
+		{"e w", 535},
+		{"e z", 535},
+		{"ec", 535},
+		{"ees", 535},
+		{"ef", 535},
+		{"ege", 535},
+		{"el ", 535},
+		{"ele", 535},
+		{"erg", 535},
+		{"ete", 535},
+		{"ez", 535},
+		{"g o", 535},
+		{"ges", 535},
+		{"ht ", 535},
+		{"ic", 535},
+		{"ig ", 535},
+		{"ik", 535},
+		{"kt ", 535},
+		{"laa", 535},
+		{"le ", 535},
+		{"mi", 535},
+		{"mo", 535},
+		{"moe", 535},
+		{"n t", 535},
+		{"ne", 535},
+		{"ng ", 535},
+		{"nie", 535},
+		{"nn", 535},
+		{"nte", 535},
+		{"ol", 535},
+		{"om ", 535},
+		{"ont", 535},
+		{"pl", 535},
+		{"r e", 535},
+		{"re ", 535},
+		{"rge", 535},
+		{"rs ", 535},
+		{"rsc", 535},
+		{"s e", 535},
+		{"s h", 535},
+		{"st ", 535},
+		{"t g", 535},
+		{"t m", 535},
+		{"t o", 535},
+		{"t v", 535},
+		{"ta", 535},
+		{"un", 535},
+		{"voo", 535},
+		{"wor", 535},
+		{"ze ", 535},
+		{" aa", 401},
+		{" bi", 401},
+		{" bo", 401},
+		{" br", 401},
+		{" c", 401},
+		{" co", 401},
+		{" go", 401},
+		{" gr", 401},
+		{" ha", 401},
+		{" ie", 401},
+		{" ja", 401},
+		{" le", 401},
+		{" mo", 401},
+		{" om", 401},
+		{" pr", 401},
+		{" so", 401},
+		{" vr", 401},
+		{" ze", 401},
+		{"ad", 401},
+		{"ag ", 401},
+		{"all", 401},
+		{"als", 401},
+		{"am ", 401},
+		{"ats", 401},
+		{"beg", 401},
+		{"bi", 401},
+		{"bij", 401},
+		{"ble", 401},
+		{"cha", 401},
+		{"chr", 401},
+		{"co", 401},
+		{"d v", 401},
+		{"d w", 401},
+		{"d z", 401},
+		{"del", 401},
+		{"dig", 401},
+		{"ds", 401},
+		{"dui", 401},
+		{"e f", 401},
+		{"e g", 401},
+		{"e k", 401},
+		{"e r", 401},
+		{"e s", 401},
+		{"ebo", 401},
+		{"ech", 401},
+		{"eef", 401},
+		{"eel", 401},
+		{"eer", 401},
+		{"eh", 401},
+		{"eke", 401},
+		{"eld", 401},
+		{"elk", 401},
+		{"em", 401},
+		{"erd", 401},
+		{"esc", 401},
+		{"eu", 401},
+		{"eze", 401},
+		{"f d", 401},
+		{"ft ", 401},
+		{"ga", 401},
+		{"gee", 401},
+		{"gi", 401},
+		{"go", 401},
+		{"gro", 401},
+		{"hap", 401},
+		{"hi", 401},
+		{"hou", 401},
+		{"hr", 401},
+		{"hte", 401},
+		{"hu", 401},
+		{"ich", 401},
+		{"ide", 401},
+		{"ied", 401},
+		{"ill", 401},
+		{"ind", 401},
+		{"ja", 401},
+		{"je ", 401},
+		{"k d", 401},
+		{"ka", 401},
+		{"ke ", 401},
+		{"kel", 401},
+		{"kk", 401},
+		{"kke", 401},
+		{"kl", 401},
+		{"ld ", 401},
+		{"lk", 401},
+		{"n n", 401},
+		{"nk", 401},
+		{"nne", 401},
+		{"nse", 401},
+		{"oet", 401},
+		{"on ", 401},
+		{"org", 401},
+		{"ort", 401},
+		{"pla", 401},
+		{"pra", 401},
+		{"r w", 401},
+		{"rd ", 401},
+		{"rec", 401},
+		{"rg ", 401},
+		{"rk", 401},
+		{"rt ", 401},
+		{"ru", 401},
+		{"s d", 401},
+		{"sl", 401},
+		{"sla", 401},
+		{"sp", 401},
+		{"t e", 401},
+		{"t h", 401},
+		{"t i", 401},
+		{"t p", 401},
+		{"t t", 401},
+		{"tie", 401},
+		{"to", 401},
+		{"tu", 401},
+		{"uis", 401},
+		{"ur", 401},
+		{"us", 401},
+		{"vr", 401},
+		{"vri", 401},
+		{"waa", 401},
+		{"war", 401},
+		{"zen", 401},
+		{"zor", 401},
+	},
+	NatLangChinese: {
+		{"的", 14312},
+		{"人", 6001},
+		{"在", 5078},
+		{"了", 4616},
+		{"们", 4616},
+		{"很", 4616},
+		{"有", 4616},
+		{"是", 4155},
+		{"一", 3693},
+		{"我", 3693},
+		{"不", 3231},
+		{"上", 2770},
+		{"会", 2770},
+		{"和", 2770},
+		{"时", 2770},
+		{"还", 2770},
+		{"个", 2308},
+		{"以", 2308},
+		{"到", 2308},
+		{"发", 2308},
+		{"天", 2308},
+		{"我们", 2308},
+		{"的 ", 2308},
+		{" 人", 1846},
+		{" 我", 1846},
+		{" 这", 1846},
+		{"也", 1846},
+		{"了 ", 1846},
+		{"候", 1846},
+		{"去", 1846},
+		{"多", 1846},
+		{"奴", 1846},
+		{"它", 1846},
+		{"家", 1846},
+		{"应", 1846},
+		{"或", 1846},
+		{"时候", 1846},
+		{"来", 1846},
+		{"生", 1846},
+		{"而", 1846},
+		{"自", 1846},
+		{"要", 1846},
+		{"过", 1846},
+		{"这", 1846},
+		{"里", 1846},
+		{" 一", 1385},
+		{" 人人", 1385},
+		{" 而", 1385},
+		{"为", 1385},
+		{"人人", 1385},
+		{"他", 1385},
+		{"位", 1385},
+		{"出", 1385},
+		{"别", 1385},
+		{"大", 1385},
+		{"奴隶", 1385},
+		{"子", 1385},
+		{"市", 1385},
+		{"年", 1385},
+		{"想", 1385},
+		{"权", 1385},
+		{"现", 1385},
+		{"用", 1385},
+		{"由", 1385},
+		{"种", 1385},
+		{"程", 1385},
+		{"经", 1385},
+		{"能", 1385},
+		{"自由", 1385},
+		{"记", 1385},
+		{"身", 1385},
+		{"边", 1385},
+		{"那", 1385},
+		{"长", 1385},
+		{"间", 1385},
+		{"隶", 1385},
+	},
+	NatLangArabic: {
+		{"ا", 44004},
+		{"ل", 31353},
+		{"ال", 20168},
+		{"ي", 19251},
+		{" ا", 18701},
+		{" ال", 17418},
+		{"ن", 17235},
+		{"ت", 15401},
+		{"م", 15218},
+		{"و", 14484},
+		{"ر", 12834},
+		{"ع", 11367},
+		{"أ", 10267},
+		{"ب", 10267},
+		{"ن ", 8984},
+		{"ق", 8617},
+		{"د", 8434},
+		{"ف", 7884},
+		{"ا ", 7517},
+		{"ة", 7334},
+		{"ة ", 7334},
+		{" أ", 6967},
+		{"ك", 6967},
+		{"س", 6600},
+		{"ي ", 5500},
+		{"ه", 5317},
+		{" م", 4950},
+		{"ج", 4767},
+		{"ح", 4767},
+		{" و", 4583},
+		{"ر ", 4217},
+		{" ب", 3850},
+		{"د ", 3850},
+		{" ف", 3483},
+		{"ت ", 3483},
+		{"خ", 3483},
+		{"ن ا", 3483},
+		{"ش", 3300},
+		{"ص", 2933},
+		{"ل ", 2933},
+		{"لا", 2933},
+		{"م ", 2933},
+		{"ما", 2933},
+		{" ك", 2566},
+		{"و ", 2566},
+		{" أو", 2383},
+		{" ت", 2383},
+		{" ع", 2383},
+		{"أن", 2383},
+		{"أو", 2383},
+		{"في", 2383},
+		{"ق ", 2383},
+		{"وا", 2383},
+		{" في", 2200},
+		{" ل", 2200},
+		{" ي", 2200},
+		{"أو ", 2200},
+		{"ان", 2200},
+		{"ض", 2200},
+		{"ط", 2200},
+		{"في ", 2200},
+		{"لم", 2200},
+		{"ين", 2200},
+		{"أن ", 2016},
+		{"ذ", 2016},
+		{"و ا", 2016},
+		{"ى", 2016},
+		{"ى ", 2016},
+		{"ء", 1833},
+		{"ة ا", 1833},
+		{"تم", 1833},
+		{"عل", 1833},
+		{"كا", 1833},
+		{"لع", 1833},
+		{"لك", 1833},
+		{"نا", 1833},
+		{" ق", 1650},
+		{"أي", 1650},
+		{"إ", 1650},
+		{"اء", 1650},
+		{"الأ", 1650},
+		{"الت", 1650},
+		{"الع", 1650},
+		{"الم", 1650},
+		{"ام", 1650},
+		{"را", 1650},
+		{"ز", 1650},
+		{"ع ", 1650},
+		{"لأ", 1650},
+		{"لت", 1650},
+		{"ما ", 1650},
+		{"من", 1650},
+		{"نت", 1650},
+		{"ي ا", 1650},
+		{" أن", 1466},
+		{" كا", 1466},
+		{" من", 1466},
+		{"ء ", 1466},
+		{"اء ", 1466},
+		{"الح", 1466},
+		{"ب ", 1466},
+		{"بع", 1466},
+		{"ث", 1466},
+		{"سا", 1466},
+		{"ست", 1466},
+		{"عن", 1466},
+		{"لح", 1466},
+		{"لى", 1466},
+		{"لى ", 1466},
+		{"من ", 1466},
+		{" س", 1283},
+		{"ات", 1283},
+		{"ات ", 1283},
+		{"ار", 1283},
+		{"الب", 1283},
+		{"رة", 1283},
+		{"رة ", 1283},
+		{"عد", 1283},
+		{"ف ", 1283},
+		{"كان", 1283},
+		{"كن", 1283},
+		{"لب", 1283},
+		{"مي", 1283},
+		{"وق", 1283},
+		{"ون", 1283},
+		{"ون ", 1283},
+		{"ين ", 1283},
+		{" أي", 1100},
+		{" إ", 1100},
+		{" بع", 1100},
+		{" وا", 1100},
+		{"آ", 1100},
+		{"أي ", 1100},
+		{"اس", 1100},
+		{"ال ", 1100},
+		{"ة أ", 1100},
+		{"ته", 1100},
+		{"تي", 1100},
+		{"دي", 1100},
+		{"س ", 1100},
+		{"قد", 1100},
+		{"كن ", 1100},
+		{"م ا", 1100},
+		{"ن أ", 1100},
+		{"نا ", 1100},
+		{"نت ", 1100},
+		{"ها", 1100},
+		{"وال", 1100},
+		{"ور", 1100},
+		{"وع", 1100},
+		{"يا", 1100},
+		{"يق", 1100},
+		{" آ", 916},
+		{" ج", 916},
+		{" عل", 916},
+		{" عن", 916},
+		{" لك", 916},
+		{"اف", 916},
+		{"اق", 916},
+		{"اك", 916},
+		{"الج", 916},
+		{"ان ", 916},
+		{"انت", 916},
+		{"با", 916},
+		{"بر", 916},
+		{"بعض", 916},
+		{"بي", 916},
+		{"ة م", 916},
+		{"ة و", 916},
+		{"ت ا", 916},
+		{"تن", 916},
+		{"حق", 916},
+		{"خر", 916},
+		{"دا", 916},
+		{"دم", 916},
+		{"رو", 916},
+		{"ظ", 916},
+		{"عا", 916},
+		{"عد ", 916},
+		{"عض", 916},
+		{"على", 916},
+		{"فة", 916},
+		{"فة ", 916},
+		{"فر", 916},
+		{"قد ", 916},
+		{"لا ", 916},
+		{"لج", 916},
+		{"لق", 916},
+		{"ها ", 916},
+		{"هم", 916},
+		{"ور ", 916},
+		{"ى ا", 916},
+		{"ي أ", 916},
+		{"يت", 916},
+		{"ير", 916},
+		{" آخ", 733},
+		{" إل", 733},
+		{" خ", 733},
+		{" ما", 733},
+		{" ه", 733},
+		{" وق", 733},
+		{"آخ", 733},
+		{"آخر", 733},
+		{"أش", 733},
+		{"أم", 733},
+		{"إل", 733},
+		{"ا أ", 733},
+		{"ا ا", 733},
+		{"ا ت", 733},
+		{"ا ف", 733},
+		{"ا ك", 733},
+		{"اج", 733},
+		{"اد", 733},
+		{"است", 733},
+		{"الش", 733},
+		{"الن", 733},
+		{"ام ", 733},
+		{"ب ا", 733},
+		{"ة ب", 733},
+		{"تب", 733},
+		{"تر", 733},
+		{"تس", 733},
+		{"تع", 733},
+		{"تي ", 733},
+		{"جد", 733},
+		{"حت", 733},
+		{"خا", 733},
+		{"د أ", 733},
+		{"د ا", 733},
+		{"دا ", 733},
+		{"ذا", 733},
+		{"رق", 733},
+		{"ري", 733},
+		{"زا", 733},
+		{"سي", 733},
+		{"شخ", 733},
+		{"عام", 733},
+		{"فت", 733},
+		{"قا", 733},
+		{"قي", 733},
+		{"كت", 733},
+		{"كر", 733},
+		{"كل", 733},
+		{"ل ا", 733},
+		{"لة", 733},
+		{"لة ", 733},
+		{"لد", 733},
+		{"لس", 733},
+		{"لش", 733},
+		{"لص", 733},
+		{"لل", 733},
+		{"لن", 733},
+		{"لو", 733},
+		{"مع", 733},
+		{"مو", 733},
+		{"ند", 733},
+		{"نس", 733},
+		{"نو", 733},
+		{"ه ", 733},
+		{"هم ", 733},
+		{"ية", 733},
+		{"ية ", 733},
+		{"يد", 733},
+		{"يع", 733},
+		{"يف", 733},
+		{" اس", 550},
+		{" تن", 550},
+		{" جد", 550},
+		{" ح", 550},
+		{" فت", 550},
+		{" قد", 550},
+		{" لا", 550},
+		{" مع", 550},
+		{" ن", 550},
+		{" هذ", 550},
+		{" وت", 550},
+		{"أت", 550},
+		{"أما", 550},
+		{"إلى", 550},
+		{"إن", 550},
+		{"ا ب", 550},
+		{"ا ع", 550},
+		{"ا م", 550},
+		{"اح", 550},
+		{"اد ", 550},
+		{"اص", 550},
+		{"اع", 550},
+		{"اكن", 550},
+		{"الإ", 550},
+		{"الا", 550},
+		{"الر", 550},
+		{"الس", 550},
+		{"الص", 550},
+		{"الق", 550},
+		{"الك", 550},
+		{"الو", 550},
+		{"بأ", 550},
+		{"بب", 550},
+		{"بعد", 550},
+		{"بل", 550},
+		{"ت ك", 550},
+		{"ت و", 550},
+		{"تح", 550},
+		{"تها", 550},
+		{"جا", 550},
+		{"جت", 550},
+		{"جو", 550},
+		{"ح ", 550},
+		{"حة", 550},
+		{"حة ", 550},
+		{"حر", 550},
+		{"دما", 550},
+		{"دين", 550},
+		{"ذا ", 550},
+		{"ر أ", 550},
+		{"ر ا", 550},
+		{"ر ع", 550},
+		{"رأ", 550},
+		{"را ", 550},
+		{"رد", 550},
+		{"ز ", 550},
+		{"زال", 550},
+		{"سو", 550},
+		{"شي", 550},
+		{"ص ", 550},
+		{"صي", 550},
+		{"ضا", 550},
+		{"ع م", 550},
+		{"عب", 550},
+		{"عن ", 550},
+		{"عند", 550},
+		{"غ", 550},
+		{"ف ا", 550},
+		{"ق ا", 550},
+		{"ق و", 550},
+		{"قر", 550},
+		{"قو", 550},
+		{"كتب", 550},
+		{"لأم", 550},
+		{"لإ", 550},
+		{"لتي", 550},
+		{"لحق", 550},
+		{"لذ", 550},
+		{"لر", 550},
+		{"لعا", 550},
+		{"لف", 550},
+		{"لكل", 550},
+		{"لكن", 550},
+		{"لي", 550},
+		{"م و", 550},
+		{"ماك", 550},
+		{"مج", 550},
+		{"مر", 550},
+		{"مس", 550},
+		{"ن ب", 550},
+		{"ندم", 550},
+		{"ني", 550},
+		{"هذ", 550},
+		{"هذا", 550},
+		{"هر", 550},
+		{"هر ", 550},
+		{"وا ", 550},
+		{"وت", 550},
+		{"وض", 550},
+		{"وق ", 550},
+		{"ول", 550},
+		{"ى م", 550},
+		{"يتم", 550},
+		{"يح", 550},
+		{"يد ", 550},
+		{"يز", 550},
+		{"يع ", 550},
+		{"يق ", 550},
+		{"يو", 550},
+	},
+	NatLangHindi: {
+		{"ा", 27795},
+		{"क", 19943},
+		{"े", 17744},
+		{"र", 17273},
+		{" क", 13505},
+		{"ह", 13347},
+		{"त", 12248},
+		{"्", 11463},
+		{"ि", 11306},
+		{"े ", 11306},
+		{"न", 10521},
+		{"ी", 10521},
+		{"स", 10207},
+		{"ा ", 9893},
+		{"ं", 9422},
+		{"म", 9108},
+		{"ी ", 8322},
+		{"ो", 8322},
+		{"ं ", 7694},
+		{"प", 7537},
+		{"र ", 7537},
+		{"ल", 7223},
+		{" ह", 7066},
+		{" स", 6438},
+		{"य", 6124},
+		{"ज", 5496},
+		{" प", 5339},
+		{"द", 5025},
+		{"ब", 5025},
+		{"ग", 4554},
+		{"ै", 4239},
+		{" म", 4082},
+		{"कि", 3768},
+		{" ब", 3611},
+		{"व", 3611},
+		{"ता", 3454},
+		{" ज", 3297},
+		{" है", 3297},
+		{"च", 3297},
+		{"है", 3297},
+		{"ु", 3297},
+		{"की", 3140},
+		{"की ", 3140},
+		{"त ", 3140},
+		{"़", 3140},
+		{"ार", 3140},
+		{" औ", 2983},
+		{" और", 2983},
+		{"औ", 2983},
+		{"और", 2983},
+		{"और ", 2983},
+		{"या", 2983},
+		{"ें", 2983},
+		{"भ", 2826},
+		{"ि ", 2826},
+		{" कि", 2669},
+		{"को", 2669},
+		{"ख", 2669},
+		{"ें ", 2669},
+		{"ो ", 2669},
+		{"ों", 2669},
+		{"ों ", 2669},
+		{" की", 2512},
+		{"ने", 2512},
+		{"का", 2355},
+		{"के", 2355},
+		{"के ", 2355},
+		{" अ", 2198},
+		{" को", 2198},
+		{" न", 2198},
+		{" ल", 2198},
+		{"अ", 2198},
+		{"ता ", 2198},
+		{"ने ", 2198},
+		{"मे", 2198},
+		{" के", 2041},
+		{" मे", 2041},
+		{"ई", 2041},
+		{"थ", 2041},
+		{"न ", 2041},
+		{"में", 2041},
+		{"े क", 2041},
+		{"्त", 2041},
+		{" द", 1884},
+		{"ई ", 1884},
+		{"ए", 1884},
+		{"ट", 1884},
+		{"है ", 1884},
+		{"ा क", 1884},
+		{"ान", 1884},
+		{"े ह", 1884},
+		{"ै ", 1884},
+		{"्र", 1884},
+		{" भ", 1727},
+		{" व", 1727},
+		{"ं क", 1727},
+		{"कि ", 1727},
+		{"को ", 1727},
+		{"ते", 1727},
+		{"ना", 1727},
+		{"पर", 1727},
+		{"प्", 1727},
+		{"म ", 1727},
+		{"या ", 1727},
+		{"रा", 1727},
+		{"ले", 1727},
+		{"श", 1727},
+		{"्य", 1727},
+		{" च", 1570},
+		{" थ", 1570},
+		{" पर", 1570},
+		{" य", 1570},
+		{" र", 1570},
+		{"चा", 1570},
+		{"ते ", 1570},
+		{"न्", 1570},
+		{"भी", 1570},
+		{"भी ", 1570},
+		{"ल ", 1570},
+		{"स्", 1570},
+		{"हो", 1570},
+		{"ात", 1570},
+		{"े म", 1570},
+		{" आ", 1413},
+		{" का", 1413},
+		{" ख", 1413},
+		{" ग", 1413},
+		{" हो", 1413},
+		{"आ", 1413},
+		{"क्", 1413},
+		{"जा", 1413},
+		{"ना ", 1413},
+		{"मा", 1413},
+		{"र्", 1413},
+		{"ष", 1413},
+		{"सम", 1413},
+		{"से", 1413},
+		{"से ", 1413},
+		{"ह ", 1413},
+		{"हैं", 1413},
+		{"ा ह", 1413},
+		{"ाल", 1413},
+		{"ैं", 1413},
+		{"ैं ", 1413},
+		{" इ", 1256},
+		{" जा", 1256},
+		{" प्", 1256},
+		{" बा", 1256},
+		{" सम", 1256},
+		{" से", 1256},
+		{"इ", 1256},
+		{"ए ", 1256},
+		{"ति", 1256},
+		{"ध", 1256},
+		{"प्र", 1256},
+		{"बा", 1256},
+		{"सा", 1256},
+		{"हा", 1256},
+		{"ाद", 1256},
+		{"ार ", 1256},
+		{"िय", 1256},
+		{" उ", 1099},
+		{" त", 1099},
+		{"ं ब", 1099},
+		{"उ", 1099},
+		{"कर", 1099},
+		{"छ", 1099},
+		{"द ", 1099},
+		{"पर ", 1099},
+		{"र क", 1099},
+		{"रह", 1099},
+		{"रे", 1099},
+		{"ही", 1099},
+		{"ाम", 1099},
+		{"ाह", 1099},
+		{"ि क", 1099},
+		{"ित", 1099},
+		{"ू", 1099},
+		{" या", 942},
+		{" सा", 942},
+		{" स्", 942},
+		{"ँ", 942},
+		{"क ", 942},
+		{"गा", 942},
+		{"ड", 942},
+		{"त स", 942},
+		{"दा", 942},
+		{"भा", 942},
+		{"र प", 942},
+		{"रत", 942},
+		{"रे ", 942},
+		{"ले ", 942},
+		{"हु", 942},
+		{"ाँ", 942},
+		{"ाल ", 942},
+		{"िक", 942},
+		{"ी क", 942},
+		{"ी ह", 942},
+		{"े प", 942},
+		{"्म", 942},
+		{"्या", 942},
+		{" इस", 785},
+		{" कर", 785},
+		{" चा", 785},
+		{" थी", 785},
+		{" ने", 785},
+		{" भी", 785},
+		{" रह", 785},
+		{" ले", 785},
+		{" हम", 785},
+		{"इस", 785},
+		{"कार", 785},
+		{"चाह", 785},
+		{"ज़", 785},
+		{"ण", 785},
+		{"त क", 785},
+		{"ति ", 785},
+		{"त्", 785},
+		{"थी", 785},
+		{"नि", 785},
+		{"फ", 785},
+		{"ब ", 785},
+		{"रो", 785},
+		{"ल क", 785},
+		{"लत", 785},
+		{"लि", 785},
+		{"वा", 785},
+		{"स ", 785},
+		{"सक", 785},
+		{"हम", 785},
+		{"हे", 785},
+		{"होत", 785},
+		{"़ ", 785},
+		{"ा औ", 785},
+		{"ा प", 785},
+		{"ारे", 785},
+		{"िन", 785},
+		{"िस", 785},
+		{"ेक", 785},
+		{"ोत", 785},
+		{" उस", 628},
+		{" घ", 628},
+		{" छ", 628},
+		{" जब", 628},
+		{" दे", 628},
+		{" भा", 628},
+		{" लि", 628},
+		{"ँ ", 628},
+		{"ं औ", 628},
+		{"ं न", 628},
+		{"ं स", 628},
+		{"ंग", 628},
+		{"उस", 628},
+		{"का ", 628},
+		{"किन", 628},
+		{"किस", 628},
+		{"क्ष", 628},
+		{"गा ", 628},
+		{"घ", 628},
+		{"जन", 628},
+		{"जब", 628},
+		{"ठ", 628},
+		{"तर", 628},
+		{"थी ", 628},
+		{"दे", 628},
+		{"धि", 628},
+		{"पत", 628},
+		{"बह", 628},
+		{"य ", 628},
+		{"याद", 628},
+		{"ये", 628},
+		{"यो", 628},
+		{"र स", 628},
+		{"रों", 628},
+		{"लेक", 628},
+		{"व ", 628},
+		{"हि", 628},
+		{"ही ", 628},
+		{"हो ", 628},
+		{"ा च", 628},
+		{"ाँ ", 628},
+		{"ाप", 628},
+		{"ाम ", 628},
+		{"ाय", 628},
+		{"ाव", 628},
+		{"िए", 628},
+		{"िए ", 628},
+		{"िन ", 628},
+		{"िया", 628},
+		{"ी द", 628},
+		{"ी य", 628},
+		{"ीं", 628},
+		{"ीं ", 628},
+		{"े ल", 628},
+		{"े स", 628},
+		{"ै औ", 628},
+		{"ोग", 628},
+		{"्च", 628},
+		{"्त ", 628},
+		{"्रा", 628},
+		{"्ष", 628},
+		{" अध", 471},
+		{" अन", 471},
+		{" उन", 471},
+		{" कई", 471},
+		{" कह", 471},
+		{" ख़", 471},
+		{" गई", 471},
+		{" जग", 471},
+		{" था", 471},
+		{" ध", 471},
+		{" बह", 471},
+		{" मा", 471},
+		{" यह", 471},
+		{" रख", 471},
+		{" वि", 471},
+		{" श", 471},
+		{" सभ", 471},
+		{" हा", 471},
+		{" हु", 471},
+		{"ं ज", 471},
+		{"अध", 471},
+		{"अधि", 471},
+		{"अन", 471},
+		{"अन्", 471},
+		{"इस ", 471},
+		{"उन", 471},
+		{"उसक", 471},
+		{"ए ह", 471},
+		{"कई", 471},
+		{"कई ", 471},
+		{"कह", 471},
+		{"कहा", 471},
+		{"किय", 471},
+		{"ख़", 471},
+		{"खा", 471},
+		{"खे", 471},
+		{"गई", 471},
+		{"गई ", 471},
+		{"गह", 471},
+		{"ग़", 471},
+		{"गे", 471},
+		{"गे ", 471},
+		{"चार", 471},
+		{"जग", 471},
+		{"जगह", 471},
+		{"जब ", 471},
+		{"ट ", 471},
+		{"ट्", 471},
+		{"ड ", 471},
+		{"ड़", 471},
+		{"ण ", 471},
+		{"त प", 471},
+		{"था", 471},
+		{"था ", 471},
+		{"दि", 471},
+		{"धिक", 471},
+		{"न क", 471},
+		{"न्त", 471},
+		{"पह", 471},
+		{"पा", 471},
+		{"प्त", 471},
+		{"फ़", 471},
+		{"बहु", 471},
+		{"बार", 471},
+		{"म स", 471},
+		{"मन", 471},
+		{"मि", 471},
+		{"मु", 471},
+		{"यह", 471},
+		{"यह ", 471},
+		{"यों", 471},
+		{"र अ", 471},
+		{"र च", 471},
+		{"र ह", 471},
+		{"रक", 471},
+		{"रख", 471},
+		{"रता", 471},
+		{"रन", 471},
+		{"रा ", 471},
+		{"राप", 471},
+		{"रि", 471},
+		{"र्च", 471},
+		{"लन", 471},
+		{"लो", 471},
+		{"लोग", 471},
+		{"वि", 471},
+		{"वे", 471},
+		{"शा", 471},
+		{"षा", 471},
+		{"षा ", 471},
+		{"सभ", 471},
+		{"सभी", 471},
+		{"सर", 471},
+		{"साल", 471},
+		{"सी", 471},
+		{"सी ", 471},
+		{"स्व", 471},
+		{"हम ", 471},
+		{"हर", 471},
+		{"हर ", 471},
+		{"हल", 471},
+		{"हाँ", 471},
+		{"हुत", 471},
+		{"हें", 471},
+		{"ा ज", 471},
+		{"ा थ", 471},
+		{"ा म", 471},
+		{"ा स", 471},
+		{"ाए", 471},
+		{"ाज", 471},
+		{"ात ", 471},
+		{"ाद ", 471},
+		{"ादा", 471},
+		{"ान ", 471},
+		{"ाना", 471},
+		{"ाने", 471},
+		{"ाप्", 471},
+		{"ारो", 471},
+		{"ाव ", 471},
+		{"ास", 471},
+		{"ाहि", 471},
+		{"िका", 471},
+		{"ित ", 471},
+		{"िता", 471},
+		{"िल", 471},
+		{"िश", 471},
+		{"िसी", 471},
+		{"ी अ", 471},
+		{"ी ग", 471},
+		{"ी ज", 471},
+		{"ीक", 471},
+		{"ीन", 471},
+		{"ुत", 471},
+		{"ुत ", 471},
+		{"े ख", 471},
+		{"े ब", 471},
+		{"ेकि", 471},
+		{"ेख", 471},
+		{"ै ज", 471},
+		{"ो ज", 471},
+		{"ो ब", 471},
+		{"ोता", 471},
+		{"ौ", 471},
+		{"्क", 471},
+		{"्ट", 471},
+		{"्ति", 471},
+		{"्रत", 471},
+		{"्व", 471},
+	},
+	NatLangIndonesian: {
+		{"a", 63169},
+		{"n", 31049},
+		{"e", 22751},
+		{"i", 21948},
+		{"an", 17799},
+		{"k", 16729},
+		{"u", 16595},
+		{"t", 16059},
+		{"m", 13383},
+		{"r", 13383},
+		{"n ", 12847},
+		{"d", 11375},
+		{"s", 11241},
+		{"g", 11108},
+		{"an ", 10037},
+		{"a ", 9635},
+		{"b", 9368},
+		{"l", 9100},
+		{"h", 8699},
+		{"ng", 8431},
+		{"p", 8297},
+		{"ka", 7226},
+		{" d", 6959},
+		{"i ", 6959},
+		{" m", 5620},
+		{" k", 5487},
+		{"da", 5487},
+		{" s", 5353},
+		{"at", 5353},
+		{"y", 5219},
+		{"er", 4951},
+		{"ang", 4817},
+		{"ta", 4817},
+		{"ya", 4817},
+		{" b", 4684},
+		{"ak", 4684},
+		{"in", 4416},
+		{"ah", 4148},
+		{"ar", 4014},
+		{"g ", 4014},
+		{"la", 4014},
+		{"me", 4014},
+		{"ng ", 4014},
+		{"o", 4014},
+		{"ra", 4014},
+		{" t", 3881},
+		{" p", 3747},
+		{"em", 3747},
+		{"pa", 3747},
+		{"un", 3747},
+		{"di", 3613},
+		{"en", 3479},
+		{" a", 3345},
+		{" me", 3345},
+		{"be", 3345},
+		{"ga", 3345},
+		{"kan", 3345},
+		{"sa", 3345},
+		{"ba", 3211},
+		{"ha", 3211},
+		{"k ", 3211},
+		{"tu", 3211},
+		{" di", 3078},
+		{"as", 3078},
+		{"h ", 3078},
+		{"ke", 3078},
+		{"ma", 3078},
+		{"se", 3078},
+		{"t ", 3078},
+		{" da", 2944},
+		{" ke", 2944},
+		{" se", 2810},
+		{"am", 2676},
+		{"u ", 2676},
+		{"al", 2542},
+		{"j", 2542},
+		{"ny", 2542},
+		{"ap", 2408},
+		{"at ", 2408},
+		{"dan", 2408},
+		{"el", 2408},
+		{" be", 2275},
+		{"ai", 2275},
+		{"nya", 2275},
+		{"te", 2275},
+		{" i", 2141},
+		{"aka", 2141},
+		{"ata", 2141},
+		{"eb", 2141},
+		{"it", 2141},
+		{"n d", 2141},
+		{"na", 2141},
+		{"pe", 2141},
+		{"ran", 2141},
+		{"ti", 2141},
+		{"yan", 2141},
+		{" y", 2007},
+		{" ya", 2007},
+		{"ah ", 2007},
+		{"ber", 2007},
+		{"li", 2007},
+		{"ua", 2007},
+		{"ya ", 2007},
+		{"ak ", 1873},
+		{"bu", 1873},
+		{"mu", 1873},
+		{" h", 1739},
+		{" l", 1739},
+		{" te", 1739},
+		{"a m", 1739},
+		{"ik", 1739},
+		{"n k", 1739},
+		{"nga", 1739},
+		{" ma", 1605},
+		{" pe", 1605},
+		{"apa", 1605},
+		{"in ", 1605},
+		{"s ", 1605},
+		{"si", 1605},
+		{"uk", 1605},
+		{" ba", 1472},
+		{" ha", 1472},
+		{" ka", 1472},
+		{" sa", 1472},
+		{"de", 1472},
+		{"ek", 1472},
+		{"ia", 1472},
+		{"nt", 1472},
+		{"r ", 1472},
+		{"w", 1472},
+		{"wa", 1472},
+		{" o", 1338},
+		{"a k", 1338},
+		{"ela", 1338},
+		{"eng", 1338},
+		{"et", 1338},
+		{"ing", 1338},
+		{"mb", 1338},
+		{"mem", 1338},
+		{"men", 1338},
+		{"n b", 1338},
+		{"or", 1338},
+		{"per", 1338},
+		{"ri", 1338},
+		{"ru", 1338},
+		{"tu ", 1338},
+		{"ud", 1338},
+		{" in", 1204},
+		{" j", 1204},
+		{"di ", 1204},
+		{"emb", 1204},
+		{"gi", 1204},
+		{"i k", 1204},
+		{"ja", 1204},
+		{"lu", 1204},
+		{"m ", 1204},
+		{"mi", 1204},
+		{"n s", 1204},
+		{"ni", 1204},
+		{"ora", 1204},
+		{" at", 1070},
+		{" or", 1070},
+		{" pa", 1070},
+		{"a s", 1070},
+		{"ai ", 1070},
+		{"ala", 1070},
+		{"any", 1070},
+		{"ara", 1070},
+		{"emu", 1070},
+		{"har", 1070},
+		{"ka ", 1070},
+		{"n m", 1070},
+		{"tan", 1070},
+		{"uda", 1070},
+		{"un ", 1070},
+		{"ur", 1070},
+		{" r", 936},
+		{" su", 936},
+		{"a b", 936},
+		{"a p", 936},
+		{"ad", 936},
+		{"ag", 936},
+		{"ain", 936},
+		{"ami", 936},
+		{"ar ", 936},
+		{"ari", 936},
+		{"asa", 936},
+		{"asi", 936},
+		{"au", 936},
+		{"bah", 936},
+		{"ebe", 936},
+		{"g b", 936},
+		{"ih", 936},
+		{"itu", 936},
+		{"ko", 936},
+		{"l ", 936},
+		{"n a", 936},
+		{"n t", 936},
+		{"na ", 936},
+		{"pat", 936},
+		{"re", 936},
+		{"su", 936},
+		{"uka", 936},
+		{"ul", 936},
+		{"ung", 936},
+		{" de", 802},
+		{" la", 802},
+		{" u", 802},
+		{"a a", 802},
+		{"ana", 802},
+		{"as ", 802},
+		{"bi", 802},
+		{"c", 802},
+		{"eba", 802},
+		{"eka", 802},
+		{"g d", 802},
+		{"gan", 802},
+		{"gat", 802},
+		{"hak", 802},
+		{"hu", 802},
+		{"ian", 802},
+		{"is", 802},
+		{"k a", 802},
+		{"k m", 802},
+		{"kam", 802},
+		{"kat", 802},
+		{"ku", 802},
+		{"lah", 802},
+		{"lai", 802},
+		{"lam", 802},
+		{"mer", 802},
+		{"mi ", 802},
+		{"n p", 802},
+		{"nd", 802},
+		{"ngi", 802},
+		{"ntu", 802},
+		{"pa ", 802},
+		{"ri ", 802},
+		{"rt", 802},
+		{"seb", 802},
+		{"sem", 802},
+		{"tah", 802},
+		{"ua ", 802},
+		{"um", 802},
+		{" ak", 669},
+		{" ap", 669},
+		{" it", 669},
+		{" ta", 669},
+		{" ti", 669},
+		{"ada", 669},
+		{"aga", 669},
+		{"aha", 669},
+		{"ahu", 669},
+		{"al ", 669},
+		{"am ", 669},
+		{"ama", 669},
+		{"beb", 669},
+		{"ca", 669},
+		{"da ", 669},
+		{"dak", 669},
+		{"dit", 669},
+		{"du", 669},
+		{"e ", 669},
+		{"eh", 669},
+		{"era", 669},
+		{"ert", 669},
+		{"eti", 669},
+		{"gg", 669},
+		{"gin", 669},
+		{"gu", 669},
+		{"hi", 669},
+		{"i d", 669},
+		{"i m", 669},
+		{"i s", 669},
+		{"ih ", 669},
+		{"ika", 669},
+		{"il", 669},
+		{"ir", 669},
+		{"je", 669},
+		{"l d", 669},
+		{"le", 669},
+		{"mas", 669},
+		{"mba", 669},
+		{"mp", 669},
+		{"n h", 669},
+		{"n y", 669},
+		{"ngg", 669},
+		{"ol", 669},
+		{"pu", 669},
+		{"tas", 669},
+		{"tem", 669},
+		{"ter", 669},
+		{"u d", 669},
+		{"unt", 669},
+		{" bu", 535},
+		{" ja", 535},
+		{" je", 535},
+		{" ko", 535},
+		{" mu", 535},
+		{" tu", 535},
+		{" un", 535},
+		{" w", 535},
+		{" wa", 535},
+		{"a d", 535},
+		{"a h", 535},
+		{"a i", 535},
+		{"aa", 535},
+		{"aan", 535},
+		{"ahw", 535},
+		{"ali", 535},
+		{"au ", 535},
+		{"bag", 535},
+		{"dah", 535},
+		{"dar", 535},
+		{"dek", 535},
+		{"elu", 535},
+		{"eme", 535},
+		{"emp", 535},
+		{"ena", 535},
+		{"end", 535},
+		{"ep", 535},
+		{"erh", 535},
+		{"es", 535},
+		{"eta", 535},
+		{"gai", 535},
+		{"gga", 535},
+		{"h d", 535},
+		{"h m", 535},
+		{"hun", 535},
+		{"hw", 535},
+		{"hwa", 535},
+		{"i i", 535},
+		{"i p", 535},
+		{"i t", 535},
+		{"ib", 535},
+		{"id", 535},
+		{"ik ", 535},
+		{"is ", 535},
+		{"keb", 535},
+		{"kel", 535},
+		{"lan", 535},
+		{"lit", 535},
+		{"ma ", 535},
+		{"mbu", 535},
+		{"mpa", 535},
+		{"mua", 535},
+		{"nak", 535},
+		{"ne", 535},
+		{"ni ", 535},
+		{"nj", 535},
+		{"nta", 535},
+		{"ok", 535},
+		{"p ", 535},
+		{"pen", 535},
+		{"pi", 535},
+		{"pi ", 535},
+		{"pun", 535},
+		{"r t", 535},
+		{"rap", 535},
+		{"rg", 535},
+		{"rh", 535},
+		{"rha", 535},
+		{"rn", 535},
+		{"rta", 535},
+		{"run", 535},
+		{"sam", 535},
+		{"san", 535},
+		{"sih", 535},
+		{"ta ", 535},
+		{"tap", 535},
+		{"tau", 535},
+		{"ti ", 535},
+		{"tik", 535},
+		{"tuk", 535},
+		{"uk ", 535},
+		{"uli", 535},
+		{"up", 535},
+		{"ura", 535},
+		{"ut", 535},
+		{"wa ", 535},
+		{" an", 401},
+		{" lu", 401},
+		{" po", 401},
+		{" pu", 401},
+		{" ru", 401},
+		{"a o", 401},
+		{"ap ", 401},
+		{"api", 401},
+		{"aru", 401},
+		{"atu", 401},
+		{"ay", 401},
+		{"aya", 401},
+		{"ban", 401},
+		{"bel", 401},
+		{"bua", 401},
+		{"buk", 401},
+		{"bur", 401},
+		{"dap", 401},
+		{"dip", 401},
+		{"ed", 401},
+		{"eh ", 401},
+		{"eli", 401},
+		{"ere", 401},
+		{"erg", 401},
+		{"ern", 401},
+		{"esa", 401},
+		{"g l", 401},
+		{"g t", 401},
+		{"gal", 401},
+		{"gi ", 401},
+		{"gk", 401},
+		{"gun", 401},
+		{"h s", 401},
+		{"has", 401},
+		{"hir", 401},
+		{"i b", 401},
+		{"i r", 401},
+		{"ibu", 401},
+		{"ini", 401},
+		{"iny", 401},
+		{"ip", 401},
+		{"ite", 401},
+		{"jan", 401},
+		{"jen", 401},
+		{"ji", 401},
+		{"kah", 401},
+		{"kar", 401},
+		{"kem", 401},
+		{"ket", 401},
+		{"ki", 401},
+		{"las", 401},
+		{"leh", 401},
+		{"lua", 401},
+		{"man", 401},
+		{"mel", 401},
+		{"muk", 401},
+		{"n j", 401},
+		{"nan", 401},
+		{"nda", 401},
+		{"ngk", 401},
+		{"ngu", 401},
+		{"ole", 401},
+		{"pad", 401},
+		{"pak", 401},
+		{"pan", 401},
+		{"po", 401},
+		{"rek", 401},
+		{"ren", 401},
+		{"sa ", 401},
+		{"sal", 401},
+		{"sel", 401},
+		{"si ", 401},
+		{"sud", 401},
+		{"t d", 401},
+		{"t p", 401},
+		{"t s", 401},
+		{"t y", 401},
+		{"tet", 401},
+		{"tin", 401},
+		{"u s", 401},
+		{"uan", 401},
+		{"uar", 401},
+		{"um ", 401},
+		{"una", 401},
+		{"us", 401},
+	},
+	NatLangBengali: {
+		{"া", 31260},
+		{"ে", 26078},
+		{"র", 22902},
+		{"ন", 15212},
+		{"ি", 15212},
+		{"ক", 14710},
+		{"ব", 13707},
+		{"্", 13540},
+		{"ে ", 13373},
+		{"য", 11534},
+		{"ত", 10197},
+		{"ল", 9027},
+		{"র ", 8525},
+		{"া ", 8024},
+		{"ম", 7355},
+		{" ক", 7021},
+		{"়", 7021},
+		{"প", 6352},
+		{"স", 6185},
+		{"য়", 5850},
+		{" ব", 5182},
+		{"ার", 5182},
+		{"ো", 5015},
+		{" প", 4680},
+		{"খ", 4680},
+		{"ু", 4680},
+		{"ছ", 4513},
+		{" স", 4346},
+		{"জ", 4346},
+		{" এ", 4179},
+		{"এ", 4179},
+		{"দ", 4179},
+		{"ন ", 4179},
+		{"গ", 4012},
+		{"কা", 3677},
+		{"রা", 3677},
+		{"হ", 3677},
+		{"ের", 3677},
+		{"ি ", 3510},
+		{" আ", 3343},
+		{"আ", 3343},
+		{"রে", 3343},
+		{"ার ", 3343},
+		{"ভ", 3176},
+		{"ট", 3009},
+		{"ধ", 3009},
+		{"়ে", 3009},
+		{"ের ", 3009},
+		{" ন", 2841},
+		{" হ", 2841},
+		{"বা", 2841},
+		{"বে", 2841},
+		{"ী", 2841},
+		{"্য", 2841},
+		{" অ", 2674},
+		{" য", 2674},
+		{"ং", 2674},
+		{"অ", 2674},
+		{"ই", 2674},
+		{"চ", 2674},
+		{"ষ", 2674},
+		{"ং ", 2507},
+		{"ন্", 2507},
+		{"য়ে", 2507},
+		{"্র", 2507},
+		{"ই ", 2340},
+		{"রা ", 2340},
+		{" এব", 2173},
+		{" ম", 2173},
+		{"এব", 2173},
+		{"এবং", 2173},
+		{"কে", 2173},
+		{"বং", 2173},
+		{"বং ", 2173},
+		{"রে ", 2173},
+		{"শ", 2173},
+		{"িক", 2173},
+		{"ও", 2006},
+		{"ভা", 2006},
+		{"য় ", 2006},
+		{"লে", 2006},
+		{"় ", 2006},
+		{"ান", 2006},
+		{" কর", 1838},
+		{" জ", 1838},
+		{"কর", 1838},
+		{"তা", 1838},
+		{"ত্", 1838},
+		{"থ", 1838},
+		{"নি", 1838},
+		{"পর", 1838},
+		{"বে ", 1838},
+		{"ল ", 1838},
+		{"াব", 1838},
+		{"িল", 1838},
+		{" কা", 1671},
+		{" খ", 1671},
+		{" ত", 1671},
+		{" ভ", 1671},
+		{"ছি", 1671},
+		{"ছিল", 1671},
+		{"ড", 1671},
+		{"দে", 1671},
+		{"মা", 1671},
+		{"যা", 1671},
+		{"যে", 1671},
+		{"়ে ", 1671},
+		{"াম", 1671},
+		{"্ত", 1671},
+		{" নি", 1504},
+		{" পর", 1504},
+		{" বা", 1504},
+		{"জা", 1504},
+		{"টি", 1504},
+		{"ত ", 1504},
+		{"তি", 1504},
+		{"তে", 1504},
+		{"লো", 1504},
+		{"িয", 1504},
+		{"িয়", 1504},
+		{"ুল", 1504},
+		{"ে প", 1504},
+		{"েক", 1504},
+		{" ছ", 1337},
+		{" দ", 1337},
+		{"উ", 1337},
+		{"ও ", 1337},
+		{"কার", 1337},
+		{"খন", 1337},
+		{"ছে", 1337},
+		{"টি ", 1337},
+		{"থা", 1337},
+		{"না", 1337},
+		{"নে", 1337},
+		{"পা", 1337},
+		{"ম ", 1337},
+		{"র্", 1337},
+		{"লা", 1337},
+		{"সম", 1337},
+		{"াজ", 1337},
+		{"েছ", 1337},
+		{"েন", 1337},
+		{"েন ", 1337},
+		{"ো ", 1337},
+		{" অন", 1170},
+		{" প্", 1170},
+		{" যে", 1170},
+		{" র", 1170},
+		{" সম", 1170},
+		{"ঁ", 1170},
+		{"অন", 1170},
+		{"ক ", 1170},
+		{"কে ", 1170},
+		{"ঠ", 1170},
+		{"ড়", 1170},
+		{"ণ", 1170},
+		{"তে ", 1170},
+		{"প্", 1170},
+		{"প্র", 1170},
+		{"স্", 1170},
+		{"া ক", 1170},
+		{"ায", 1170},
+		{"ায়", 1170},
+		{"িল ", 1170},
+		{"ে এ", 1170},
+		{"ে ক", 1170},
+		{"ে ব", 1170},
+		{" কো", 1003},
+		{" চ", 1003},
+		{" জা", 1003},
+		{" তা", 1003},
+		{" পা", 1003},
+		{" হয", 1003},
+		{"কো", 1003},
+		{"খা", 1003},
+		{"তি ", 1003},
+		{"দের", 1003},
+		{"ন য", 1003},
+		{"না ", 1003},
+		{"নিয", 1003},
+		{"বি", 1003},
+		{"মর", 1003},
+		{"র ক", 1003},
+		{"র প", 1003},
+		{"লো ", 1003},
+		{"সে", 1003},
+		{"হয", 1003},
+		{"হয়", 1003},
+		{"াঁ", 1003},
+		{"াগ", 1003},
+		{"াত", 1003},
+		{"াবে", 1003},
+		{"াম ", 1003},
+		{"ারে", 1003},
+		{"াল", 1003},
+		{"িত", 1003},
+		{"ির", 1003},
+		{"ে স", 1003},
+		{"েখ", 1003},
+		{"্ব", 1003},
+		{" অধ", 835},
+		{" আম", 835},
+		{" উ", 835},
+		{" গ", 835},
+		{" ছি", 835},
+		{" ভা", 835},
+		{" যা", 835},
+		{" ল", 835},
+		{" শ", 835},
+		{" স্", 835},
+		{"অধ", 835},
+		{"আম", 835},
+		{"করে", 835},
+		{"কি", 835},
+		{"ক্", 835},
+		{"গা", 835},
+		{"চি", 835},
+		{"ছে ", 835},
+		{"জন", 835},
+		{"ঠি", 835},
+		{"ধি", 835},
+		{"ন্য", 835},
+		{"ফ", 835},
+		{"ভাব", 835},
+		{"র স", 835},
+		{"রের", 835},
+		{"লাম", 835},
+		{"ষ্", 835},
+		{"হা", 835},
+		{"াই", 835},
+		{"াদ", 835},
+		{"িত ", 835},
+		{"িন", 835},
+		{"ুলো", 835},
+		{"ে চ", 835},
+		{"ে য", 835},
+		{"্ম", 835},
+		{"্যা", 835},
+		{" কি", 668},
+		{" খু", 668},
+		{" ধ", 668},
+		{" পড", 668},
+		{" মন", 668},
+		{" মা", 668},
+		{" রা", 668},
+		{" হা", 668},
+		{"অধি", 668},
+		{"অন্", 668},
+		{"ওয", 668},
+		{"ওয়", 668},
+		{"করা", 668},
+		{"ক্ষ", 668},
+		{"খন ", 668},
+		{"খনও", 668},
+		{"খু", 668},
+		{"গু", 668},
+		{"গুল", 668},
+		{"গে", 668},
+		{"গ্", 668},
+		{"জে", 668},
+		{"টা", 668},
+		{"ণ ", 668},
+		{"তা ", 668},
+		{"তু", 668},
+		{"দা", 668},
+		{"দি", 668},
+		{"ধিক", 668},
+		{"ধী", 668},
+		{"ধীন", 668},
+		{"নও", 668},
+		{"নও ", 668},
+		{"নত", 668},
+		{"নো", 668},
+		{"ন্ত", 668},
+		{"পড", 668},
+		{"পড়", 668},
+		{"পত", 668},
+		{"পত্", 668},
+		{"পরি", 668},
+		{"পরে", 668},
+		{"ব ", 668},
+		{"বা ", 668},
+		{"বার", 668},
+		{"মন", 668},
+		{"মরা", 668},
+		{"মান", 668},
+		{"যে ", 668},
+		{"র অ", 668},
+		{"র খ", 668},
+		{"রক", 668},
+		{"রত", 668},
+		{"রি", 668},
+		{"র্য", 668},
+		{"লেন", 668},
+		{"শে", 668},
+		{"়া", 668},
+		{"়েছ", 668},
+		{"া ব", 668},
+		{"া স", 668},
+		{"াই ", 668},
+		{"াতে", 668},
+		{"াধ", 668},
+		{"িকা", 668},
+		{"ীন", 668},
+		{"ে আ", 668},
+		{"ে হ", 668},
+		{"েই", 668},
+		{"েই ", 668},
+		{"েছি", 668},
+		{"্তি", 668},
+		{"্ধ", 668},
+		{"্রত", 668},
+		{"্ষ", 668},
+		{" আগ", 501},
+		{" আর", 501},
+		{" এক", 501},
+		{" এখ", 501},
+		{" কথ", 501},
+		{" খা", 501},
+		{" চি", 501},
+		{" জন", 501},
+		{" থ", 501},
+		{" ধর", 501},
+		{" না", 501},
+		{" ফ", 501},
+		{" বছ", 501},
+		{" বল", 501},
+		{" বি", 501},
+		{" যখ", 501},
+		{"ং ক", 501},
+		{"ং ব", 501},
+		{"আগ", 501},
+		{"আমর", 501},
+		{"আর", 501},
+		{"এক", 501},
+		{"এখ", 501},
+		{"এখন", 501},
+		{"ক ব", 501},
+		{"কথ", 501},
+		{"কথা", 501},
+		{"কাল", 501},
+		{"কের", 501},
+		{"খে", 501},
+		{"গে ", 501},
+		{"গ্র", 501},
+		{"চন", 501},
+		{"চনা", 501},
+		{"ছর", 501},
+		{"ছর ", 501},
+		{"জ ", 501},
+		{"জন্", 501},
+		{"জায", 501},
+		{"জার", 501},
+		{"ড়ে", 501},
+		{"ণ ক", 501},
+		{"তার", 501},
+		{"ত্ত", 501},
+		{"থা ", 501},
+		{"দ্", 501},
+		{"ধ ", 501},
+		{"ধর", 501},
+		{"ন ম", 501},
+		{"নতা", 501},
+		{"নু", 501},
+		{"নুষ", 501},
+		{"নে ", 501},
+		{"বছ", 501},
+		{"বছর", 501},
+		{"বন", 501},
+		{"বর", 501},
+		{"বল", 501},
+		{"বস", 501},
+		{"বাধ", 501},
+		{"মে", 501},
+		{"যখ", 501},
+		{"যখন", 501},
+		{"য়গ", 501},
+		{"য়া", 501},
+		{"যাব", 501},
+		{"র আ", 501},
+		{"র জ", 501},
+		{"র ল", 501},
+		{"রই", 501},
+		{"রই ", 501},
+		{"রো", 501},
+		{"লল", 501},
+		{"লে ", 501},
+		{"লেখ", 501},
+		{"লোচ", 501},
+		{"শি", 501},
+		{"শেষ", 501},
+		{"ষ ", 501},
+		{"ষণ", 501},
+		{"ষা", 501},
+		{"সে ", 501},
+		{"স্ব", 501},
+		{"় ব", 501},
+		{"়গ", 501},
+		{"়গা", 501},
+		{"া এ", 501},
+		{"া ত", 501},
+		{"া ন", 501},
+		{"া হ", 501},
+		{"াক", 501},
+		{"াখ", 501},
+		{"াজা", 501},
+		{"াধী", 501},
+		{"ান ", 501},
+		{"িক ", 501},
+		{"িকে", 501},
+		{"িন্", 501},
+		{"িব", 501},
+		{"ির ", 501},
+		{"িলে", 501},
+		{"িশ", 501},
+		{"ী ", 501},
+		{"ীনত", 501},
+		{"ীর", 501},
+		{"ু ", 501},
+		{"ুদ", 501},
+		{"ুষ", 501},
+		{"ৃ", 501},
+		{"ে অ", 501},
+		{"ে খ", 501},
+		{"ে গ", 501},
+		{"ে ত", 501},
+		{"ে ন", 501},
+		{"ে র", 501},
+		{"েক ", 501},
+		{"েকে", 501},
+		{"েছে", 501},
+		{"েরই", 501},
+		{"েষ", 501},
+		{"োচ", 501},
+		{"োচন", 501},
+		{"োন", 501},
+		{"্ক", 501},
+		{"্প", 501},
+		{"্বা", 501},
+		{"্যে", 501},
+	},
+	NatLangRussian: {
+		{"о", 35884},
+		{"а", 22546},
+		{"и", 22388},
+		{"е", 21752},
+		{"н", 19530},
+		{"т", 17942},
+		{"д", 12702},
+		{"л", 12702},
+		{"с", 12702},
+		{"р", 12384},
+		{"м", 10161},
+		{"в", 9844},
+		{"и ", 9844},
+		{"к", 9526},
+		{"у", 8415},
+		{"о ", 7939},
+		{"ы", 7939},
+		{"п", 7780},
+		{"а ", 7462},
+		{"г", 6510},
+		{"б", 5874},
+		{"ь", 5874},
+		{"я", 5716},
+		{" н", 5557},
+		{" п", 5557},
+		{" д", 4445},
+		{" и", 4445},
+		{" с", 4445},
+		{"е ", 4445},
+		{"ж", 4445},
+		{"ч", 4445},
+		{"то", 4287},
+		{"на", 4128},
+		{"ы ", 3969},
+		{" в", 3810},
+		{" о", 3810},
+		{"з", 3810},
+		{"ко", 3651},
+		{"ли", 3651},
+		{"но", 3651},
+		{"ст", 3651},
+		{"ни", 3493},
+		{"по", 3334},
+		{"ь ", 3334},
+		{" к", 3175},
+		{"ен", 3175},
+		{"од", 3175},
+		{"ол", 3175},
+		{"ро", 3175},
+		{" б", 3016},
+		{"ра", 3016},
+		{"пр", 2858},
+		{"я ", 2858},
+		{" на", 2699},
+		{" ч", 2699},
+		{"й", 2699},
+		{" и ", 2540},
+		{" по", 2540},
+		{"го", 2540},
+		{"ли ", 2540},
+		{"ог", 2540},
+		{" пр", 2381},
+		{"да", 2381},
+		{"ть", 2381},
+		{"х", 2381},
+		{"ю", 2381},
+		{" м", 2222},
+		{" р", 2222},
+		{"во", 2222},
+		{"ов", 2222},
+		{"то ", 2222},
+		{"ве", 2064},
+		{"до", 2064},
+		{"й ", 2064},
+		{"ка", 2064},
+		{"т ", 2064},
+		{"те", 2064},
+		{"ть ", 2064},
+		{"ш", 2064},
+		{" т", 1905},
+		{" чт", 1905},
+		{"ал", 1905},
+		{"де", 1905},
+		{"ел", 1905},
+		{"ет", 1905},
+		{"ла", 1905},
+		{"м ", 1905},
+		{"ми", 1905},
+		{"не", 1905},
+		{"ны", 1905},
+		{"ом", 1905},
+		{"чт", 1905},
+		{"ё", 1905},
+		{"ат", 1746},
+		{"в ", 1746},
+		{"ес", 1746},
+		{"ль", 1746},
+		{"на ", 1746},
+		{"об", 1746},
+		{"ц", 1746},
+		{"что", 1746},
+		{" до", 1587},
+		{" з", 1587},
+		{" ко", 1587},
+		{"го ", 1587},
+		{"ло", 1587},
+		{"ми ", 1587},
+		{"ож", 1587},
+		{"ор", 1587},
+		{"от", 1587},
+		{"про", 1587},
+		{"со", 1587},
+		{"та", 1587},
+		{" в ", 1429},
+		{" со", 1429},
+		{"ам", 1429},
+		{"бы", 1429},
+		{"да ", 1429},
+		{"ер", 1429},
+		{"за", 1429},
+		{"ил", 1429},
+		{"ис", 1429},
+		{"ме", 1429},
+		{"ого", 1429},
+		{"ос", 1429},
+		{"ру", 1429},
+		{"у ", 1429},
+		{"щ", 1429},
+		{" е", 1270},
+		{" не", 1270},
+		{"бо", 1270},
+		{"дн", 1270},
+		{"ду", 1270},
+		{"ем", 1270},
+		{"ени", 1270},
+		{"жд", 1270},
+		{"ие", 1270},
+		{"ин", 1270},
+		{"мо", 1270},
+		{"но ", 1270},
+		{"оль", 1270},
+		{"он", 1270},
+		{"ры", 1270},
+		{"ся", 1270},
+		{"че", 1270},
+		{" др", 1111},
+		{" за", 1111},
+		{" ка", 1111},
+		{" ра", 1111},
+		{" у", 1111},
+		{"ать", 1111},
+		{"др", 1111},
+		{"же", 1111},
+		{"или", 1111},
+		{"к ", 1111},
+		{"ле", 1111},
+		{"ма", 1111},
+		{"о д", 1111},
+		{"ове", 1111},
+		{"ом ", 1111},
+		{"хо", 1111},
+		{"ю ", 1111},
+		{"ят", 1111},
+		{"ё ", 1111},
+		{" бы", 952},
+		{" г", 952},
+		{" л", 952},
+		{"аз", 952},
+		{"ак", 952},
+		{"али", 952},
+		{"ва", 952},
+		{"ги", 952},
+		{"дру", 952},
+		{"ест", 952},
+		{"ет ", 952},
+		{"и н", 952},
+		{"и с", 952},
+		{"и ч", 952},
+		{"ик", 952},
+		{"им", 952},
+		{"ит", 952},
+		{"ла ", 952},
+		{"ни ", 952},
+		{"ног", 952},
+		{"ны ", 952},
+		{"о н", 952},
+		{"о п", 952},
+		{"ок", 952},
+		{"ост", 952},
+		{"пол", 952},
+		{"ре", 952},
+		{"руг", 952},
+		{"се", 952},
+		{"сп", 952},
+		{"ся ", 952},
+		{"ти", 952},
+		{"ты", 952},
+		{"уг", 952},
+		{"ую", 952},
+		{"х ", 952},
+		{" де", 793},
+		{" ме", 793},
+		{" то", 793},
+		{"ав", 793},
+		{"бе", 793},
+		{"гд", 793},
+		{"дол", 793},
+		{"ек", 793},
+		{"жи", 793},
+		{"жн", 793},
+		{"и в", 793},
+		{"и и", 793},
+		{"и п", 793},
+		{"ие ", 793},
+		{"ког", 793},
+		{"му", 793},
+		{"ну", 793},
+		{"о с", 793},
+		{"ово", 793},
+		{"ой", 793},
+		{"ри", 793},
+		{"ров", 793},
+		{"ск", 793},
+		{"ста", 793},
+		{"ств", 793},
+		{"тв", 793},
+		{"тел", 793},
+		{"тс", 793},
+		{"ты ", 793},
+		{"уд", 793},
+		{"уж", 793},
+		{"ум", 793},
+		{"ци", 793},
+		{"ы п", 793},
+		{"ыл", 793},
+		{"я в", 793},
+		{" а", 635},
+		{" вс", 635},
+		{" вы", 635},
+		{" го", 635},
+		{" ил", 635},
+		{" но", 635},
+		{" о ", 635},
+		{" он", 635},
+		{" от", 635},
+		{" св", 635},
+		{" х", 635},
+		{" хо", 635},
+		{"а в", 635},
+		{"а д", 635},
+		{"а и", 635},
+		{"а н", 635},
+		{"а п", 635},
+		{"аб", 635},
+		{"ами", 635},
+		{"ар", 635},
+		{"ас", 635},
+		{"аю", 635},
+		{"бы ", 635},
+		{"был", 635},
+		{"вс", 635},
+		{"вы", 635},
+		{"га", 635},
+		{"гда", 635},
+		{"год", 635},
+		{"ди", 635},
+		{"ев", 635},
+		{"ег", 635},
+		{"ей", 635},
+		{"ей ", 635},
+		{"ещ", 635},
+		{"жен", 635},
+		{"и д", 635},
+		{"и о", 635},
+		{"и р", 635},
+		{"ии", 635},
+		{"ии ", 635},
+		{"ия", 635},
+		{"ия ", 635},
+		{"ку", 635},
+		{"лж", 635},
+		{"лов", 635},
+		{"льн", 635},
+		{"мес", 635},
+		{"му ", 635},
+		{"не ", 635},
+		{"ник", 635},
+		{"нь", 635},
+		{"о т", 635},
+		{"огд", 635},
+		{"ода", 635},
+		{"одн", 635},
+		{"ожд", 635},
+		{"оже", 635},
+		{"ои", 635},
+		{"ой ", 635},
+		{"олж", 635},
+		{"ош", 635},
+		{"пи", 635},
+		{"пис", 635},
+		{"пра", 635},
+		{"рав", 635},
+		{"род", 635},
+		{"св", 635},
+		{"сво", 635},
+		{"сем", 635},
+		{"сл", 635},
+		{"спо", 635},
+		{"сто", 635},
+		{"та ", 635},
+		{"те ", 635},
+		{"тор", 635},
+		{"тся", 635},
+		{"ше", 635},
+		{"ый", 635},
+		{"ый ", 635},
+		{"ь в", 635},
+		{"ь н", 635},
+		{"ьн", 635},
+		{"ьно", 635},
+		{"ют", 635},
+		{" а ", 476},
+		{" бе", 476},
+		{" бо", 476},
+		{" ещ", 476},
+		{" её", 476},
+		{" ин", 476},
+		{" ле", 476},
+		{" мо", 476},
+		{" мы", 476},
+		{" ни", 476},
+		{" ну", 476},
+		{" об", 476},
+		{" ро", 476},
+		{" се", 476},
+		{" сл", 476},
+		{" сп", 476},
+		{" ст", 476},
+		{" че", 476},
+		{" я", 476},
+		{"а к", 476},
+		{"а м", 476},
+		{"а с", 476},
+		{"аг", 476},
+		{"ад", 476},
+		{"аж", 476},
+		{"ажд", 476},
+		{"ай", 476},
+		{"ап", 476},
+		{"ах", 476},
+		{"ац", 476},
+		{"аци", 476},
+		{"бл", 476},
+		{"бод", 476},
+		{"бол", 476},
+		{"бр", 476},
+		{"бра", 476},
+		{"бу", 476},
+		{"вн", 476},
+		{"во ", 476},
+		{"воб", 476},
+		{"все", 476},
+		{"г ", 476},
+		{"га ", 476},
+		{"гр", 476},
+		{"ден", 476},
+		{"дом", 476},
+		{"ду ", 476},
+		{"ды", 476},
+		{"дый", 476},
+		{"дя", 476},
+		{"дят", 476},
+		{"е д", 476},
+		{"е з", 476},
+		{"е и", 476},
+		{"е о", 476},
+		{"ед", 476},
+		{"ели", 476},
+		{"ень", 476},
+		{"еск", 476},
+		{"ещё", 476},
+		{"её", 476},
+		{"её ", 476},
+		{"жа", 476},
+		{"жды", 476},
+		{"за ", 476},
+		{"зн", 476},
+		{"и е", 476},
+		{"и к", 476},
+		{"иг", 476},
+		{"ись", 476},
+		{"ич", 476},
+		{"йт", 476},
+		{"ка ", 476},
+		{"каж", 476},
+		{"как", 476},
+		{"ки", 476},
+		{"кн", 476},
+		{"ко ", 476},
+		{"кол", 476},
+		{"кр", 476},
+		{"кры", 476},
+		{"л ", 476},
+		{"ло ", 476},
+		{"льш", 476},
+		{"ля", 476},
+		{"мен", 476},
+		{"мы", 476},
+		{"мы ", 476},
+		{"н ", 476},
+		{"ние", 476},
+		{"нии", 476},
+		{"нн", 476},
+		{"нт", 476},
+		{"нуж", 476},
+		{"ным", 476},
+		{"нь ", 476},
+		{"ня", 476},
+		{"о б", 476},
+		{"о и", 476},
+		{"о р", 476},
+		{"обо", 476},
+		{"оду", 476},
+		{"одя", 476},
+		{"ое", 476},
+		{"оло", 476},
+		{"они", 476},
+		{"оп", 476},
+		{"оро", 476},
+		{"оры", 476},
+		{"отк", 476},
+		{"раз", 476},
+		{"рат", 476},
+		{"рн", 476},
+		{"рог", 476},
+		{"ря", 476},
+		{"са", 476},
+		{"ско", 476},
+		{"сть", 476},
+		{"сь", 476},
+		{"тал", 476},
+		{"тве", 476},
+		{"ти ", 476},
+		{"тк", 476},
+		{"тр", 476},
+		{"тя", 476},
+		{"ужн", 476},
+		{"ума", 476},
+		{"уч", 476},
+		{"ую ", 476},
+		{"ча", 476},
+		{"чен", 476},
+		{"чи", 476},
+		{"ши", 476},
+		{"ще", 476},
+		{"щё", 476},
+		{"щё ", 476},
+		{"ы б", 476},
+		{"ые", 476},
+		{"ые ", 476},
+		{"ым", 476},
+		{"ыми", 476},
+		{"ь п", 476},
+		{"ьш", 476},
+		{"ю н", 476},
+		{"юд", 476},
+		{"я е", 476},
+		{"ян", 476},
+		{"ят ", 476},
+	},
+	NatLangJapanese: {
+		{"の", 12709},
+		{"し", 12374},
+		{"い", 11705},
+		{"は", 11371},
+		{"に", 11036},
+		{"た", 10367},
+		{"ま", 10033},
+		{"て", 9364},
+		{"る", 8695},
+		{"と", 7692},
+		{"を", 7692},
+		{"が", 7357},
+		{"す", 7023},
+		{"で", 6354},
+		{"か", 6020},
+		{"した", 5351},
+		{"な", 5016},
+		{"こ", 4682},
+		{"も", 4682},
+		{"まし", 4347},
+		{"ました", 4347},
+		{"した ", 4013},
+		{"た ", 4013},
+		{"いま", 3678},
+		{"は ", 3678},
+		{"く", 3344},
+		{"れ", 3344},
+		{"人", 3344},
+		{"き", 3010},
+		{"して", 3010},
+		{"っ", 3010},
+		{"り", 3010},
+		{"いまし", 2675},
+		{"こと", 2675},
+		{"する", 2675},
+		{"てい", 2675},
+		{"ます", 2675},
+		{"す ", 2341},
+		{"ていま", 2341},
+		{"ど", 2341},
+		{"ます ", 2341},
+		{"そ", 2006},
+		{"ち", 2006},
+		{"って", 2006},
+		{"つ", 2006},
+		{"よ", 2006},
+		{"るこ", 2006},
+		{"ること", 2006},
+		{"ん", 2006},
+		{"いて", 1672},
+		{"う", 1672},
+		{"け", 1672},
+		{"たち", 1672},
+		{"べ", 1672},
+		{"何", 1672},
+		{"場", 1672},
+		{"行", 1672},
+		{"見", 1672},
+		{"間", 1672},
+		{"あ", 1337},
+		{"お", 1337},
+		{"く ", 1337},
+		{"すべ", 1337},
+		{"すべて", 1337},
+		{"たちは", 1337},
+		{"だ", 1337},
+		{"ちは", 1337},
+		{"で ", 1337},
+		{"とを", 1337},
+		{"ので", 1337},
+		{"べて", 1337},
+		{"ら", 1337},
+		{"り ", 1337},
+		{"ト", 1337},
+		{"ン", 1337},
+		{"ー", 1337},
+		{"日", 1337},
+		{"由", 1337},
+		{"自", 1337},
+		{"言", 1337},
+		{" す", 1003},
+		{" すべ", 1003},
+		{" ほ", 1003},
+		{" 何", 1003},
+		{"い ", 1003},
+		{"いた", 1003},
+		{"います", 1003},
+		{"え", 1003},
+		{"かの", 1003},
+		{"かを", 1003},
+		{"が ", 1003},
+		{"がで", 1003},
+		{"ができ", 1003},
+		{"きる", 1003},
+		{"ことが", 1003},
+		{"しく", 1003},
+		{"してい", 1003},
+		{"しま", 1003},
+		{"しまし", 1003},
+		{"たが", 1003},
+		{"たの", 1003},
+		{"ってい", 1003},
+		{"つい", 1003},
+		{"ついて", 1003},
+		{"て ", 1003},
+		{"でき", 1003},
+		{"できる", 1003},
+		{"とが", 1003},
+		{"とがで", 1003},
+		{"ない", 1003},
+		{"にし", 1003},
+		{"にして", 1003},
+		{"につ", 1003},
+		{"につい", 1003},
+		{"ので ", 1003},
+		{"の人", 1003},
+		{"ば", 1003},
+		{"ほ", 1003},
+		{"まだ", 1003},
+		{"や", 1003},
+		{"よう", 1003},
+		{"りま", 1003},
+		{"る ", 1003},
+		{"ると", 1003},
+		{"るの", 1003},
+		{"わ", 1003},
+		{"ド", 1003},
+		{"利", 1003},
+		{"場所", 1003},
+		{"家", 1003},
+		{"市", 1003},
+		{"年", 1003},
+		{"所", 1003},
+		{"権", 1003},
+		{"権利", 1003},
+		{"者", 1003},
+		{"自由", 1003},
+	},
+}
@@ -0,0 +1,97 @@
+package detect
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Natural and programming language identification work the same way: a
+// profile holds the frequencies of a language's most common n-grams (or
+// tokens), and a text is scored by the average log probability of its
+// n-grams under each profile. The helpers here are shared by both.
+
+// rankNGrams turns n-gram counts out of total into frequencies and returns
+// the most frequent, at most top, ordered by decreasing frequency (ties by
+// text). N-grams counted fewer than minCount times are left out.
+func rankNGrams(counts map[string]int, total, top, minCount int) []NGram {
+	grams := make([]NGram, 0, len(counts))
+	for g, n := range counts {
+		if n < minCount {
+			continue
+		}
+		grams = append(grams, NGram{Text: g, PPM: int(int64(n) * 1000000 / int64(total))})
+	}
+	sort.Slice(grams, func(i, j int) bool {
+		if grams[i].PPM != grams[j].PPM {
+			return grams[i].PPM > grams[j].PPM
+		}
+		return grams[i].Text < grams[j].Text
+	})
+	if len(grams) > top {
+		grams = grams[:top]
+	}
+	return grams
+}
+
+// logProbTable maps every profiled n-gram to its log probability in each
+// of a list of profiles. It is built once, on first use.
+type logProbTable struct {
+	unseen   float64          // log probability of an n-gram a profile lacks
+	profiles func() [][]NGram // the profiles, in language order
+
+	once  sync.Once
+	table map[string][]float64
+}
+
+// get returns the table, building it on the first call.
+func (t *logProbTable) get() map[string][]float64 {
+	t.once.Do(func() {
+		profiles := t.profiles()
+		t.table = make(map[string][]float64)
+		for i, profile := range profiles {
+			for _, g := range profile {
+				probs, ok := t.table[g.Text]
+				if !ok {
+					probs = make([]float64, len(profiles))
+					for j := range probs {
+						probs[j] = t.unseen
+					}
+					t.table[g.Text] = probs
+				}
+				probs[i] = math.Log(float64(g.PPM) / 1e6)
+			}
+		}
+	})
+	return t.table
+}
+
+// weighEvidence turns log probabilities summed over total n-grams into
+// their average, scaled by the evidence the text provides: total n-grams,
+// but at most maxEvidence, as neighbouring n-grams are not independent.
+func weighEvidence(sums []float64, total, maxEvidence int) {
+	if total == 0 {
+		return
+	}
+	evidence := float64(min(total, maxEvidence))
+	for j := range sums {
+		sums[j] = sums[j] / float64(total) * evidence
+	}
+}
+
+// softmax normalises log-odds scores, in place, into probabilities summing
+// to 1.
+func softmax(scores []float64) {
+	best := math.Inf(-1)
+	for _, s := range scores {
+		best = math.Max(best, s)
+	}
+	var norm float64
+	for j := range scores {
+		scores[j] = math.Exp(scores[j] - best)
+		norm += scores[j]
+	}
+	for j := range scores {
+		scores[j] /= norm
+	}
+}
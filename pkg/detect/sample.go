@@ -0,0 +1,328 @@
+package detect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SampleMode selects the parts of a file that are analysed.
+type SampleMode int
+
+const (
+	SampleWindows SampleMode = iota // windows at the start, middle and end
+	SampleHead                      // the start of the file
+	SampleStride                    // chunks spread evenly over the file
+	SampleFull                      // the whole file
+)
+
+func (m SampleMode) String() string {
+	names := []string{"windows", "head", "stride", "full"}
+	if int(m) < len(names) {
+		return names[m]
+	}
+	return "unknown"
+}
+
+const (
+	// DefaultSampleSize is the number of bytes sampled from a file, unless
+	// Sampling.Size says otherwise. Smaller files are analysed whole.
+	DefaultSampleSize = 8192
+
+	// DefaultChunkSize is the size of the chunks of SampleStride.
+	DefaultChunkSize = 512
+)
+
+// Sampling selects the parts of files larger than its size that are
+// analysed:
+//
+//   - SampleWindows: three windows of a third of the size each, at the
+//     start, in the middle and at the end. The middle window starts at the
+//     largest multiple of the window size by a power of two that keeps it
+//     in the first half, so that a Detector can find it in a stream.
+//   - SampleHead: the first Size bytes.
+//   - SampleStride: chunks of Chunk bytes at multiples of a stride: the
+//     chunk size, doubled until at most Size/Chunk chunks fit.
+//   - SampleFull: everything.
+type Sampling struct {
+	Mode  SampleMode
+	Size  int // bytes sampled; 0 means DefaultSampleSize
+	Chunk int // chunk size of SampleStride; 0 means DefaultChunkSize
+}
+
+// DefaultSampling is the sampling Detect uses.
+var DefaultSampling = Sampling{Mode: SampleWindows}
+
+// ParseSampling reads a sampling written as its mode (windows, head,
+// stride or full), optionally followed by a colon and the sample size in
+// bytes: "head:65536".
+func ParseSampling(str string) (Sampling, error) {
+	name, size, hasSize := strings.Cut(str, ":")
+	var s Sampling
+	for mode := SampleWindows; mode <= SampleFull; mode++ {
+		if name == mode.String() {
+			s.Mode = mode
+			break
+		}
+		if mode == SampleFull {
+			return s, fmt.Errorf("detect: unknown sampling mode %q", name)
+		}
+	}
+	if hasSize {
+		n, err := strconv.Atoi(size)
+		if err != nil || n <= 0 {
+			return s, fmt.Errorf("detect: bad sample size %q", size)
+		}
+		s.Size = n
+	}
+	return s, nil
+}
+
+// size returns the number of bytes sampled.
+func (s Sampling) size() int {
+	switch {
+	case s.Mode == SampleFull:
+		return math.MaxInt
+	case s.Size <= 0:
+		return DefaultSampleSize
+	case s.Size < 3:
+		// Room for three windows
+		return 3
+	}
+	return s.Size
+}
+
+// chunk returns the chunk size of SampleStride.
+func (s Sampling) chunk() int {
+	chunk := s.Chunk
+	if chunk <= 0 {
+		chunk = DefaultChunkSize
+	}
+	return min(chunk, s.size())
+}
+
+// windows returns the sampled parts of data, the start of the file first.
+func (s Sampling) windows(data []byte) [][]byte {
+	size, n := s.size(), len(data)
+	if n <= size {
+		return [][]byte{data}
+	}
+
+	switch s.Mode {
+	case SampleWindows:
+		w := size / 3
+		m := middleWindow(n, w)
+		return [][]byte{data[:w], data[m : m+w], data[n-w:]}
+	case SampleStride:
+		chunk := s.chunk()
+		stride := chunk
+		for (n+stride-1)/stride > size/chunk {
+			stride *= 2
+		}
+		var windows [][]byte
+		for off := 0; off < n; off += stride {
+			windows = append(windows, data[off:min(off+chunk, n)])
+		}
+		return windows
+	default:
+		return [][]byte{data[:size]}
+	}
+}
+
+// middleWindow returns the offset of the middle window of size w in n > 3w
+// bytes: the largest w·2^k that does not put the window past the middle.
+func middleWindow(n, w int) int {
+	m := w
+	for 2*m <= (n-w)/2 {
+		m *= 2
+	}
+	return m
+}
+
+// minCSVLines is the number of lines with the same number of commas that
+// make a table.
+const minCSVLines = 5
+
+// isCSVBody reports whether the sample after the start of the file holds a
+// table, as when a CSV file has a prose preamble: one of the later windows,
+// or the second half of a file analysed whole, has only lines with the same
+// number of commas. Partial lines at the edges of windows are ignored.
+func isCSVBody(windows [][]byte) bool {
+	parts := windows[1:]
+	if len(windows) == 1 {
+		parts = [][]byte{windows[0][len(windows[0])/2:]}
+	}
+
+	for _, part := range parts {
+		lines := bytes.Split(part, []byte("\n"))
+		if len(lines) < minCSVLines+2 {
+			continue
+		}
+		lines = lines[1 : len(lines)-1]
+		commas := bytes.Count(lines[0], []byte(","))
+		table := commas > 0
+		for _, line := range lines {
+			if bytes.Count(line, []byte(",")) != commas || bytes.ContainsAny(line, "{};") {
+				table = false
+				break
+			}
+		}
+		if table {
+			return true
+		}
+	}
+	return false
+}
+
+// window is a sampled part of a stream.
+type window struct {
+	off  int
+	data []byte
+}
+
+// capture appends to w what p, written at offset start, holds of the n
+// bytes from w.off.
+func (w *window) capture(n, start int, p []byte) {
+	lo, hi := max(w.off, start), min(w.off+n, start+len(p))
+	if lo < hi {
+		w.data = append(w.data, p[lo-start:hi-start]...)
+	}
+}
+
+// Detector detects the type of data written to it in chunks, keeping only
+// the samples its Sampling selects: a stream is profiled as DetectWith
+// profiles the same bytes in one slice. The one difference is that a
+// stream larger than the sample size has its programming language
+// classified from the samples, and mixed content is not segmented.
+type Detector struct {
+	sampling Sampling
+	size     int
+	n        int // bytes written
+
+	head    window   // the first size bytes
+	windows []window // middle windows or strided chunks
+	tail    []byte   // last window with SampleWindows
+	next    int      // offset of the next middle window or chunk
+	stride  int      // stride with SampleStride
+}
+
+// NewDetector returns a Detector sampling as s says.
+func NewDetector(s Sampling) *Detector {
+	d := &Detector{sampling: s, size: s.size()}
+	switch s.Mode {
+	case SampleWindows:
+		d.next = d.size / 3
+	case SampleStride:
+		d.stride = s.chunk()
+	}
+	return d
+}
+
+// Write samples p. It never fails.
+func (d *Detector) Write(p []byte) (int, error) {
+	start := d.n
+	d.n += len(p)
+	d.head.capture(d.size, start, p)
+
+	switch d.sampling.Mode {
+	case SampleWindows:
+		w := d.size / 3
+		// Start a window at each w·2^k reached; middleWindow picks one of
+		// the last three
+		for d.next < d.n {
+			d.windows = append(d.windows, window{off: d.next})
+			if len(d.windows) > 3 {
+				d.windows = d.windows[1:]
+			}
+			d.next *= 2
+		}
+		for i := range d.windows {
+			d.windows[i].capture(w, start, p)
+		}
+
+		d.tail = append(d.tail, p[max(len(p)-w, 0):]...)
+		if len(d.tail) > w {
+			d.tail = append(d.tail[:0], d.tail[len(d.tail)-w:]...)
+		}
+
+	case SampleStride:
+		chunk := d.sampling.chunk()
+		maxChunks := max(d.size/chunk, 1)
+		for d.next < d.n {
+			if len(d.windows) == maxChunks {
+				// Double the stride, keeping the chunks at its multiples
+				d.stride *= 2
+				kept := d.windows[:0]
+				for _, c := range d.windows {
+					if c.off%d.stride == 0 {
+						kept = append(kept, c)
+					}
+				}
+				d.windows = kept
+				if d.next%d.stride != 0 {
+					d.next += d.stride / 2
+					continue
+				}
+			}
+			d.windows = append(d.windows, window{off: d.next})
+			d.next += d.stride
+		}
+		for i := len(d.windows) - 1; i >= 0 && d.windows[i].off+chunk > start; i-- {
+			d.windows[i].capture(chunk, start, p)
+		}
+	}
+	return len(p), nil
+}
+
+// ReadFrom samples r until EOF.
+func (d *Detector) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, 32*1024)
+	var total int64
+	for {
+		n, err := r.Read(buf)
+		d.Write(buf[:n])
+		total += int64(n)
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// Len returns the number of bytes written.
+func (d *Detector) Len() int {
+	return d.n
+}
+
+// Profile returns the profile of the data written so far.
+func (d *Detector) Profile() Profile {
+	if d.n <= d.size {
+		return analyze([][]byte{d.head.data}, d.head.data)
+	}
+
+	switch d.sampling.Mode {
+	case SampleWindows:
+		w := d.size / 3
+		m := middleWindow(d.n, w)
+		windows := [][]byte{d.head.data[:w], nil, d.tail}
+		for _, mid := range d.windows {
+			if mid.off == m {
+				windows[1] = mid.data
+			}
+		}
+		return analyze(windows, nil)
+	case SampleStride:
+		var windows [][]byte
+		for _, c := range d.windows {
+			windows = append(windows, c.data)
+		}
+		return analyze(windows, nil)
+	default:
+		return analyze([][]byte{d.head.data}, nil)
+	}
+}
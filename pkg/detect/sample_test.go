@@ -0,0 +1,209 @@
+package detect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSamplingWindows(t *testing.T) {
+	data := make([]byte, 100000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	testCases := []struct {
+		sampling Sampling
+		count    int   // number of windows
+		offsets  []int // offsets of the first windows
+		lengths  []int
+	}{
+		{Sampling{Mode: SampleHead}, 1, []int{0}, []int{8192}},
+		{Sampling{Mode: SampleFull}, 1, []int{0}, []int{100000}},
+		// Windows of 2730 bytes; the middle one at 2730·16 <= (100000-2730)/2
+		{Sampling{}, 3, []int{0, 43680, 100000 - 2730}, []int{2730, 2730, 2730}},
+		{Sampling{Mode: SampleWindows, Size: 300}, 3, []int{0, 25600, 99900}, []int{100, 100, 100}},
+		// At most 16 chunks of 512 bytes: the stride doubles from 512 to 8192
+		{Sampling{Mode: SampleStride}, 13, []int{0, 8192, 16384}, []int{512, 512, 512}},
+		{Sampling{Mode: SampleStride, Size: 1000, Chunk: 400}, 2, []int{0, 51200}, []int{400, 400}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v/%d/%d", tc.sampling.Mode, tc.sampling.Size, tc.sampling.Chunk), func(t *testing.T) {
+			windows := tc.sampling.windows(data)
+			if len(windows) != tc.count {
+				t.Fatalf("got %d windows, want %d", len(windows), tc.count)
+			}
+			for i, off := range tc.offsets {
+				if !bytes.Equal(windows[i], data[off:off+tc.lengths[i]]) {
+					t.Errorf("window %d: want %d bytes at %d", i, tc.lengths[i], off)
+				}
+			}
+		})
+	}
+
+	// Small files are analysed whole
+	for _, mode := range []SampleMode{SampleWindows, SampleHead, SampleStride} {
+		if windows := (Sampling{Mode: mode}).windows(data[:5000]); len(windows) != 1 || len(windows[0]) != 5000 {
+			t.Errorf("%v: small file not whole", mode)
+		}
+	}
+}
+
+func TestDetector(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; buf.Len() < 200000; i++ {
+		fmt.Fprintf(&buf, "func f%d(x int) int {\n\treturn x * %d\n}\n\n", i, i)
+		if i%50 == 0 {
+			fmt.Fprintf(&buf, "// Section %d describes the next functions in plain words.\n", i)
+		}
+	}
+	large := buf.Bytes()
+
+	samplings := []Sampling{
+		{},
+		{Mode: SampleHead},
+		{Mode: SampleStride},
+		{Mode: SampleStride, Size: 1000, Chunk: 300},
+		{Mode: SampleWindows, Size: 999},
+		{Mode: SampleFull},
+	}
+	for _, s := range samplings {
+		for _, n := range []int{0, 100, 8192, 20000, 65536, len(large)} {
+			for _, step := range []int{1, 777, 1 << 20} {
+				if step == 1 && n > 20000 {
+					continue
+				}
+				t.Run(fmt.Sprintf("%v/%d/%d/%d", s.Mode, s.Size, n, step), func(t *testing.T) {
+					data := large[:n]
+					d := NewDetector(s)
+					for i := 0; i < n; i += step {
+						d.Write(data[i:min(i+step, n)])
+					}
+					if d.Len() != n {
+						t.Fatalf("Len: got %d, want %d", d.Len(), n)
+					}
+
+					want := DetectWith(data, s)
+					if n > s.size() {
+						want = analyze(s.windows(data), nil)
+					}
+					if got := d.Profile(); !reflect.DeepEqual(got, want) {
+						t.Errorf("got %+v\nwant %+v", got, want)
+					}
+				})
+			}
+		}
+	}
+
+	// ReadFrom
+	d := NewDetector(Sampling{})
+	if n, err := d.ReadFrom(bytes.NewReader(large)); err != nil || n != int64(len(large)) {
+		t.Fatalf("ReadFrom: %d, %v", n, err)
+	}
+	if got := d.Profile(); got.Type != TypeCode || got.Language != CodeLangGo {
+		t.Errorf("ReadFrom: got %v %v, want Go code", got.Type, got.Language)
+	}
+}
+
+// withLicence returns source starting with a licence header in line
+// comments of the given number of lines, followed by three times as much
+// code: header, then definitions written by def until it is long enough.
+func withLicence(lines int, header string, def func(w io.Writer, i int)) string {
+	licence := strings.Repeat("// Permission is hereby granted, free of charge, to any person obtaining\n"+
+		"// a copy of this software, to deal in the Software without restriction.\n", lines/2)
+	var code strings.Builder
+	code.WriteString(licence + "\n" + header)
+	for i := 0; code.Len() < 3*len(licence); i++ {
+		def(&code, i)
+	}
+	return code.String()
+}
+
+func goHandler(w io.Writer, i int) {
+	fmt.Fprintf(w, "func handle%d(w http.ResponseWriter, r *http.Request) {\n"+
+		"\tif err := r.ParseForm(); err != nil {\n\t\thttp.Error(w, err.Error(), 400)\n\t\treturn\n\t}\n"+
+		"\tfmt.Fprintf(w, \"%%s\", r.Form.Get(\"q\"))\n}\n\n", i)
+}
+
+func jsHandler(w io.Writer, i int) {
+	fmt.Fprintf(w, "export async function handle%d(req, res) {\n"+
+		"  const items = await fetchItems(req.query.q);\n"+
+		"  if (!items.length) {\n    return res.status(404).json({ error: 'not found' });\n  }\n"+
+		"  res.json(items.map((item) => ({ id: item.id, name: item.name })));\n}\n\n", i)
+}
+
+func TestDetectLargeFiles(t *testing.T) {
+	goHeader := "package server\n\nimport (\n\t\"fmt\"\n\t\"net/http\"\n)\n\n"
+	jsHeader := "import { fetchItems } from './store.js';\n\n"
+
+	preamble := strings.Repeat("This file lists the readings taken at each station during the survey.\n"+
+		"Values are in degrees Celsius, and missing readings are left empty.\n"+
+		"Stations were visited daily, weather permitting, by one of three teams.\n", 60)
+	var table strings.Builder
+	table.WriteString(preamble + "station,date,min,max\n")
+	for i := 0; table.Len() < 2*len(preamble); i++ {
+		fmt.Fprintf(&table, "S%03d,2024-01-%02d,%d.5,%d.0\n", i%40, i%28+1, i%10, i%10+12)
+	}
+
+	testCases := []struct {
+		name     string
+		data     string
+		wantType Type
+		wantLang CodeLang
+		wantFmt  DataFormat
+	}{
+		{"Go with a licence header", withLicence(160, goHeader, goHandler), TypeCode, CodeLangGo, DataFormatNone},
+		{"JavaScript with a licence header over 64 KB", withLicence(960, jsHeader, jsHandler), TypeCode, CodeLangJavaScript, DataFormatNone},
+		{"CSV with a preamble", table.String(), TypeCode, CodeLangUnknown, DataFormatCSV},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if len(tc.data) <= DefaultSampleSize {
+				t.Fatalf("test data too small: %d bytes", len(tc.data))
+			}
+			profile := Detect([]byte(tc.data))
+			if profile.Type != tc.wantType || profile.Language != tc.wantLang || profile.DataFmt != tc.wantFmt {
+				t.Errorf("got %v/%v/%v, want %v/%v/%v", profile.Type, profile.Language, profile.DataFmt,
+					tc.wantType, tc.wantLang, tc.wantFmt)
+			}
+
+			// The head alone misses it
+			head := DetectWith([]byte(tc.data), Sampling{Mode: SampleHead})
+			if head.Type != TypeText {
+				t.Errorf("head sampling: got %v, want text", head.Type)
+			}
+		})
+	}
+}
+
+func TestParseSampling(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Sampling
+		err  bool
+	}{
+		{"windows", Sampling{Mode: SampleWindows}, false},
+		{"head", Sampling{Mode: SampleHead}, false},
+		{"stride:65536", Sampling{Mode: SampleStride, Size: 65536}, false},
+		{"full", Sampling{Mode: SampleFull}, false},
+		{"middle", Sampling{}, true},
+		{"head:", Sampling{}, true},
+		{"head:-1", Sampling{}, true},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseSampling(tc.in)
+		if (err != nil) != tc.err {
+			t.Errorf("ParseSampling(%q): error %v", tc.in, err)
+			continue
+		}
+		if err == nil && got != tc.want {
+			t.Errorf("ParseSampling(%q): got %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package detect
+
+import "bytes"
+
+// Segment is a region of a file holding a single kind of content: markup,
+// code in one language, or structured data.
+type Segment struct {
+	Offset   int
+	Length   int
+	Type     Type       // TypeText for markup and prose, TypeCode for code and data
+	Language CodeLang   // language of a code region, if known
+	DataFmt  DataFormat // format of a data region
+	Markup   MarkupLang // format of a markup region
+}
+
+// minSegment is the length below which a region is merged into the one
+// before it; switching vocabulary for a few bytes costs more than it saves.
+const minSegment = 64
+
+// Segments splits files that mix several kinds of content into regions:
+// Markdown into prose and fenced code blocks, HTML into markup, scripts
+// and PHP blocks, and Jupyter notebooks into JSON and the source of code
+// cells. It returns nil for other files and for files with only one
+// region.
+func Segments(data []byte) []Segment {
+	sample := data
+	if len(sample) > DefaultSampleSize {
+		sample = sample[:DefaultSampleSize]
+	}
+
+	var segs []Segment
+	switch {
+	case isNotebook(sample):
+		segs = notebookSegments(data)
+	case detectMarkup(sample) == MarkupMarkdown:
+		segs = markdownSegments(data)
+	case detectMarkup(sample) == MarkupHTML:
+		segs = htmlSegments(data)
+	}
+	segs = mergeSegments(segs)
+	if len(segs) < 2 {
+		return nil
+	}
+	return segs
+}
+
+// segmenter collects regions of special content, filling the gaps between
+// them with the base kind of the file.
+type segmenter struct {
+	base Segment
+	segs []Segment
+	end  int // end of the last region added
+}
+
+// add records data[start:end] as region s.
+func (sg *segmenter) add(start, end int, s Segment) {
+	if start >= end || start < sg.end {
+		return
+	}
+	if start > sg.end {
+		sg.fill(start)
+	}
+	s.Offset, s.Length = start, end-start
+	sg.segs = append(sg.segs, s)
+	sg.end = end
+}
+
+// fill extends the base kind up to end.
+func (sg *segmenter) fill(end int) []Segment {
+	if end > sg.end {
+		s := sg.base
+		s.Offset, s.Length = sg.end, end-sg.end
+		sg.segs = append(sg.segs, s)
+		sg.end = end
+	}
+	return sg.segs
+}
+
+// mergeSegments joins neighbouring regions of the same kind, and merges
+// regions shorter than minSegment into the region before them (or, at the
+// start of the file, after them).
+func mergeSegments(segs []Segment) []Segment {
+	var out []Segment
+	for _, s := range segs {
+		if n := len(out); n > 0 && (s.Length < minSegment || sameKind(out[n-1], s)) {
+			out[n-1].Length += s.Length
+			continue
+		}
+		out = append(out, s)
+	}
+	if len(out) > 1 && out[0].Length < minSegment {
+		out[1].Length += out[1].Offset
+		out[1].Offset = 0
+		out = out[1:]
+	}
+	return out
+}
+
+func sameKind(a, b Segment) bool {
+	return a.Type == b.Type && a.Language == b.Language && a.DataFmt == b.DataFmt && a.Markup == b.Markup
+}
+
+// codeSegment returns the segment for a block of code in lang, guessing
+// the language from the content if lang is unknown.
+func codeSegment(code []byte, lang CodeLang) Segment {
+	if lang == CodeLangUnknown {
+		lang, _, _ = detectLanguage(DefaultSampling.windows(code)...)
+	}
+	return Segment{Type: TypeCode, Language: lang}
+}
+
+// markdownSegments finds fenced code blocks (``` or ~~~). The language
+// comes from the info string ("```go", "~~~ {.python}") or the content.
+// An unclosed fence runs to the end of the file, as in CommonMark.
+func markdownSegments(data []byte) []Segment {
+	sg := segmenter{base: Segment{Type: TypeText, Markup: MarkupMarkdown}}
+
+	var fence []byte // opening fence while inside a block
+	var lang CodeLang
+	codeStart := 0
+	for pos := 0; pos < len(data); {
+		end := bytes.IndexByte(data[pos:], '\n')
+		if end < 0 {
+			end = len(data)
+		} else {
+			end += pos + 1
+		}
+		line := data[pos:end]
+		trimmed := bytes.TrimLeft(line, " ")
+
+		switch {
+		case fence == nil && len(line)-len(trimmed) <= 3:
+			if f := fenceMarker(trimmed); f != nil {
+				fence = f
+				info := bytes.Fields(bytes.Trim(bytes.TrimSpace(trimmed[len(f):]), "{}"))
+				lang = CodeLangUnknown
+				if len(info) > 0 {
+					lang = codeLangName(bytes.TrimLeft(info[0], "."))
+				}
+				codeStart = end
+			}
+		case fence != nil && len(line)-len(trimmed) <= 3:
+			f := fenceMarker(trimmed)
+			if f != nil && f[0] == fence[0] && len(f) >= len(fence) && len(bytes.TrimSpace(trimmed[len(f):])) == 0 {
+				sg.add(codeStart, pos, codeSegment(data[codeStart:pos], lang))
+				fence = nil
+			}
+		}
+		pos = end
+	}
+	if fence != nil {
+		sg.add(codeStart, len(data), codeSegment(data[codeStart:], lang))
+	}
+	return sg.fill(len(data))
+}
+
+// fenceMarker returns the run of three or more backticks or tildes that
+// line starts with, or nil.
+func fenceMarker(line []byte) []byte {
+	if len(line) < 3 || (line[0] != '`' && line[0] != '~') {
+		return nil
+	}
+	n := 0
+	for n < len(line) && line[n] == line[0] {
+		n++
+	}
+	if n < 3 {
+		return nil
+	}
+	// A backtick fence's info string cannot contain backticks
+	if line[0] == '`' && bytes.IndexByte(line[n:], '`') >= 0 {
+		return nil
+	}
+	return line[:n]
+}
+
+// htmlSegments finds <script> elements and PHP blocks. Scripts whose type
+// mentions JSON are JSON data; scripts of types other than JavaScript
+// (templates, for instance) are left as markup.
+func htmlSegments(data []byte) []Segment {
+	sg := segmenter{base: Segment{Type: TypeText, Markup: MarkupHTML}}
+	lower := bytes.ToLower(data)
+
+	for pos := 0; pos < len(data); {
+		script := bytes.Index(lower[pos:], []byte("<script"))
+		php := bytes.Index(lower[pos:], []byte("<?php"))
+		if script < 0 && php < 0 {
+			break
+		}
+
+		if php >= 0 && (script < 0 || php < script) {
+			start := pos + php
+			end := bytes.Index(data[start:], []byte("?>"))
+			if end < 0 {
+				end = len(data)
+			} else {
+				end += start + 2
+			}
+			sg.add(start, end, Segment{Type: TypeCode, Language: CodeLangPHP})
+			pos = end
+			continue
+		}
+
+		start := pos + script
+		tagEnd := bytes.IndexByte(data[start:], '>')
+		if tagEnd < 0 {
+			break
+		}
+		tagEnd += start + 1
+		end := bytes.Index(lower[tagEnd:], []byte("</script"))
+		if end < 0 {
+			end = len(data)
+		} else {
+			end += tagEnd
+		}
+
+		typ := scriptType(lower[start:tagEnd])
+		switch {
+		case bytes.Contains(typ, []byte("json")):
+			sg.add(tagEnd, end, Segment{Type: TypeCode, DataFmt: DataFormatJSON})
+		case len(typ) == 0 || bytes.Contains(typ, []byte("javascript")) ||
+			bytes.Equal(typ, []byte("module")) || bytes.Contains(typ, []byte("ecmascript")):
+			sg.add(tagEnd, end, Segment{Type: TypeCode, Language: CodeLangJavaScript})
+		}
+		pos = end
+	}
+	return sg.fill(len(data))
+}
+
+// scriptType returns the value of the type attribute of a lower-cased
+// <script> tag, or nil.
+func scriptType(tag []byte) []byte {
+	for i := 0; ; {
+		j := bytes.Index(tag[i:], []byte("type"))
+		if j < 0 {
+			return nil
+		}
+		i += j + 4
+		// Only a whole attribute name counts ("data-type" does not)
+		if prev := tag[i-5]; prev != ' ' && prev != '\t' && prev != '\n' {
+			continue
+		}
+		rest := bytes.TrimLeft(tag[i:], " \t\n")
+		if len(rest) == 0 || rest[0] != '=' {
+			continue
+		}
+		rest = bytes.TrimLeft(rest[1:], " \t\n")
+		if len(rest) > 0 && (rest[0] == '"' || rest[0] == '\'') {
+			if k := bytes.IndexByte(rest[1:], rest[0]); k >= 0 {
+				return bytes.TrimSpace(rest[1 : k+1])
+			}
+			return nil
+		}
+		if k := bytes.IndexAny(rest, " \t\n>"); k >= 0 {
+			return rest[:k]
+		}
+		return rest
+	}
+}
+
+// isNotebook reports whether data looks like a Jupyter notebook.
+func isNotebook(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{' &&
+		bytes.Contains(data, []byte(`"cells"`)) && bytes.Contains(data, []byte(`"nbformat"`))
+}
+
+// notebookSegments finds the source of each code cell in a Jupyter
+// notebook. The language is the kernel's, from the notebook metadata.
+func notebookSegments(data []byte) []Segment {
+	s := jsonScanner{data: data}
+	var cells [][2]int
+	var lang CodeLang
+
+	ok := s.object(func(key []byte) bool {
+		switch string(key) {
+		case "cells":
+			return s.array(func() bool {
+				var cellType []byte
+				var source [2]int
+				ok := s.object(func(key []byte) bool {
+					switch string(key) {
+					case "cell_type":
+						var ok bool
+						cellType, ok = s.str()
+						return ok
+					case "source":
+						source[0] = s.pos
+						ok := s.value()
+						source[1] = s.pos
+						return ok
+					}
+					return s.value()
+				})
+				if ok && string(cellType) == "code" {
+					cells = append(cells, source)
+				}
+				return ok
+			})
+		case "metadata":
+			return s.object(func(key []byte) bool {
+				switch string(key) {
+				case "kernelspec":
+					return s.object(func(key []byte) bool {
+						if string(key) == "language" {
+							name, ok := s.str()
+							if l := codeLangName(name); l != CodeLangUnknown {
+								lang = l
+							}
+							return ok
+						}
+						return s.value()
+					})
+				case "language_info":
+					return s.object(func(key []byte) bool {
+						if string(key) == "name" {
+							name, ok := s.str()
+							if l := codeLangName(name); l != CodeLangUnknown && lang == CodeLangUnknown {
+								lang = l
+							}
+							return ok
+						}
+						return s.value()
+					})
+				}
+				return s.value()
+			})
+		}
+		return s.value()
+	})
+	if !ok {
+		return nil
+	}
+
+	sg := segmenter{base: Segment{Type: TypeCode, DataFmt: DataFormatJSON}}
+	for _, cell := range cells {
+		sg.add(cell[0], cell[1], Segment{Type: TypeCode, Language: lang})
+	}
+	return sg.fill(len(data))
+}
+
+// jsonScanner walks JSON without decoding it, so that callers can find
+// the byte offsets of values.
+type jsonScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\r', '\n':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek skips white space and returns the next byte, or 0 at the end.
+func (s *jsonScanner) peek() byte {
+	s.skipSpace()
+	if s.pos >= len(s.data) {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+// object reads an object, calling member with the raw key of each member
+// and the scanner positioned at its value, which member must consume.
+func (s *jsonScanner) object(member func(key []byte) bool) bool {
+	if s.peek() != '{' {
+		return false
+	}
+	s.pos++
+	if s.peek() == '}' {
+		s.pos++
+		return true
+	}
+	for {
+		key, ok := s.str()
+		if !ok || s.peek() != ':' {
+			return false
+		}
+		s.pos++
+		if !member(key) {
+			return false
+		}
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case '}':
+			s.pos++
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// array reads an array, calling elem with the scanner positioned at each
+// element, which elem must consume.
+func (s *jsonScanner) array(elem func() bool) bool {
+	if s.peek() != '[' {
+		return false
+	}
+	s.pos++
+	if s.peek() == ']' {
+		s.pos++
+		return true
+	}
+	for {
+		if !elem() {
+			return false
+		}
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case ']':
+			s.pos++
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// str reads a string and returns its raw contents, escapes included.
+func (s *jsonScanner) str() ([]byte, bool) {
+	if s.peek() != '"' {
+		return nil, false
+	}
+	start := s.pos + 1
+	for i := start; i < len(s.data); i++ {
+		switch s.data[i] {
+		case '\\':
+			i++
+		case '"':
+			s.pos = i + 1
+			return s.data[start:i], true
+		}
+	}
+	return nil, false
+}
+
+// value skips any value.
+func (s *jsonScanner) value() bool {
+	switch s.peek() {
+	case '{':
+		return s.object(func([]byte) bool { return s.value() })
+	case '[':
+		return s.array(s.value)
+	case '"':
+		_, ok := s.str()
+		return ok
+	case 0:
+		return false
+	}
+	// Number, true, false or null
+	start := s.pos
+	for s.pos < len(s.data) && bytes.IndexByte([]byte(",}] \t\r\n"), s.data[s.pos]) < 0 {
+		s.pos++
+	}
+	return s.pos > start
+}
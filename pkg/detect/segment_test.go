@@ -0,0 +1,213 @@
+package detect
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const (
+	segGoCode = `package main
+
+import "fmt"
+
+func main() {
+	for i := 0; i < 3; i++ {
+		fmt.Println("hello", i)
+	}
+}
+`
+	segPyCode = `import os
+
+def walk(root):
+    for name in os.listdir(root):
+        if name.startswith("."):
+            continue
+        print(name)
+`
+	segJSCode = `const items = document.querySelectorAll(".item");
+items.forEach((item) => {
+  item.addEventListener("click", () => console.log(item.id));
+});
+`
+	segProse = "This guide explains how to install the tool and how to use it on\n" +
+		"your own projects, with a few examples in different languages.\n\n"
+)
+
+// segmentAt returns the segment containing the first occurrence of marker.
+func segmentAt(t *testing.T, data []byte, segs []Segment, marker string) Segment {
+	t.Helper()
+	i := bytes.Index(data, []byte(marker))
+	if i < 0 {
+		t.Fatalf("marker %q not in data", marker)
+	}
+	for _, s := range segs {
+		if i >= s.Offset && i < s.Offset+s.Length {
+			return s
+		}
+	}
+	t.Fatalf("no segment contains %q", marker)
+	return Segment{}
+}
+
+// checkCoverage checks that segments cover data without gaps or overlaps.
+func checkCoverage(t *testing.T, data []byte, segs []Segment) {
+	t.Helper()
+	end := 0
+	for _, s := range segs {
+		if s.Offset != end || s.Length <= 0 {
+			t.Fatalf("segment at %d+%d after end %d", s.Offset, s.Length, end)
+		}
+		end += s.Length
+	}
+	if end != len(data) {
+		t.Fatalf("segments end at %d, want %d", end, len(data))
+	}
+}
+
+func TestSegments(t *testing.T) {
+	type region struct {
+		marker string
+		typ    Type
+		lang   CodeLang
+		fmt    DataFormat
+	}
+	testCases := []struct {
+		name    string
+		data    string
+		regions []region
+	}{
+		{
+			name: "Markdown",
+			data: "# Guide\n\n" + segProse + "```go\n" + segGoCode + "```\n\n" + segProse +
+				"~~~ {.python}\n" + segPyCode + "~~~\n\n" + segProse,
+			regions: []region{
+				{"# Guide", TypeText, CodeLangUnknown, DataFormatNone},
+				{"package main", TypeCode, CodeLangGo, DataFormatNone},
+				{"def walk", TypeCode, CodeLangPython, DataFormatNone},
+			},
+		},
+		{
+			name: "Markdown unlabelled and unclosed",
+			data: "# Notes\n\n" + segProse + "```\n" + segGoCode + "```\n\n" + segProse + "```python\n" + segPyCode,
+			regions: []region{
+				{"# Notes", TypeText, CodeLangUnknown, DataFormatNone},
+				{"package main", TypeCode, CodeLangGo, DataFormatNone},
+				{"def walk", TypeCode, CodeLangPython, DataFormatNone},
+			},
+		},
+		{
+			name: "HTML",
+			data: "<!DOCTYPE html>\n<html><head><title>Demo</title>\n" +
+				"<script type=\"application/ld+json\">{\"@context\": \"https://schema.org\", \"@type\": \"WebSite\", \"name\": \"Demo\"}</script>\n" +
+				"<script type=\"text/x-template\"><div class=\"item\">{{ name }} is a template for the list, not a script</div></script>\n" +
+				"<SCRIPT>\n" + segJSCode + "</SCRIPT>\n</head>\n<body>\n<p>" + segProse + "</p>\n" +
+				"<?php echo htmlspecialchars($user->name); foreach ($items as $item) { echo $item; } ?>\n</body></html>\n",
+			regions: []region{
+				{"<title>", TypeText, CodeLangUnknown, DataFormatNone},
+				{"@context", TypeCode, CodeLangUnknown, DataFormatJSON},
+				{"{{ name }}", TypeText, CodeLangUnknown, DataFormatNone},
+				{"querySelectorAll", TypeCode, CodeLangJavaScript, DataFormatNone},
+				{"htmlspecialchars", TypeCode, CodeLangPHP, DataFormatNone},
+			},
+		},
+		{
+			name: "notebook",
+			data: `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": ["# Listing files\n", "` + strings.TrimSpace(segProse) + `"]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {},
+   "outputs": [{"name": "stdout", "output_type": "stream", "text": ["a.txt\n", "b.txt\n"]}],
+   "source": ["import os\n", "\n", "def walk(root):\n", "    for name in os.listdir(root):\n", "        print(name)\n"]
+  }
+ ],
+ "metadata": {"kernelspec": {"display_name": "Python 3", "language": "python", "name": "python3"}},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`,
+			regions: []region{
+				{"# Listing files", TypeCode, CodeLangUnknown, DataFormatJSON},
+				{"def walk", TypeCode, CodeLangPython, DataFormatNone},
+				{"nbformat_minor", TypeCode, CodeLangUnknown, DataFormatJSON},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := []byte(tc.data)
+			segs := Segments(data)
+			checkCoverage(t, data, segs)
+			for _, r := range tc.regions {
+				s := segmentAt(t, data, segs, r.marker)
+				if s.Type != r.typ || s.Language != r.lang || s.DataFmt != r.fmt {
+					t.Errorf("%q: got %v/%v/%v, want %v/%v/%v",
+						r.marker, s.Type, s.Language, s.DataFmt, r.typ, r.lang, r.fmt)
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentsSingleRegion(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{"plain code", segGoCode},
+		{"Markdown without code", "# Title\n\n" + segProse + segProse},
+		{"JSON", `{"cells": [], "name": "not a notebook"}`},
+		{"broken notebook", `{"cells": [{"cell_type": "code", "source": [`},
+		// Blocks shorter than minSegment stay part of the prose
+		{"Markdown short block", "# Title\n\n" + segProse + "```\nls\n```\n" + segProse},
+	}
+
+	for _, tc := range testCases {
+		if segs := Segments([]byte(tc.data)); segs != nil {
+			t.Errorf("%s: got %d segments, want none", tc.name, len(segs))
+		}
+	}
+}
+
+func TestDetectSegments(t *testing.T) {
+	data := []byte("# Guide\n\n" + segProse + "```go\n" + segGoCode + "```\n\n" + segProse)
+	profile := Detect(data)
+	if len(profile.Segments) != 3 {
+		t.Fatalf("got %d segments, want 3", len(profile.Segments))
+	}
+	if profile.Segments[1].Language != CodeLangGo {
+		t.Errorf("code block: got %v, want Go", profile.Segments[1].Language)
+	}
+
+	if profile := Detect([]byte(segGoCode)); profile.Segments != nil {
+		t.Errorf("plain code: got %d segments, want none", len(profile.Segments))
+	}
+}
+
+func TestFenceMarker(t *testing.T) {
+	testCases := []struct {
+		line string
+		want string
+	}{
+		{"```go\n", "```"},
+		{"~~~~\n", "~~~~"},
+		{"``not a fence\n", ""},
+		{"``` has ` backtick\n", ""},
+		{"~~~ tilde ` fine\n", "~~~"},
+		{"text\n", ""},
+	}
+
+	for _, tc := range testCases {
+		if got := string(fenceMarker([]byte(tc.line))); got != tc.want {
+			t.Errorf("fenceMarker(%q): got %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package git
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRepo builds a repository with the git command: two commits that
+// edit a file (so repacking produces deltas), an executable, a symlink, a
+// subdirectory and an annotated tag.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE=1700000000 +0100", "GIT_COMMITTER_DATE=1700000000 +0100")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string, mode os.FileMode) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(p), 0755)
+		if err := os.WriteFile(p, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	body := strings.Repeat("line of source code that repeats across versions\n", 200)
+	write("main.go", "package main\n"+body, 0644)
+	write("pkg/util/util.go", "package util\n", 0644)
+	run("add", ".")
+	run("commit", "-q", "-m", "first")
+
+	write("main.go", "package main\n// edited\n"+body+"func main() {}\n", 0644)
+	write("build.sh", "#!/bin/sh\necho build\n", 0755)
+	if err := os.Symlink("main.go", filepath.Join(dir, "link.go")); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "second")
+	run("tag", "-a", "-m", "release", "v1.0")
+	return dir
+}
+
+// gitOutput runs a git command in dir and returns its raw output.
+func gitOutput(t *testing.T, dir string, args ...string) []byte {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v: %v", args, err)
+	}
+	return out
+}
+
+func TestReadRepository(t *testing.T) {
+	dir := newTestRepo(t)
+
+	check := func(t *testing.T) {
+		r, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		defer r.Close()
+
+		for _, rev := range []string{"HEAD", "main", "v1.0", "HEAD~1", "HEAD^", "v1.0^{}", "main^{tree}", "HEAD:pkg"} {
+			spec := rev + "^{tree}"
+			if strings.Contains(rev, ":") {
+				spec = rev
+			}
+			want := strings.TrimSpace(string(gitOutput(t, dir, "rev-parse", spec)))
+			tree, _, err := r.ResolveTree(rev)
+			if err != nil {
+				t.Errorf("ResolveTree(%q): %v", rev, err)
+				continue
+			}
+			if tree.String() != want {
+				t.Errorf("ResolveTree(%q) = %s, want %s", rev, tree, want)
+			}
+		}
+
+		head := strings.TrimSpace(string(gitOutput(t, dir, "rev-parse", "HEAD")))
+		if id, err := r.Resolve(head[:8]); err != nil || id.String() != head {
+			t.Errorf("Resolve(abbreviated) = %s, %v", id, err)
+		}
+		if _, err := r.Resolve("no-such-branch"); err == nil {
+			t.Error("Resolve accepted a missing ref")
+		}
+
+		tree, commit, err := r.ResolveTree("v1.0")
+		if err != nil {
+			t.Fatalf("ResolveTree: %v", err)
+		}
+		if commit == nil || commit.ID.String() != head || commit.CommitterTime.Unix() != 1700000000 {
+			t.Errorf("commit = %+v", commit)
+		}
+		if _, offset := commit.CommitterTime.Zone(); offset != 3600 {
+			t.Errorf("committer zone offset %d, want 3600", offset)
+		}
+
+		modes := make(map[string]uint32)
+		err = r.Walk(tree, func(name string, entry TreeEntry) error {
+			modes[name] = entry.Mode
+			if entry.IsTree() {
+				return nil
+			}
+			typ, data, err := r.Object(entry.ID)
+			if err != nil {
+				return err
+			}
+			if typ != ObjectBlob {
+				t.Errorf("%s: type %v", name, typ)
+			}
+			if want := gitOutput(t, dir, "cat-file", "blob", entry.ID.String()); !bytes.Equal(data, want) {
+				t.Errorf("%s: content differs from git cat-file", name)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Walk: %v", err)
+		}
+		wantModes := map[string]uint32{
+			"build.sh": ModeExecutable, "link.go": ModeSymlink, "main.go": ModeBlob,
+			"pkg": ModeTree, "pkg/util": ModeTree, "pkg/util/util.go": ModeBlob,
+		}
+		for name, mode := range wantModes {
+			if modes[name] != mode {
+				t.Errorf("%s: mode %o, want %o", name, modes[name], mode)
+			}
+		}
+		if len(modes) != len(wantModes) {
+			t.Errorf("walked %d entries, want %d", len(modes), len(wantModes))
+		}
+	}
+
+	t.Run("loose", check)
+	gitOutput(t, dir, "repack", "-adf", "-q", "--depth=50")
+	gitOutput(t, dir, "pack-refs", "--all")
+	if loose, _ := filepath.Glob(filepath.Join(dir, ".git/objects/??/*")); len(loose) != 0 {
+		t.Fatalf("%d loose objects left after repack", len(loose))
+	}
+	t.Run("packed", check)
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("0123456789abcdef")
+	delta := []byte{
+		16, 11, // base and result sizes
+		0x91, 10, 6, // copy offset 10, size 6: "abcdef"
+		3, 'x', 'y', 'z', // insert "xyz"
+		0x90, 2, // copy offset 0, size 2: "01"
+	}
+	got, err := applyDelta(base, delta)
+	if err != nil || string(got) != "abcdefxyz01" {
+		t.Errorf("applyDelta = %q, %v", got, err)
+	}
+
+	delta[1] = 12 // result size mismatch
+	if _, err := applyDelta(base, delta); err == nil {
+		t.Error("wrong result size accepted")
+	}
+	if _, err := applyDelta(base[:8], []byte{16, 1, 1, 'x'}); err == nil {
+		t.Error("wrong base size accepted")
+	}
+}
+
+func TestSplitSuffixes(t *testing.T) {
+	base, ops := splitSuffixes("v1.0^{commit}~2^2^")
+	if base != "v1.0" || strings.Join(ops, " ") != "^{commit} ~2 ^2 ^" {
+		t.Errorf("splitSuffixes = %q, %q", base, ops)
+	}
+}
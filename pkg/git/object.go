@@ -0,0 +1,212 @@
+// Package git reads objects from a git repository without a checkout or a
+// git binary: loose objects, packfiles (including delta chains) and refs.
+// It is enough to resolve a revision and walk its tree, which is what enz
+// needs to archive a commit directly.
+package git
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned for objects and revisions that do not exist.
+	ErrNotFound = errors.New("git: not found")
+
+	// ErrCorrupt is returned for malformed objects, packs and indexes.
+	ErrCorrupt = errors.New("git: corrupt object data")
+
+	// SkipTree is returned by a Walk callback to skip the contents of the
+	// tree entry it was called with.
+	SkipTree = errors.New("git: skip this tree")
+)
+
+// ID is a SHA-1 object name.
+type ID [20]byte
+
+// ParseID parses a 40-digit hexadecimal object name.
+func ParseID(s string) (ID, error) {
+	var id ID
+	if len(s) != 2*len(id) {
+		return id, fmt.Errorf("git: invalid object name %q", s)
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return id, fmt.Errorf("git: invalid object name %q", s)
+	}
+	return id, nil
+}
+
+// String returns the hexadecimal form of the ID.
+func (id ID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// ObjectType is the type of a git object.
+type ObjectType int
+
+const (
+	ObjectCommit ObjectType = 1
+	ObjectTree   ObjectType = 2
+	ObjectBlob   ObjectType = 3
+	ObjectTag    ObjectType = 4
+)
+
+func (t ObjectType) String() string {
+	switch t {
+	case ObjectCommit:
+		return "commit"
+	case ObjectTree:
+		return "tree"
+	case ObjectBlob:
+		return "blob"
+	case ObjectTag:
+		return "tag"
+	default:
+		return "unknown"
+	}
+}
+
+// parseObjectType parses the type name of a loose object header.
+func parseObjectType(name string) (ObjectType, bool) {
+	for t := ObjectCommit; t <= ObjectTag; t++ {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// Tree entry modes. Git only records these; permissions other than the
+// executable bit are not tracked.
+const (
+	ModeTree       = 0040000
+	ModeBlob       = 0100644
+	ModeExecutable = 0100755
+	ModeSymlink    = 0120000
+	ModeSubmodule  = 0160000
+)
+
+// TreeEntry is one entry of a tree object.
+type TreeEntry struct {
+	Name string
+	Mode uint32
+	ID   ID
+}
+
+// IsTree reports whether the entry is a subdirectory.
+func (e TreeEntry) IsTree() bool {
+	return e.Mode&0170000 == ModeTree
+}
+
+// parseTree parses the binary entries of a tree object: "mode name\0"
+// followed by the 20-byte object name.
+func parseTree(data []byte) ([]TreeEntry, error) {
+	var entries []TreeEntry
+	for len(data) > 0 {
+		sp := bytes.IndexByte(data, ' ')
+		if sp < 0 {
+			return nil, ErrCorrupt
+		}
+		mode, err := strconv.ParseUint(string(data[:sp]), 8, 32)
+		if err != nil {
+			return nil, ErrCorrupt
+		}
+		data = data[sp+1:]
+		nul := bytes.IndexByte(data, 0)
+		if nul < 0 || len(data) < nul+1+len(ID{}) {
+			return nil, ErrCorrupt
+		}
+		entry := TreeEntry{Name: string(data[:nul]), Mode: uint32(mode)}
+		copy(entry.ID[:], data[nul+1:])
+		entries = append(entries, entry)
+		data = data[nul+1+len(ID{}):]
+	}
+	return entries, nil
+}
+
+// Commit holds the fields of a commit object that archiving needs.
+type Commit struct {
+	ID            ID
+	Tree          ID
+	Parents       []ID
+	CommitterTime time.Time
+	Message       string
+}
+
+// parseCommit parses a commit object's headers and message.
+func parseCommit(id ID, data []byte) (*Commit, error) {
+	c := &Commit{ID: id}
+	haveTree := false
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		if nl < 0 {
+			return nil, ErrCorrupt
+		}
+		line := string(data[:nl])
+		data = data[nl+1:]
+		if line == "" {
+			c.Message = string(data)
+			break
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "tree":
+			tree, err := ParseID(value)
+			if err != nil {
+				return nil, ErrCorrupt
+			}
+			c.Tree, haveTree = tree, true
+		case "parent":
+			parent, err := ParseID(value)
+			if err != nil {
+				return nil, ErrCorrupt
+			}
+			c.Parents = append(c.Parents, parent)
+		case "committer":
+			c.CommitterTime = parseSignatureTime(value)
+		}
+	}
+	if !haveTree {
+		return nil, ErrCorrupt
+	}
+	return c, nil
+}
+
+// parseSignatureTime extracts the time from "Name <email> 1700000000 +0100".
+func parseSignatureTime(sig string) time.Time {
+	gt := strings.LastIndexByte(sig, '>')
+	if gt < 0 {
+		return time.Time{}
+	}
+	var sec int64
+	var zone string
+	if _, err := fmt.Sscanf(sig[gt+1:], "%d %s", &sec, &zone); err != nil {
+		return time.Time{}
+	}
+	t := time.Unix(sec, 0)
+	if offset, err := strconv.Atoi(zone); err == nil && len(zone) == 5 {
+		minutes := offset/100*60 + offset%100
+		t = t.In(time.FixedZone(zone, minutes*60))
+	}
+	return t
+}
+
+// parseTagTarget returns the object an annotated tag points to.
+func parseTagTarget(data []byte) (ID, error) {
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		if nl <= 0 {
+			break
+		}
+		if key, value, _ := strings.Cut(string(data[:nl]), " "); key == "object" {
+			return ParseID(value)
+		}
+		data = data[nl+1:]
+	}
+	return ID{}, ErrCorrupt
+}
@@ -0,0 +1,327 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Packed object types beyond the four base types.
+const (
+	packOfsDelta = 6 // delta against an object earlier in the same pack
+	packRefDelta = 7 // delta against an object named by ID
+)
+
+// maxDeltaDepth bounds delta chains so that a corrupt pack whose deltas
+// form a cycle fails instead of recursing without end. git writes chains of
+// at most 50 by default and refuses --depth above 4095; the bound is well
+// above both so that packs from other writers still read.
+const maxDeltaDepth = 10000
+
+// pack is an opened packfile and its index.
+type pack struct {
+	file    *os.File
+	ids     []ID     // sorted object names
+	offsets []uint64 // offset in the pack of ids[i]
+	cache   map[uint64]cachedObject
+}
+
+type cachedObject struct {
+	typ  ObjectType
+	data []byte
+}
+
+// maxCached bounds the delta base cache of each pack, in objects.
+const maxCached = 256
+
+// openPack opens name.pack using name.idx (version 1 or 2).
+func openPack(name string) (*pack, error) {
+	idx, err := os.ReadFile(name + ".idx")
+	if err != nil {
+		return nil, err
+	}
+	p := &pack{cache: make(map[uint64]cachedObject)}
+	if err := p.readIndex(idx); err != nil {
+		return nil, fmt.Errorf("%s.idx: %w", name, err)
+	}
+	f, err := os.Open(name + ".pack")
+	if err != nil {
+		return nil, err
+	}
+	var header [12]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil || string(header[:4]) != "PACK" {
+		f.Close()
+		return nil, fmt.Errorf("%s.pack: %w", name, ErrCorrupt)
+	}
+	if v := binary.BigEndian.Uint32(header[4:]); v != 2 && v != 3 {
+		f.Close()
+		return nil, fmt.Errorf("%s.pack: unsupported version %d", name, v)
+	}
+	p.file = f
+	return p, nil
+}
+
+// readIndex parses a pack index. Version 2 starts with "\377tOc"; version
+// 1 has no header and stores 4-byte offsets next to each name.
+func (p *pack) readIndex(idx []byte) error {
+	const fanoutSize = 256 * 4
+	if len(idx) >= 8 && string(idx[:4]) == "\377tOc" {
+		if binary.BigEndian.Uint32(idx[4:]) != 2 {
+			return fmt.Errorf("unsupported index version %d", binary.BigEndian.Uint32(idx[4:]))
+		}
+		idx = idx[8:]
+		if len(idx) < fanoutSize {
+			return ErrCorrupt
+		}
+		n := int(binary.BigEndian.Uint32(idx[fanoutSize-4:]))
+		names := idx[fanoutSize:]
+		if len(names) < n*(20+4+4) {
+			return ErrCorrupt
+		}
+		offsets := names[n*20+n*4:]
+		large := offsets[n*4:]
+		p.ids = make([]ID, n)
+		p.offsets = make([]uint64, n)
+		for i := 0; i < n; i++ {
+			copy(p.ids[i][:], names[i*20:])
+			off := binary.BigEndian.Uint32(offsets[i*4:])
+			if off&0x80000000 == 0 {
+				p.offsets[i] = uint64(off)
+				continue
+			}
+			j := int(off &^ 0x80000000)
+			if len(large) < (j+1)*8 {
+				return ErrCorrupt
+			}
+			p.offsets[i] = binary.BigEndian.Uint64(large[j*8:])
+		}
+		return nil
+	}
+
+	if len(idx) < fanoutSize {
+		return ErrCorrupt
+	}
+	n := int(binary.BigEndian.Uint32(idx[fanoutSize-4:]))
+	entries := idx[fanoutSize:]
+	if len(entries) < n*24 {
+		return ErrCorrupt
+	}
+	p.ids = make([]ID, n)
+	p.offsets = make([]uint64, n)
+	for i := 0; i < n; i++ {
+		p.offsets[i] = uint64(binary.BigEndian.Uint32(entries[i*24:]))
+		copy(p.ids[i][:], entries[i*24+4:])
+	}
+	return nil
+}
+
+// find returns the pack offset of an object.
+func (p *pack) find(id ID) (uint64, bool) {
+	i := sort.Search(len(p.ids), func(i int) bool {
+		return bytes.Compare(p.ids[i][:], id[:]) >= 0
+	})
+	if i < len(p.ids) && p.ids[i] == id {
+		return p.offsets[i], true
+	}
+	return 0, false
+}
+
+// withPrefix appends the objects whose hexadecimal name starts with prefix.
+func (p *pack) withPrefix(prefix string, found map[ID]bool) {
+	for _, id := range p.ids {
+		if strings.HasPrefix(id.String(), prefix) {
+			found[id] = true
+		}
+	}
+}
+
+// read returns the object at offset, applying deltas. Bases named by ID
+// (REF_DELTA, used by thin packs) are looked up through r.
+func (p *pack) read(r *Repository, offset uint64, depth int) (ObjectType, []byte, error) {
+	if obj, ok := p.cache[offset]; ok {
+		return obj.typ, obj.data, nil
+	}
+	if depth > maxDeltaDepth {
+		return 0, nil, fmt.Errorf("git: delta chain too long: %w", ErrCorrupt)
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(p.file, int64(offset), 1<<62))
+
+	// Header: type in bits 4-6 of the first byte, size as a little-endian
+	// base-128 number in the low 4 bits and following bytes
+	b, err := br.ReadByte()
+	if err != nil {
+		return 0, nil, ErrCorrupt
+	}
+	kind := int(b>>4) & 7
+	size := uint64(b & 0x0f)
+	for shift := uint(4); b&0x80 != 0; shift += 7 {
+		if b, err = br.ReadByte(); err != nil || shift > 63 {
+			return 0, nil, ErrCorrupt
+		}
+		size |= uint64(b&0x7f) << shift
+	}
+
+	var typ ObjectType
+	var data []byte
+	switch kind {
+	case int(ObjectCommit), int(ObjectTree), int(ObjectBlob), int(ObjectTag):
+		typ = ObjectType(kind)
+		if data, err = inflate(br, size); err != nil {
+			return 0, nil, err
+		}
+
+	case packOfsDelta:
+		// Base offset: big-endian base-128 with an implicit +1 per
+		// continuation byte, subtracted from this object's offset
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, nil, ErrCorrupt
+		}
+		rel := uint64(b & 0x7f)
+		for b&0x80 != 0 {
+			if b, err = br.ReadByte(); err != nil || rel >= 1<<56 {
+				return 0, nil, ErrCorrupt
+			}
+			rel = (rel+1)<<7 | uint64(b&0x7f)
+		}
+		if rel == 0 || rel > offset {
+			return 0, nil, ErrCorrupt
+		}
+		delta, err := inflate(br, size)
+		if err != nil {
+			return 0, nil, err
+		}
+		baseType, base, err := p.read(r, offset-rel, depth+1)
+		if err != nil {
+			return 0, nil, err
+		}
+		typ = baseType
+		if data, err = applyDelta(base, delta); err != nil {
+			return 0, nil, err
+		}
+
+	case packRefDelta:
+		var baseID ID
+		if _, err := io.ReadFull(br, baseID[:]); err != nil {
+			return 0, nil, ErrCorrupt
+		}
+		delta, err := inflate(br, size)
+		if err != nil {
+			return 0, nil, err
+		}
+		baseType, base, err := r.object(baseID, depth+1)
+		if err != nil {
+			return 0, nil, err
+		}
+		typ = baseType
+		if data, err = applyDelta(base, delta); err != nil {
+			return 0, nil, err
+		}
+
+	default:
+		return 0, nil, fmt.Errorf("git: unknown packed object type %d: %w", kind, ErrCorrupt)
+	}
+
+	// Only delta bases are cached: chains often share them
+	if depth > 0 {
+		if len(p.cache) >= maxCached {
+			for k := range p.cache {
+				delete(p.cache, k)
+			}
+		}
+		p.cache[offset] = cachedObject{typ, data}
+	}
+	return typ, data, nil
+}
+
+// inflate decompresses exactly size bytes of zlib data.
+func inflate(r io.Reader, size uint64) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	defer zr.Close()
+	// Not preallocated: a corrupt header must not cause a huge allocation
+	data, err := io.ReadAll(io.LimitReader(zr, int64(size&(1<<62-1))+1))
+	if err != nil || uint64(len(data)) != size {
+		return nil, ErrCorrupt
+	}
+	return data, nil
+}
+
+// applyDelta rebuilds an object from its base and a git delta: the base
+// and result sizes, then instructions that either copy a range of the base
+// (high bit set; the low 7 bits say which offset and size bytes follow) or
+// insert the next 1-127 literal bytes.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	baseSize, delta, ok := deltaSize(delta)
+	if !ok || baseSize != uint64(len(base)) {
+		return nil, ErrCorrupt
+	}
+	resultSize, delta, ok := deltaSize(delta)
+	if !ok {
+		return nil, ErrCorrupt
+	}
+
+	result := make([]byte, 0, min(resultSize, 1<<24))
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&0x80 != 0:
+			var off, n uint64
+			for i := uint(0); i < 7; i++ {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				if len(delta) == 0 {
+					return nil, ErrCorrupt
+				}
+				if i < 4 {
+					off |= uint64(delta[0]) << (8 * i)
+				} else {
+					n |= uint64(delta[0]) << (8 * (i - 4))
+				}
+				delta = delta[1:]
+			}
+			if n == 0 {
+				n = 0x10000
+			}
+			if off+n > uint64(len(base)) || uint64(len(result))+n > resultSize {
+				return nil, ErrCorrupt
+			}
+			result = append(result, base[off:off+n]...)
+		case op != 0:
+			if int(op) > len(delta) || uint64(len(result))+uint64(op) > resultSize {
+				return nil, ErrCorrupt
+			}
+			result = append(result, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, ErrCorrupt
+		}
+	}
+	if uint64(len(result)) != resultSize {
+		return nil, ErrCorrupt
+	}
+	return result, nil
+}
+
+// deltaSize reads a little-endian base-128 size from the start of a delta.
+func deltaSize(delta []byte) (uint64, []byte, bool) {
+	var size uint64
+	for i, shift := 0, uint(0); i < len(delta) && shift < 64; i, shift = i+1, shift+7 {
+		size |= uint64(delta[i]&0x7f) << shift
+		if delta[i]&0x80 == 0 {
+			return size, delta[i+1:], true
+		}
+	}
+	return 0, nil, false
+}
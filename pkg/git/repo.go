@@ -0,0 +1,506 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Repository is a git repository opened for reading.
+type Repository struct {
+	gitDir    string   // the .git directory (or the bare repository)
+	commonDir string   // shared refs and objects of linked worktrees
+	objectDir []string // objects directory and its alternates
+	packs     []*pack
+}
+
+// Open opens the repository at dir: a working tree containing .git (a
+// directory, or a file pointing to one as in worktrees and submodules), or
+// a bare repository.
+func Open(dir string) (*Repository, error) {
+	gitDir := filepath.Join(dir, ".git")
+	if fi, err := os.Stat(gitDir); err == nil && !fi.IsDir() {
+		data, err := os.ReadFile(gitDir)
+		if err != nil {
+			return nil, err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !ok {
+			return nil, fmt.Errorf("git: %s: not a gitdir file", gitDir)
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		gitDir = target
+	} else if err != nil {
+		gitDir = dir // bare repository
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return nil, fmt.Errorf("git: %s is not a git repository", dir)
+	}
+
+	r := &Repository{gitDir: gitDir, commonDir: gitDir}
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		r.commonDir = common
+	}
+	if data, err := os.ReadFile(filepath.Join(r.commonDir, "config")); err == nil {
+		if bytes.Contains(data, []byte("objectformat = sha256")) {
+			return nil, fmt.Errorf("git: SHA-256 repositories are not supported")
+		}
+	}
+
+	r.addObjectDir(filepath.Join(r.commonDir, "objects"), 0)
+	return r, nil
+}
+
+// addObjectDir adds an objects directory, its packs and, recursively, the
+// alternates it lists.
+func (r *Repository) addObjectDir(dir string, depth int) {
+	if depth > 5 {
+		return
+	}
+	r.objectDir = append(r.objectDir, dir)
+	if idx, err := filepath.Glob(filepath.Join(dir, "pack", "*.idx")); err == nil {
+		sort.Strings(idx)
+		for _, name := range idx {
+			if p, err := openPack(strings.TrimSuffix(name, ".idx")); err == nil {
+				r.packs = append(r.packs, p)
+			}
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "info", "alternates"))
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(dir, line)
+		}
+		r.addObjectDir(line, depth+1)
+	}
+}
+
+// Close releases the repository's pack files.
+func (r *Repository) Close() error {
+	var first error
+	for _, p := range r.packs {
+		if err := p.file.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Object returns the type and content of an object.
+func (r *Repository) Object(id ID) (ObjectType, []byte, error) {
+	return r.object(id, 0)
+}
+
+func (r *Repository) object(id ID, depth int) (ObjectType, []byte, error) {
+	hexID := id.String()
+	for _, dir := range r.objectDir {
+		f, err := os.Open(filepath.Join(dir, hexID[:2], hexID[2:]))
+		if err != nil {
+			continue
+		}
+		typ, data, err := readLoose(f)
+		f.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("git: object %s: %w", hexID, err)
+		}
+		return typ, data, nil
+	}
+	for _, p := range r.packs {
+		if offset, ok := p.find(id); ok {
+			typ, data, err := p.read(r, offset, depth)
+			if err != nil {
+				return 0, nil, fmt.Errorf("git: object %s: %w", hexID, err)
+			}
+			return typ, data, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("git: object %s: %w", hexID, ErrNotFound)
+}
+
+// readLoose reads a zlib-compressed loose object: "type size\0" then the
+// content.
+func readLoose(f io.Reader) (ObjectType, []byte, error) {
+	zr, err := zlib.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return 0, nil, ErrCorrupt
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+	header, err := br.ReadString(0)
+	if err != nil {
+		return 0, nil, ErrCorrupt
+	}
+	name, sizeStr, _ := strings.Cut(strings.TrimSuffix(header, "\x00"), " ")
+	typ, ok := parseObjectType(name)
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if !ok || err != nil || size < 0 {
+		return 0, nil, ErrCorrupt
+	}
+	data, err := io.ReadAll(io.LimitReader(br, size+1))
+	if err != nil || int64(len(data)) != size {
+		return 0, nil, ErrCorrupt
+	}
+	return typ, data, nil
+}
+
+// Commit reads a commit object.
+func (r *Repository) Commit(id ID) (*Commit, error) {
+	typ, data, err := r.Object(id)
+	if err != nil {
+		return nil, err
+	}
+	if typ != ObjectCommit {
+		return nil, fmt.Errorf("git: %s is a %s, not a commit", id, typ)
+	}
+	return parseCommit(id, data)
+}
+
+// Tree reads a tree object.
+func (r *Repository) Tree(id ID) ([]TreeEntry, error) {
+	typ, data, err := r.Object(id)
+	if err != nil {
+		return nil, err
+	}
+	if typ != ObjectTree {
+		return nil, fmt.Errorf("git: %s is a %s, not a tree", id, typ)
+	}
+	return parseTree(data)
+}
+
+// Walk calls fn for every entry below a tree, depth first in tree order,
+// with its slash-separated path. A tree entry is passed before its
+// contents, which are skipped if fn returns SkipTree.
+func (r *Repository) Walk(tree ID, fn func(name string, entry TreeEntry) error) error {
+	return r.walk(tree, "", fn)
+}
+
+func (r *Repository) walk(tree ID, prefix string, fn func(string, TreeEntry) error) error {
+	entries, err := r.Tree(tree)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := prefix + entry.Name
+		err := fn(name, entry)
+		if err == SkipTree && entry.IsTree() {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsTree() {
+			if err := r.walk(entry.ID, name+"/", fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveTree resolves a tree-ish, optionally followed by ":path" to
+// select a subdirectory, to a tree. The commit is nil if the revision
+// names a tree rather than a commit or a tag of one.
+func (r *Repository) ResolveTree(rev string) (ID, *Commit, error) {
+	// "rev:path" selects a subtree
+	rev, subPath, hasPath := strings.Cut(rev, ":")
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	id, err := r.Resolve(rev)
+	if err != nil {
+		return ID{}, nil, err
+	}
+	id, err = r.peel(id, ObjectTree, true)
+	if err != nil {
+		return ID{}, nil, err
+	}
+	commit, tree, err := r.commitTree(id)
+	if err != nil {
+		return ID{}, nil, err
+	}
+
+	if hasPath && strings.Trim(subPath, "/") != "" {
+		for _, part := range strings.Split(strings.Trim(subPath, "/"), "/") {
+			entries, err := r.Tree(tree)
+			if err != nil {
+				return ID{}, nil, err
+			}
+			found := false
+			for _, entry := range entries {
+				if entry.Name == part && entry.IsTree() {
+					tree, found = entry.ID, true
+					break
+				}
+			}
+			if !found {
+				return ID{}, nil, fmt.Errorf("git: %s:%s: %w", rev, subPath, ErrNotFound)
+			}
+		}
+	}
+	return tree, commit, nil
+}
+
+// commitTree returns the commit (if any) and tree for a commit or tree ID.
+func (r *Repository) commitTree(id ID) (*Commit, ID, error) {
+	typ, data, err := r.Object(id)
+	if err != nil {
+		return nil, ID{}, err
+	}
+	switch typ {
+	case ObjectCommit:
+		commit, err := parseCommit(id, data)
+		if err != nil {
+			return nil, ID{}, err
+		}
+		return commit, commit.Tree, nil
+	case ObjectTree:
+		return nil, id, nil
+	default:
+		return nil, ID{}, fmt.Errorf("git: %s is a %s, not a tree-ish", id, typ)
+	}
+}
+
+// Resolve resolves a revision to an object name. It accepts full and
+// abbreviated object names, HEAD and other refs (searched like git does:
+// the name itself, refs/, refs/tags/, refs/heads/, refs/remotes/), and the
+// suffixes ^{}, ^{commit}, ^{tree}, ^N and ~N.
+func (r *Repository) Resolve(rev string) (ID, error) {
+	base, ops := splitSuffixes(rev)
+	id, err := r.resolveName(base)
+	if err != nil {
+		return ID{}, err
+	}
+	for _, op := range ops {
+		switch {
+		case op == "^{}":
+			id, err = r.peel(id, 0, false)
+		case op == "^{commit}":
+			id, err = r.peel(id, ObjectCommit, false)
+		case op == "^{tree}":
+			id, err = r.peel(id, ObjectTree, false)
+		case op[0] == '~':
+			n := 1
+			if len(op) > 1 {
+				if n, err = strconv.Atoi(op[1:]); err != nil {
+					return ID{}, fmt.Errorf("git: bad revision %q", rev)
+				}
+			}
+			for i := 0; i < n && err == nil; i++ {
+				id, err = r.parent(id, 1)
+			}
+		case op[0] == '^':
+			n := 1
+			if len(op) > 1 {
+				if n, err = strconv.Atoi(op[1:]); err != nil {
+					return ID{}, fmt.Errorf("git: bad revision %q", rev)
+				}
+			}
+			if n > 0 {
+				id, err = r.parent(id, n)
+			} else {
+				id, err = r.peel(id, ObjectCommit, false)
+			}
+		}
+		if err != nil {
+			return ID{}, err
+		}
+	}
+	return id, nil
+}
+
+// splitSuffixes separates "name^{tree}~2^" into the name and its
+// navigation suffixes. Ref names cannot contain "^" or "~".
+func splitSuffixes(rev string) (string, []string) {
+	i := strings.IndexAny(rev, "^~")
+	if i < 0 {
+		return rev, nil
+	}
+	base, rest := rev[:i], rev[i:]
+	var ops []string
+	for rest != "" {
+		n := 1
+		if strings.HasPrefix(rest, "^{") {
+			if end := strings.IndexByte(rest, '}'); end > 0 {
+				n = end + 1
+			}
+		} else {
+			for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+				n++
+			}
+		}
+		ops = append(ops, rest[:n])
+		rest = rest[n:]
+	}
+	return base, ops
+}
+
+// parent returns the n-th parent of a commit-ish.
+func (r *Repository) parent(id ID, n int) (ID, error) {
+	id, err := r.peel(id, ObjectCommit, false)
+	if err != nil {
+		return ID{}, err
+	}
+	commit, err := r.Commit(id)
+	if err != nil {
+		return ID{}, err
+	}
+	if n > len(commit.Parents) {
+		return ID{}, fmt.Errorf("git: %s has no parent %d: %w", id, n, ErrNotFound)
+	}
+	return commit.Parents[n-1], nil
+}
+
+// peel follows annotated tags until reaching an object of type want (any
+// non-tag if want is 0). With stopAtCommit, a commit is accepted when a
+// tree is wanted, so callers can keep the commit for its ID and time.
+func (r *Repository) peel(id ID, want ObjectType, stopAtCommit bool) (ID, error) {
+	for depth := 0; depth < 100; depth++ {
+		typ, data, err := r.Object(id)
+		if err != nil {
+			return ID{}, err
+		}
+		switch {
+		case typ == ObjectTag:
+			if id, err = parseTagTarget(data); err != nil {
+				return ID{}, err
+			}
+			continue
+		case want == 0 || typ == want:
+			return id, nil
+		case typ == ObjectCommit && want == ObjectTree:
+			if stopAtCommit {
+				return id, nil
+			}
+			commit, err := parseCommit(id, data)
+			if err != nil {
+				return ID{}, err
+			}
+			return commit.Tree, nil
+		}
+		return ID{}, fmt.Errorf("git: %s is a %s, not a %s", id, typ, want)
+	}
+	return ID{}, fmt.Errorf("git: tag chain too long: %w", ErrCorrupt)
+}
+
+// resolveName resolves an object name or ref name without suffixes.
+func (r *Repository) resolveName(name string) (ID, error) {
+	if len(name) == 40 {
+		if id, err := ParseID(name); err == nil {
+			return id, nil
+		}
+	}
+	for _, candidate := range []string{
+		name,
+		"refs/" + name,
+		"refs/tags/" + name,
+		"refs/heads/" + name,
+		"refs/remotes/" + name,
+		"refs/remotes/" + name + "/HEAD",
+	} {
+		if id, ok, err := r.readRef(candidate, 0); err != nil {
+			return ID{}, err
+		} else if ok {
+			return id, nil
+		}
+	}
+	if len(name) >= 4 && len(name) < 40 {
+		if _, err := hex.DecodeString(name + name[:len(name)%2]); err == nil {
+			return r.resolvePrefix(strings.ToLower(name))
+		}
+	}
+	return ID{}, fmt.Errorf("git: revision %q: %w", name, ErrNotFound)
+}
+
+// resolvePrefix finds the unique object whose name starts with prefix.
+func (r *Repository) resolvePrefix(prefix string) (ID, error) {
+	found := make(map[ID]bool)
+	for _, dir := range r.objectDir {
+		names, _ := filepath.Glob(filepath.Join(dir, prefix[:2], prefix[2:]+"*"))
+		for _, name := range names {
+			if id, err := ParseID(prefix[:2] + filepath.Base(name)); err == nil {
+				found[id] = true
+			}
+		}
+	}
+	for _, p := range r.packs {
+		p.withPrefix(prefix, found)
+	}
+	switch len(found) {
+	case 0:
+		return ID{}, fmt.Errorf("git: revision %q: %w", prefix, ErrNotFound)
+	case 1:
+		for id := range found {
+			return id, nil
+		}
+	}
+	return ID{}, fmt.Errorf("git: short object name %q is ambiguous", prefix)
+}
+
+// readRef reads a ref from its file or packed-refs, following symbolic
+// refs ("ref: refs/heads/main").
+func (r *Repository) readRef(name string, depth int) (ID, bool, error) {
+	if depth > 10 {
+		return ID{}, false, fmt.Errorf("git: symbolic ref loop at %s", name)
+	}
+	if path.Clean(name) != name || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "..") {
+		return ID{}, false, nil
+	}
+
+	// HEAD and other per-worktree refs live in gitDir, the rest in commonDir
+	dir := r.commonDir
+	if !strings.HasPrefix(name, "refs/") {
+		dir = r.gitDir
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name))); err == nil {
+		value := strings.TrimSpace(string(data))
+		if target, ok := strings.CutPrefix(value, "ref: "); ok {
+			return r.readRef(target, depth+1)
+		}
+		if id, err := ParseID(value); err == nil {
+			return id, true, nil
+		}
+		return ID{}, false, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.commonDir, "packed-refs"))
+	if err != nil {
+		return ID{}, false, nil
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		value, ref, ok := strings.Cut(line, " ")
+		if ok && ref == name {
+			id, err := ParseID(value)
+			if err != nil {
+				return ID{}, false, fmt.Errorf("git: packed-refs: %w", ErrCorrupt)
+			}
+			return id, true, nil
+		}
+	}
+	return ID{}, false, nil
+}